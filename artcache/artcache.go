@@ -0,0 +1,269 @@
+// Package artcache caches resized cover art, in memory and on disk,
+// keyed by video, source file version, and geometry.
+package artcache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/idiomatic/tvql/graph/model"
+	"github.com/idiomatic/tvql/logging"
+	"github.com/idiomatic/tvql/metrics"
+	"golang.org/x/sync/singleflight"
+)
+
+// Source is where artwork comes from, i.e., a model.Library.
+type Source interface {
+	// file holding a video's artwork, if any
+	ArtworkPath(id string) (string, bool)
+	GetArtwork(id string) ([]byte, error)
+}
+
+// Artwork is a (possibly resized and reencoded) image.
+type Artwork struct {
+	Data        []byte
+	ContentType string
+	// strong validator, changing with source file or geometry
+	ETag string
+}
+
+// Cache resizes artwork at most once per source file version and
+// geometry, unless evicted.
+type Cache struct {
+	source Source
+	memory *lru.Cache
+	// disk cache directory (optional)
+	dir string
+	// concurrent misses await one resize
+	resizing singleflight.Group
+}
+
+// New caches up to entries images in memory, and, if dir is set, all
+// on disk.
+func New(source Source, entries int, dir string) (*Cache, error) {
+	memory, err := lru.New(entries)
+	if err != nil {
+		return nil, err
+	}
+	if dir != "" {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return nil, err
+		}
+	}
+	return &Cache{source: source, memory: memory, dir: dir}, nil
+}
+
+// key identifies artwork of a video, from a source file version, at a
+// geometry.
+type key struct {
+	id         string
+	modTime    int64
+	size       int64
+	width      int
+	height     int
+	dpr        float64
+	fit        model.ArtworkFit
+	anchor     model.ArtworkAnchor
+	background string
+	format     model.ArtworkFormat
+	quality    int
+}
+
+func newKey(id string, info os.FileInfo, geometry *model.GeometryFilter) key {
+	// lest artwork fit by another default be recalled
+	k := key{id: id, modTime: info.ModTime().UnixNano(), size: info.Size(), fit: model.DefaultArtworkFit}
+	if geometry != nil {
+		if geometry.Width != nil {
+			k.width = *geometry.Width
+		}
+		if geometry.Height != nil {
+			k.height = *geometry.Height
+		}
+		if geometry.Dpr != nil {
+			k.dpr = *geometry.Dpr
+		}
+		if geometry.Fit != nil {
+			k.fit = *geometry.Fit
+		}
+		if geometry.Anchor != nil {
+			k.anchor = *geometry.Anchor
+		}
+		if geometry.Background != nil {
+			k.background = strings.ToLower(strings.TrimPrefix(*geometry.Background, "#"))
+		}
+		if geometry.Format != nil {
+			k.format = *geometry.Format
+		}
+		if geometry.Quality != nil {
+			k.quality = *geometry.Quality
+		}
+	}
+	return k
+}
+
+// version distinguishes source file versions, geometries (including
+// fit), and encodings of a video's artwork.
+func (k key) version() string {
+	version := fmt.Sprintf("%d-%d-%dx%d", k.modTime, k.size, k.width, k.height)
+	if k.dpr != 0 {
+		version += fmt.Sprintf("@%gx", k.dpr)
+	}
+	version += "-" + strings.ToLower(k.fit.String())
+	if k.anchor != "" {
+		version += "-" + strings.ToLower(k.anchor.String())
+	}
+	if k.background != "" {
+		version += "-" + k.background
+	}
+	if k.format != "" {
+		version += "-" + strings.ToLower(k.format.String())
+	}
+	if k.quality != 0 {
+		version += fmt.Sprintf("-q%d", k.quality)
+	}
+	return version
+}
+
+// String distinguishes artwork across videos, source file versions,
+// and geometries.
+func (k key) String() string {
+	return k.id + "\x00" + k.version()
+}
+
+func (k key) etag() string {
+	digest := sha256.Sum256([]byte(k.String()))
+	return `"` + hex.EncodeToString(digest[:12]) + `"`
+}
+
+// videoDir is where a video's artwork is cached on disk.
+func (c *Cache) videoDir(k key) string {
+	digest := sha256.Sum256([]byte(k.id))
+	return filepath.Join(c.dir, hex.EncodeToString(digest[:12]))
+}
+
+func (c *Cache) diskPath(k key) string {
+	// content type is sniffed upon reading
+	return filepath.Join(c.videoDir(k), k.version())
+}
+
+// ETag returns the validator of a video's artwork at a geometry,
+// without resizing it.
+func (c *Cache) ETag(id string, geometry *model.GeometryFilter) (string, error) {
+	k, err := c.key(id, geometry)
+	if err != nil {
+		return "", err
+	}
+	return k.etag(), nil
+}
+
+func (c *Cache) key(id string, geometry *model.GeometryFilter) (key, error) {
+	path, ok := c.source.ArtworkPath(id)
+	if !ok {
+		return key{}, fmt.Errorf("artwork not found")
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return key{}, err
+	}
+	return newKey(id, info, geometry), nil
+}
+
+// Get returns a video's artwork, resized and encoded per geometry (if
+// any).
+// Artwork of a changed source file is resized anew.
+func (c *Cache) Get(id string, geometry *model.GeometryFilter) (*Artwork, error) {
+	k, err := c.key(id, geometry)
+	if err != nil {
+		return nil, err
+	}
+
+	if cached, ok := c.memory.Get(k); ok {
+		metrics.ArtworkCache.WithLabelValues("memory").Inc()
+		return cached.(*Artwork), nil
+	}
+
+	if c.dir != "" {
+		if data, err := ioutil.ReadFile(c.diskPath(k)); err == nil {
+			metrics.ArtworkCache.WithLabelValues("disk").Inc()
+			artwork := &Artwork{Data: data, ContentType: http.DetectContentType(data), ETag: k.etag()}
+			c.memory.Add(k, artwork)
+			return artwork, nil
+		}
+	}
+
+	metrics.ArtworkCache.WithLabelValues("miss").Inc()
+
+	artwork, err, _ := c.resizing.Do(k.String(), func() (interface{}, error) {
+		return c.resize(k, geometry)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return artwork.(*Artwork), nil
+}
+
+// resize reads, resizes, and caches artwork.
+func (c *Cache) resize(k key, geometry *model.GeometryFilter) (*Artwork, error) {
+	data, err := c.source.GetArtwork(k.id)
+	if err != nil {
+		return nil, err
+	}
+	start := time.Now()
+	data, contentType, err := model.ResizeArtwork(data, geometry)
+	if err != nil {
+		return nil, err
+	}
+	metrics.ArtworkResize.Observe(time.Since(start).Seconds())
+
+	artwork := &Artwork{Data: data, ContentType: contentType, ETag: k.etag()}
+	c.memory.Add(k, artwork)
+	if c.dir != "" {
+		if err := c.save(k, data); err != nil {
+			// merely uncached
+			logging.Default().Warn("artwork cache write failed", "video", k.id, "error", err)
+		}
+	}
+	return artwork, nil
+}
+
+// save writes artwork to disk atomically, removing artwork of prior
+// source file versions.
+func (c *Cache) save(k key, data []byte) error {
+	dir := c.videoDir(k)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	prefix := fmt.Sprintf("%d-%d-", k.modTime, k.size)
+	if infos, err := ioutil.ReadDir(dir); err == nil {
+		for _, info := range infos {
+			// sparing others' temporary files
+			if !strings.HasPrefix(info.Name(), prefix) && !strings.HasPrefix(info.Name(), ".") {
+				os.Remove(filepath.Join(dir, info.Name()))
+			}
+		}
+	}
+
+	tmp, err := ioutil.TempFile(dir, ".artwork.*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), c.diskPath(k))
+}
@@ -0,0 +1,247 @@
+package artcache
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/idiomatic/tvql/graph/model"
+)
+
+// source serves artwork of one video, "v1", from a file.
+type source struct {
+	path string
+	// GetArtwork calls
+	reads int32
+	// if set, GetArtwork awaits its closing
+	gate chan struct{}
+}
+
+func (s *source) ArtworkPath(id string) (string, bool) {
+	return s.path, id == "v1"
+}
+
+func (s *source) GetArtwork(id string) ([]byte, error) {
+	atomic.AddInt32(&s.reads, 1)
+	if s.gate != nil {
+		<-s.gate
+	}
+	return ioutil.ReadFile(s.path)
+}
+
+// writeArtwork writes a JPEG of a color, dated modTime.
+func writeArtwork(t *testing.T, path string, c color.Color, modTime time.Time) {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, 40, 20))
+	for y := 0; y < 20; y++ {
+		for x := 0; x < 40; x++ {
+			img.Set(x, y, c)
+		}
+	}
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(path, modTime, modTime); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func newSource(t *testing.T) *source {
+	t.Helper()
+	s := &source{path: filepath.Join(t.TempDir(), "cover.jpg")}
+	writeArtwork(t, s.path, color.RGBA{R: 255, A: 255}, time.Now().Add(-time.Hour))
+	return s
+}
+
+func width(n int) *model.GeometryFilter {
+	return &model.GeometryFilter{Width: &n}
+}
+
+func get(t *testing.T, c *Cache, geometry *model.GeometryFilter) *Artwork {
+	t.Helper()
+	artwork, err := c.Get("v1", geometry)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return artwork
+}
+
+func TestGetMemory(t *testing.T) {
+	src := newSource(t)
+	c, err := New(src, 4, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	first := get(t, c, width(10))
+	if config, err := jpeg.DecodeConfig(bytes.NewReader(first.Data)); err != nil || config.Width != 10 || config.Height != 5 {
+		t.Errorf("resized to %+v, %v", config, err)
+	}
+	if again := get(t, c, width(10)); again != first || src.reads != 1 {
+		t.Errorf("memory miss: %d reads", src.reads)
+	}
+	if other := get(t, c, width(20)); other.ETag == first.ETag || src.reads != 2 {
+		t.Errorf("another geometry: %d reads, ETag %s", src.reads, other.ETag)
+	}
+
+	if _, err := c.Get("v2", nil); err == nil {
+		t.Error("got artwork of unknown video")
+	}
+}
+
+func TestGetDisk(t *testing.T) {
+	src := newSource(t)
+	dir := t.TempDir()
+	c, err := New(src, 4, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	first := get(t, c, width(10))
+
+	// as if restarted
+	restarted, err := New(src, 4, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second := get(t, restarted, width(10))
+	if src.reads != 1 {
+		t.Errorf("disk miss: %d reads", src.reads)
+	}
+	if !bytes.Equal(second.Data, first.Data) || second.ETag != first.ETag || second.ContentType != "image/jpeg" {
+		t.Errorf("disk hit differs: %s %s, want %s %s", second.ContentType, second.ETag, first.ContentType, first.ETag)
+	}
+}
+
+func TestGetChangedSource(t *testing.T) {
+	src := newSource(t)
+	dir := t.TempDir()
+	c, err := New(src, 4, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	before := get(t, c, width(10))
+	get(t, c, width(20))
+
+	k, err := c.key("v1", width(10))
+	if err != nil {
+		t.Fatal(err)
+	}
+	stale := c.diskPath(k)
+
+	writeArtwork(t, src.path, color.RGBA{B: 255, A: 255}, time.Now())
+	after := get(t, c, width(10))
+	if src.reads != 3 {
+		t.Errorf("changed source not reread: %d reads", src.reads)
+	}
+	if after.ETag == before.ETag || bytes.Equal(after.Data, before.Data) {
+		t.Error("changed source served stale artwork")
+	}
+
+	if _, err := os.Stat(stale); !os.IsNotExist(err) {
+		t.Errorf("stale artwork not pruned: %v", err)
+	}
+	infos, err := ioutil.ReadDir(c.videoDir(k))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(infos) != 1 {
+		t.Errorf("%d files cached, want only the current version", len(infos))
+	}
+}
+
+func TestGetConcurrentMisses(t *testing.T) {
+	src := newSource(t)
+	src.gate = make(chan struct{})
+	c, err := New(src, 4, t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const n = 8
+	var wg sync.WaitGroup
+	artworks := make([]*Artwork, n)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			artworks[i], _ = c.Get("v1", width(10))
+		}(i)
+	}
+	// let the misses pile up
+	time.Sleep(50 * time.Millisecond)
+	close(src.gate)
+	wg.Wait()
+
+	if reads := atomic.LoadInt32(&src.reads); reads != 1 {
+		t.Errorf("%d concurrent misses read the source %d times", n, reads)
+	}
+	for i, artwork := range artworks {
+		if artwork == nil || artwork.ETag != artworks[0].ETag {
+			t.Errorf("miss %d: %+v", i, artwork)
+		}
+	}
+}
+
+func TestHandlerNotModified(t *testing.T) {
+	src := newSource(t)
+	c, err := New(src, 4, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := http.StripPrefix("/artwork/", c.Handler(60, false))
+
+	serve := func(target string, header http.Header) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodGet, target, nil)
+		for k, v := range header {
+			r.Header[k] = v
+		}
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		return w
+	}
+
+	w := serve("/artwork/v1?width=10", nil)
+	etag := w.Header().Get("ETag")
+	if w.Code != http.StatusOK || etag == "" || w.Header().Get("Cache-Control") != "public, max-age=60" {
+		t.Fatalf("status %d, headers %v", w.Code, w.Header())
+	}
+
+	for _, test := range []struct {
+		name        string
+		target      string
+		ifNoneMatch string
+		status      int
+	}{
+		{"matching", "/artwork/v1?width=10", etag, http.StatusNotModified},
+		{"weak", "/artwork/v1?width=10", "W/" + etag, http.StatusNotModified},
+		{"listed", "/artwork/v1?width=10", `"stale", ` + etag, http.StatusNotModified},
+		{"wildcard", "/artwork/v1?width=10", "*", http.StatusNotModified},
+		{"stale", "/artwork/v1?width=10", `"stale"`, http.StatusOK},
+		{"another geometry", "/artwork/v1?width=20", etag, http.StatusOK},
+		{"unknown video", "/artwork/v2", etag, http.StatusNotFound},
+		{"bad geometry", "/artwork/v1?width=0", etag, http.StatusBadRequest},
+	} {
+		reads := src.reads
+		w := serve(test.target, http.Header{"If-None-Match": {test.ifNoneMatch}})
+		if w.Code != test.status {
+			t.Errorf("%s: status %d, want %d", test.name, w.Code, test.status)
+		}
+		if w.Code == http.StatusNotModified && (w.Body.Len() != 0 || src.reads != reads) {
+			t.Errorf("%s: resized or sent despite not modified", test.name)
+		}
+	}
+}
@@ -0,0 +1,152 @@
+package artcache
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/idiomatic/tvql/graph/model"
+	"github.com/idiomatic/tvql/optional"
+)
+
+// Handler serves artwork by video ID (i.e., the path), resized per
+// optional width, height, dpr, fit, anchor, and background parameters,
+// and encoded per optional format and quality parameters.  Absent a
+// format, WEBP is served to clients accepting it (if available), else
+// the original format.  Responses carry ETags and Cache-Control, and If-None-Match
+// is honored without resizing.  Private responses are not for shared
+// caches.
+func (c *Cache) Handler(maxAge int, private bool) http.Handler {
+	scope := "public"
+	if private {
+		scope = "private"
+	}
+	cacheControl := fmt.Sprintf("%s, max-age=%d", scope, maxAge)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.URL.Path
+		q := r.URL.Query()
+
+		geometry := &model.GeometryFilter{
+			Width:   optional.Atoi(q.Get("width")),
+			Height:  optional.Atoi(q.Get("height")),
+			Quality: optional.Atoi(q.Get("quality")),
+		}
+		if s := q.Get("dpr"); s != "" {
+			dpr, err := strconv.ParseFloat(s, 64)
+			if err != nil {
+				http.Error(w, "artwork dpr not a number", http.StatusBadRequest)
+				return
+			}
+			geometry.Dpr = &dpr
+		}
+		if s := q.Get("fit"); s != "" {
+			fit := model.ArtworkFit(strings.ToUpper(s))
+			geometry.Fit = &fit
+		}
+		if s := q.Get("anchor"); s != "" {
+			anchor := model.ArtworkAnchor(strings.ToUpper(s))
+			geometry.Anchor = &anchor
+		}
+		if s := q.Get("background"); s != "" {
+			geometry.Background = &s
+		}
+		if err := geometry.Validate(); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if name := q.Get("format"); name != "" {
+			format := model.ArtworkFormat(strings.ToUpper(name))
+			if !format.IsValid() {
+				http.Error(w, "unknown artwork format", http.StatusBadRequest)
+				return
+			}
+			if !format.Available() {
+				http.Error(w, "artwork format unavailable", http.StatusNotImplemented)
+				return
+			}
+			geometry.Format = &format
+		} else {
+			w.Header().Add("Vary", "Accept")
+			geometry.Format = negotiate(r.Header.Get("Accept"))
+		}
+
+		etag, err := c.ETag(id, geometry)
+		if err != nil {
+			http.Error(w, "artwork not found", http.StatusNotFound)
+			return
+		}
+		w.Header().Set("ETag", etag)
+		w.Header().Set("Cache-Control", cacheControl)
+
+		if matches(r.Header.Get("If-None-Match"), etag) {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		artwork, err := c.Get(id, geometry)
+		if err != nil {
+			http.Error(w, "artwork retrieval failed", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", artwork.ContentType)
+		http.ServeContent(w, r, "", time.Time{}, bytes.NewReader(artwork.Data))
+	})
+}
+
+// matches reports whether an If-None-Match header lists etag, weakly
+// compared.
+func matches(header string, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == etag || candidate == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+// negotiated are formats preferred over the original, if accepted.
+var negotiated = []model.ArtworkFormat{model.ArtworkFormatWebp}
+
+// negotiate picks an available format explicitly listed by an Accept
+// header, else none (i.e., the original format).  Wildcards do not
+// count, as browsers send them regardless.
+func negotiate(accept string) *model.ArtworkFormat {
+	for _, format := range negotiated {
+		if !format.Available() {
+			continue
+		}
+		for _, candidate := range strings.Split(accept, ",") {
+			params := strings.Split(candidate, ";")
+			if !strings.EqualFold(strings.TrimSpace(params[0]), format.ContentType()) {
+				continue
+			}
+			if !rejected(params[1:]) {
+				format := format
+				return &format
+			}
+		}
+	}
+	return nil
+}
+
+// rejected reports whether media range parameters include q=0.
+func rejected(params []string) bool {
+	for _, param := range params {
+		name, value := param, ""
+		if i := strings.Index(param, "="); i >= 0 {
+			name, value = param[:i], param[i+1:]
+		}
+		if strings.TrimSpace(name) == "q" {
+			q, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+			return err != nil || q <= 0
+		}
+	}
+	return false
+}
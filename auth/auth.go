@@ -0,0 +1,304 @@
+// Package auth authenticates API clients, by bearer token or by
+// username and password session.
+package auth
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/idiomatic/tvql/proxy"
+)
+
+// SessionCookie carries a session ID, once logged in.
+const SessionCookie = "tvql_session"
+
+// Principal is an authenticated client.
+type Principal struct {
+	Name  string `json:"name"`
+	Admin bool   `json:"admin"`
+	// if set, the only profile the principal may select; if unset,
+	// administrators may select any, others only the default
+	Profile string `json:"profile,omitempty"`
+}
+
+// Config lists who may authenticate.
+type Config struct {
+	Tokens []Token `json:"tokens"`
+	Users  []User  `json:"users"`
+}
+
+// Token is an API token, known only by its SHA-256 hex digest.
+type Token struct {
+	Principal
+	SHA256 string `json:"tokenSha256"`
+}
+
+// User logs in with a password, hashed as
+// "pbkdf2-sha256$<iterations>$<salt>$<hash>" (unpadded base64).
+type User struct {
+	Principal
+	Password string `json:"password"`
+}
+
+type session struct {
+	principal *Principal
+	expires   time.Time
+}
+
+// Authenticator identifies the principal of each request.  Without
+// config, everyone is an anonymous administrator.
+type Authenticator struct {
+	open       bool
+	tokens     map[string]*Principal
+	users      map[string]*User
+	sessionTTL time.Duration
+	now        func() time.Time
+
+	mutex    sync.Mutex
+	sessions map[string]*session
+}
+
+// anonymous is everyone, absent config.
+var anonymous = &Principal{Name: "anonymous", Admin: true}
+
+// Open reads config from path, else (if path is empty) authenticates
+// no one, admitting everyone.
+func Open(path string, sessionTTL time.Duration) (*Authenticator, error) {
+	a := &Authenticator{
+		open:       path == "",
+		tokens:     make(map[string]*Principal),
+		users:      make(map[string]*User),
+		sessionTTL: sessionTTL,
+		now:        time.Now,
+		sessions:   make(map[string]*session),
+	}
+	if a.open {
+		return a, nil
+	}
+
+	buf, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var config Config
+	if err := json.Unmarshal(buf, &config); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	for i := range config.Tokens {
+		token := &config.Tokens[i]
+		a.tokens[strings.ToLower(token.SHA256)] = &token.Principal
+	}
+	for i := range config.Users {
+		user := &config.Users[i]
+		if user.Name == "" {
+			return nil, fmt.Errorf("%s: user name missing", path)
+		}
+		a.users[user.Name] = user
+	}
+
+	return a, nil
+}
+
+// Open reports whether everyone is admitted.
+func (a *Authenticator) Open() bool {
+	return a.open
+}
+
+// authenticate identifies a request's principal by bearer token, else
+// session cookie.
+func (a *Authenticator) authenticate(r *http.Request) (*Principal, bool) {
+	if a.open {
+		return anonymous, true
+	}
+
+	if header := r.Header.Get("Authorization"); strings.HasPrefix(header, "Bearer ") {
+		digest := sha256.Sum256([]byte(strings.TrimPrefix(header, "Bearer ")))
+		principal, ok := a.tokens[hex.EncodeToString(digest[:])]
+		return principal, ok
+	}
+
+	if cookie, err := r.Cookie(SessionCookie); err == nil {
+		a.mutex.Lock()
+		defer a.mutex.Unlock()
+
+		s, ok := a.sessions[cookie.Value]
+		if !ok {
+			return nil, false
+		}
+		if a.now().After(s.expires) {
+			delete(a.sessions, cookie.Value)
+			return nil, false
+		}
+		return s.principal, true
+	}
+
+	return nil, false
+}
+
+// Require refuses unauthenticated requests, and carries the principal
+// of the rest in the request context.
+func (a *Authenticator) Require(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		principal, ok := a.authenticate(r)
+		if !ok {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="tvql"`)
+			http.Error(w, "authentication required", http.StatusUnauthorized)
+			return
+		}
+		h.ServeHTTP(w, r.WithContext(WithPrincipal(r.Context(), principal)))
+	})
+}
+
+// RequireAdmin is like Require, but refuses principals other than
+// administrators.
+func (a *Authenticator) RequireAdmin(h http.Handler) http.Handler {
+	return a.Require(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if principal, _ := PrincipalFrom(r.Context()); !principal.Admin {
+			http.Error(w, "administrators only", http.StatusForbidden)
+			return
+		}
+		h.ServeHTTP(w, r)
+	}))
+}
+
+// Login starts a session given a POSTed username and password form.
+func (a *Authenticator) Login() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		user, ok := a.users[r.PostFormValue("username")]
+		// XXX no rate limiting
+		if !ok || !checkPassword(user.Password, r.PostFormValue("password")) {
+			http.Error(w, "invalid username or password", http.StatusUnauthorized)
+			return
+		}
+
+		id := make([]byte, 32)
+		if _, err := rand.Read(id); err != nil {
+			http.Error(w, "session creation failed", http.StatusInternalServerError)
+			return
+		}
+		sessionID := base64.RawURLEncoding.EncodeToString(id)
+		now := a.now()
+		expires := now.Add(a.sessionTTL)
+
+		// XXX sessions do not survive restarts
+		a.mutex.Lock()
+		// lest abandoned sessions accumulate
+		for id, s := range a.sessions {
+			if now.After(s.expires) {
+				delete(a.sessions, id)
+			}
+		}
+		a.sessions[sessionID] = &session{&user.Principal, expires}
+		a.mutex.Unlock()
+
+		base := proxy.BaseURL(r.Context())
+		http.SetCookie(w, &http.Cookie{
+			Name:     SessionCookie,
+			Value:    sessionID,
+			Path:     base.Path,
+			Expires:  expires,
+			HttpOnly: true,
+			Secure:   base.Scheme == "https",
+			SameSite: http.SameSiteLaxMode,
+		})
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+// Logout ends the session, if any.
+func (a *Authenticator) Logout() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		if cookie, err := r.Cookie(SessionCookie); err == nil {
+			a.mutex.Lock()
+			delete(a.sessions, cookie.Value)
+			a.mutex.Unlock()
+		}
+
+		http.SetCookie(w, &http.Cookie{
+			Name:   SessionCookie,
+			Path:   proxy.BaseURL(r.Context()).Path,
+			MaxAge: -1,
+		})
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+// checkPassword compares a password to its PBKDF2-SHA256 hash.
+func checkPassword(hash, password string) bool {
+	parts := strings.Split(hash, "$")
+	if len(parts) != 4 || parts[0] != "pbkdf2-sha256" {
+		return false
+	}
+	iterations, err := strconv.Atoi(parts[1])
+	if err != nil || iterations < 1 {
+		return false
+	}
+	salt, err := base64.RawStdEncoding.DecodeString(strings.TrimRight(parts[2], "="))
+	if err != nil {
+		return false
+	}
+	expected, err := base64.RawStdEncoding.DecodeString(strings.TrimRight(parts[3], "="))
+	if err != nil || len(expected) != sha256.Size {
+		return false
+	}
+
+	return subtle.ConstantTimeCompare(pbkdf2SHA256([]byte(password), salt, iterations), expected) == 1
+}
+
+// pbkdf2SHA256 derives one block (i.e., 32 bytes) per RFC 8018.
+func pbkdf2SHA256(password, salt []byte, iterations int) []byte {
+	mac := hmac.New(sha256.New, password)
+	mac.Write(salt)
+	mac.Write([]byte{0, 0, 0, 1})
+	u := mac.Sum(nil)
+
+	derived := append([]byte(nil), u...)
+	for i := 1; i < iterations; i++ {
+		mac.Reset()
+		mac.Write(u)
+		u = mac.Sum(u[:0])
+		for j := range derived {
+			derived[j] ^= u[j]
+		}
+	}
+	return derived
+}
+
+type principalContextKey struct{}
+
+// WithPrincipal carries a principal in a context.
+func WithPrincipal(ctx context.Context, principal *Principal) context.Context {
+	return context.WithValue(ctx, principalContextKey{}, principal)
+}
+
+// PrincipalFrom returns the principal carried by a context, if any.
+func PrincipalFrom(ctx context.Context) (*Principal, bool) {
+	principal, ok := ctx.Value(principalContextKey{}).(*Principal)
+	return principal, ok
+}
@@ -0,0 +1,235 @@
+package auth
+
+import (
+	"encoding/hex"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+const (
+	// sha256("sesame")
+	adminTokenSHA256 = "d0c04f4b1951e4aeaaec8223ed2039e542f3aae805a6fa7f6d794e5afff5d272"
+	// sha256("kid-token")
+	kidTokenSHA256 = "b3cb4edbf8f0c3556c1ac415771917a2e49189524647e56e4b1a60219afa8a5f"
+	// pbkdf2-sha256("hunter2", "pepper", 1000)
+	adaPassword = "pbkdf2-sha256$1000$cGVwcGVy$l9exyr4FngIjDsKUx+KfA8JdrMu1ZqY9yqztnomq+sA"
+)
+
+var epoch = time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+
+func newAuthenticator(t *testing.T) (*Authenticator, *time.Time) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "auth.json")
+	config := `{
+		"tokens": [
+			{"name": "ops", "admin": true, "tokenSha256": "` + strings.ToUpper(adminTokenSHA256) + `"},
+			{"name": "kid", "profile": "p1", "tokenSha256": "` + kidTokenSHA256 + `"}
+		],
+		"users": [
+			{"name": "ada", "password": "` + adaPassword + `"}
+		]
+	}`
+	if err := ioutil.WriteFile(path, []byte(config), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	a, err := Open(path, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := epoch
+	a.now = func() time.Time { return now }
+	return a, &now
+}
+
+// login returns a session cookie, else nil.
+func login(t *testing.T, a *Authenticator, username, password string) *http.Cookie {
+	t.Helper()
+	form := url.Values{"username": {username}, "password": {password}}
+	r := httptest.NewRequest(http.MethodPost, "/login", strings.NewReader(form.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+	a.Login().ServeHTTP(w, r)
+	for _, cookie := range w.Result().Cookies() {
+		if cookie.Name == SessionCookie {
+			return cookie
+		}
+	}
+	return nil
+}
+
+func logout(a *Authenticator, cookie *http.Cookie) {
+	r := httptest.NewRequest(http.MethodPost, "/logout", nil)
+	r.AddCookie(cookie)
+	a.Logout().ServeHTTP(httptest.NewRecorder(), r)
+}
+
+// whoami reports the authenticated principal's name.
+var whoami = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	principal, _ := PrincipalFrom(r.Context())
+	w.Write([]byte(principal.Name))
+})
+
+func TestRequire(t *testing.T) {
+	a, now := newAuthenticator(t)
+
+	session := login(t, a, "ada", "hunter2")
+	if session == nil {
+		t.Fatal("ada not logged in")
+	}
+	loggedOut := login(t, a, "ada", "hunter2")
+	logout(a, loggedOut)
+	expired := login(t, a, "ada", "hunter2")
+	// sessions are looked up, so expire one by hand
+	a.sessions[expired.Value].expires = epoch.Add(-time.Second)
+
+	for _, test := range []struct {
+		name          string
+		authorization string
+		cookie        *http.Cookie
+		admin         bool
+		status        int
+		principal     string
+	}{
+		{"admin token", "Bearer sesame", nil, false, http.StatusOK, "ops"},
+		{"admin token, admin only", "Bearer sesame", nil, true, http.StatusOK, "ops"},
+		{"token", "Bearer kid-token", nil, false, http.StatusOK, "kid"},
+		{"token, admin only", "Bearer kid-token", nil, true, http.StatusForbidden, ""},
+		{"wrong token", "Bearer open-sesame", nil, false, http.StatusUnauthorized, ""},
+		{"token digest", "Bearer " + adminTokenSHA256, nil, false, http.StatusUnauthorized, ""},
+		{"not bearer", "Basic c2VzYW1l", nil, false, http.StatusUnauthorized, ""},
+		{"no authorization", "", nil, false, http.StatusUnauthorized, ""},
+		{"session", "", session, false, http.StatusOK, "ada"},
+		{"session, admin only", "", session, true, http.StatusForbidden, ""},
+		{"logged out session", "", loggedOut, false, http.StatusUnauthorized, ""},
+		{"expired session", "", expired, false, http.StatusUnauthorized, ""},
+		{"unknown session", "", &http.Cookie{Name: SessionCookie, Value: "forged"}, false, http.StatusUnauthorized, ""},
+		// the token, if any, prevails
+		{"wrong token with session", "Bearer open-sesame", session, false, http.StatusUnauthorized, ""},
+	} {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		if test.authorization != "" {
+			r.Header.Set("Authorization", test.authorization)
+		}
+		if test.cookie != nil {
+			r.AddCookie(test.cookie)
+		}
+		h := a.Require(whoami)
+		if test.admin {
+			h = a.RequireAdmin(whoami)
+		}
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+
+		if w.Code != test.status {
+			t.Errorf("%s: status %d, want %d", test.name, w.Code, test.status)
+		}
+		if w.Code == http.StatusOK && w.Body.String() != test.principal {
+			t.Errorf("%s: principal %q, want %q", test.name, w.Body.String(), test.principal)
+		}
+		if w.Code == http.StatusUnauthorized && w.Header().Get("WWW-Authenticate") == "" {
+			t.Errorf("%s: challenge missing", test.name)
+		}
+	}
+
+	*now = epoch.Add(2 * time.Hour)
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.AddCookie(session)
+	w := httptest.NewRecorder()
+	a.Require(whoami).ServeHTTP(w, r)
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("session past its lifetime: status %d", w.Code)
+	}
+}
+
+func TestLogin(t *testing.T) {
+	a, now := newAuthenticator(t)
+
+	for _, test := range []struct {
+		username, password string
+		ok                 bool
+	}{
+		{"ada", "hunter2", true},
+		{"ada", "hunter3", false},
+		{"ada", "", false},
+		{"bob", "hunter2", false},
+		// tokens are not users
+		{"ops", "sesame", false},
+	} {
+		if cookie := login(t, a, test.username, test.password); (cookie != nil) != test.ok {
+			t.Errorf("%s/%s: session %v", test.username, test.password, cookie)
+		} else if cookie != nil && (!cookie.HttpOnly || !cookie.Expires.Equal(epoch.Add(time.Hour))) {
+			t.Errorf("%s: cookie %v", test.username, cookie)
+		}
+	}
+
+	r := httptest.NewRequest(http.MethodGet, "/login", nil)
+	w := httptest.NewRecorder()
+	a.Login().ServeHTTP(w, r)
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("GET: status %d", w.Code)
+	}
+
+	// logging in prunes abandoned sessions
+	*now = epoch.Add(2 * time.Hour)
+	login(t, a, "ada", "hunter2")
+	if n := len(a.sessions); n != 1 {
+		t.Errorf("%d sessions, want 1", n)
+	}
+}
+
+func TestOpen(t *testing.T) {
+	a, err := Open("", time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w := httptest.NewRecorder()
+	a.RequireAdmin(whoami).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	if w.Code != http.StatusOK || w.Body.String() != "anonymous" {
+		t.Errorf("open: status %d, principal %q", w.Code, w.Body.String())
+	}
+
+	path := filepath.Join(t.TempDir(), "auth.json")
+	if err := ioutil.WriteFile(path, []byte(`{"users": [{"password": "x"}]}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Open(path, time.Hour); err == nil {
+		t.Error("nameless user accepted")
+	}
+}
+
+func TestCheckPassword(t *testing.T) {
+	for _, test := range []struct {
+		hash, password string
+		ok             bool
+	}{
+		{adaPassword, "hunter2", true},
+		{adaPassword, "Hunter2", false},
+		// padded base64
+		{"pbkdf2-sha256$1000$cGVwcGVy$l9exyr4FngIjDsKUx+KfA8JdrMu1ZqY9yqztnomq+sA=", "hunter2", true},
+		{"pbkdf2-sha256$999$cGVwcGVy$l9exyr4FngIjDsKUx+KfA8JdrMu1ZqY9yqztnomq+sA", "hunter2", false},
+		{"pbkdf2-sha256$0$cGVwcGVy$l9exyr4FngIjDsKUx+KfA8JdrMu1ZqY9yqztnomq+sA", "hunter2", false},
+		{"pbkdf2-sha1$1000$cGVwcGVy$l9exyr4FngIjDsKUx+KfA8JdrMu1ZqY9yqztnomq+sA", "hunter2", false},
+		{"pbkdf2-sha256$1000$cGVwcGVy$l9exyr4F", "hunter2", false},
+		{"hunter2", "hunter2", false},
+		{"", "", false},
+	} {
+		if ok := checkPassword(test.hash, test.password); ok != test.ok {
+			t.Errorf("%s, %s: %v", test.hash, test.password, ok)
+		}
+	}
+}
+
+func TestPBKDF2SHA256(t *testing.T) {
+	// RFC 7914 §11
+	want := "55ac046e56e3089fec1691c22544b605f94185216dde0465e68b9d57c20dacbc"
+	if got := hex.EncodeToString(pbkdf2SHA256([]byte("passwd"), []byte("salt"), 1)); got != want {
+		t.Errorf("derived %s, want %s", got, want)
+	}
+}
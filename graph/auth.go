@@ -0,0 +1,249 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/idiomatic/tvql/auth"
+	"github.com/idiomatic/tvql/graph/model"
+)
+
+const errForbidden = "FORBIDDEN"
+
+// ErrForbidden refuses principals lacking permission.
+var ErrForbidden = errors.New("forbidden")
+
+// Admin implements the @admin directive.
+func Admin(ctx context.Context, obj interface{}, next graphql.Resolver) (interface{}, error) {
+	if principal, ok := auth.PrincipalFrom(ctx); !ok || !principal.Admin {
+		return nil, fmt.Errorf("administrators only: %w", ErrForbidden)
+	}
+	return next(ctx)
+}
+
+// permits returns whether the selected profile may see a video.
+func (r *Resolver) permits(ctx context.Context) (func(video *model.Video) bool, error) {
+	profile, err := r.profile(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(video *model.Video) bool {
+		return r.library.Permits(profile, video)
+	}, nil
+}
+
+// permittedVideos filters videos to those the selected profile sees.
+func (r *Resolver) permittedVideos(ctx context.Context, videos []*model.Video) ([]*model.Video, error) {
+	permits, err := r.permits(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var permitted []*model.Video
+	for _, video := range videos {
+		if permits(video) {
+			permitted = append(permitted, video)
+		}
+	}
+	return permitted, nil
+}
+
+// permittedEpisodes filters episodes to those the selected profile
+// sees.
+func (r *Resolver) permittedEpisodes(ctx context.Context, episodes []*model.Episode) ([]*model.Episode, error) {
+	permits, err := r.permits(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var permitted []*model.Episode
+	for _, episode := range episodes {
+		if permits(episode.Video) {
+			permitted = append(permitted, episode)
+		}
+	}
+	return permitted, nil
+}
+
+// permittedNode hides nodes the selected profile may not see: videos,
+// episodes, and renditions restricted; series, seasons, franchises,
+// and collections thereof; and others' collections.
+func (r *Resolver) permittedNode(ctx context.Context, node model.Node) (model.Node, error) {
+	permits, err := r.permits(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var video *model.Video
+	switch node := node.(type) {
+	case *model.Video:
+		video = node
+	case *model.Episode:
+		video = node.Video
+	case *model.Rendition:
+		if metarendition, ok := r.library.Metarendition(node.ID); ok {
+			if metavideo, ok := r.library.Snapshot().Metavideos[metarendition.VideoID]; ok {
+				video = &metavideo.Video
+			}
+		}
+	case *model.Series:
+		return r.permittedParent(ctx, node, node.ID)
+	case *model.Season:
+		return r.permittedParent(ctx, node, node.ID)
+	case *model.Collection:
+		sees, err := r.seesCollection(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if !sees(node) {
+			return nil, nil
+		}
+		return node, nil
+	case *model.Franchise:
+		permitsFranchise, err := r.permitsFranchise(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if !permitsFranchise(node) {
+			return nil, nil
+		}
+		return node, nil
+	default:
+		return node, nil
+	}
+
+	if video == nil || !permits(video) {
+		return nil, nil
+	}
+	return node, nil
+}
+
+// permittedParent hides a series or season unless the selected profile
+// sees any of its episodes.
+func (r *Resolver) permittedParent(ctx context.Context, node model.Node, parentID string) (model.Node, error) {
+	permitsParent, err := r.permitsParent(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !permitsParent(parentID) {
+		return nil, nil
+	}
+	return node, nil
+}
+
+// permitsFranchise returns whether the selected profile may see a
+// franchise, i.e., any of its videos.
+func (r *Resolver) permitsFranchise(ctx context.Context) (func(franchise *model.Franchise) bool, error) {
+	permits, err := r.permits(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(franchise *model.Franchise) bool {
+		for _, video := range franchise.Videos {
+			if permits(video) {
+				return true
+			}
+		}
+		return false
+	}, nil
+}
+
+// permitsEvent returns whether the selected profile may learn of a
+// video event.
+func (r *Resolver) permitsEvent(ctx context.Context) (func(event model.Event) bool, error) {
+	profile, err := r.profile(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(event model.Event) bool {
+		return r.library.PermitsEvent(profile, event)
+	}, nil
+}
+
+// permittedVideo looks up a video the selected profile may see.
+func (r *Resolver) permittedVideo(ctx context.Context, id string) (*model.Video, error) {
+	video, err := r.video(id)
+	if err != nil {
+		return nil, err
+	}
+
+	permits, err := r.permits(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !permits(video) {
+		return nil, fmt.Errorf("video not found")
+	}
+
+	return video, nil
+}
+
+// permittedChildEpisodes returns the episodes of a series or season
+// the selected profile may see.
+func (r *Resolver) permittedChildEpisodes(ctx context.Context, parentID string) ([]*model.Episode, error) {
+	episodes, err := r.loaders(ctx).Episodes.Load(parentID)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.permittedEpisodes(ctx, episodes)
+}
+
+// permitsParent returns whether the selected profile may see a series
+// or season, i.e., any of its episodes.
+func (r *Resolver) permitsParent(ctx context.Context) (func(parentID string) bool, error) {
+	profile, err := r.profile(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(parentID string) bool {
+		if !profile.Restricted() {
+			return true
+		}
+		for _, episode := range r.library.EpisodesByParent([]string{parentID})[0] {
+			if r.library.Permits(profile, episode.Video) {
+				return true
+			}
+		}
+		return false
+	}, nil
+}
+
+// permittedMembers filters collection members to those the selected
+// profile sees.
+func (r *Resolver) permittedMembers(ctx context.Context, members []model.CollectionMember) ([]model.CollectionMember, error) {
+	permits, err := r.permits(ctx)
+	if err != nil {
+		return nil, err
+	}
+	permitsParent, err := r.permitsParent(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var permitted []model.CollectionMember
+	for _, member := range members {
+		switch member := member.(type) {
+		case *model.Video:
+			if !permits(member) {
+				continue
+			}
+		case *model.Episode:
+			if !permits(member.Video) {
+				continue
+			}
+		case *model.Series:
+			if !permitsParent(member.ID) {
+				continue
+			}
+		}
+		permitted = append(permitted, member)
+	}
+	return permitted, nil
+}
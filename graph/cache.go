@@ -0,0 +1,75 @@
+package graph
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// CacheableGET adds Cache-Control and ETag headers to successful
+// GraphQL GET responses, and honors If-None-Match.  ETags change
+// whenever the version (e.g., of the library) changes, and vary by
+// selected profile.  The version is hashed, as it may name the principal or
+// client.  Private responses are not for shared caches.
+func CacheableGET(version func(r *http.Request) string, maxAge int, private bool, h http.Handler) http.Handler {
+	scope := "public"
+	if private {
+		scope = "private"
+	}
+	cacheControl := fmt.Sprintf("%s, max-age=%d", scope, maxAge)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.Header.Get("Upgrade") != "" {
+			h.ServeHTTP(w, r)
+			return
+		}
+
+		w.Header().Set("Vary", ProfileHeader)
+
+		digest := sha256.Sum256([]byte(version(r) + "\x00" + r.URL.RawQuery + "\x00" + SelectedProfile(r.Context())))
+		etag := `"` + hex.EncodeToString(digest[:16]) + `"`
+
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		buffered := &bufferedResponse{header: w.Header(), status: http.StatusOK}
+		h.ServeHTTP(buffered, r)
+
+		var response struct {
+			Errors json.RawMessage `json:"errors"`
+		}
+		body := buffered.body.Bytes()
+		if buffered.status == http.StatusOK && json.Unmarshal(body, &response) == nil && response.Errors == nil {
+			w.Header().Set("Cache-Control", cacheControl)
+			w.Header().Set("ETag", etag)
+		}
+
+		w.WriteHeader(buffered.status)
+		w.Write(body)
+	})
+}
+
+// bufferedResponse holds a response body until it is known to be
+// cacheable, sharing the underlying response's headers.
+type bufferedResponse struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func (w *bufferedResponse) Header() http.Header {
+	return w.header
+}
+
+func (w *bufferedResponse) WriteHeader(status int) {
+	w.status = status
+}
+
+func (w *bufferedResponse) Write(p []byte) (int, error) {
+	return w.body.Write(p)
+}
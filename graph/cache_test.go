@@ -0,0 +1,163 @@
+package graph_test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/99designs/gqlgen/graphql/handler/extension"
+	"github.com/idiomatic/tvql/graph"
+)
+
+func get(h http.Handler, values url.Values, header http.Header) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(http.MethodGet, "/query?"+values.Encode(), nil)
+	for key, vs := range header {
+		r.Header[key] = vs
+	}
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	return w
+}
+
+func TestCacheableGET(t *testing.T) {
+	s := newTestServer(t)
+	version := "1.secret-principal"
+	h := graph.WithProfileHeader(graph.CacheableGET(func(r *http.Request) string {
+		return version
+	}, 60, true, s.handler(admin)))
+	query := url.Values{"query": {"{ videos { id } }"}}
+
+	first := get(h, query, nil)
+	etag := first.Header().Get("ETag")
+	if first.Code != http.StatusOK || etag == "" {
+		t.Fatalf("status %d, ETag %q", first.Code, etag)
+	}
+	if strings.Contains(etag, "secret") {
+		t.Errorf("ETag %s reveals its version", etag)
+	}
+	if got := first.Header().Get("Cache-Control"); got != "private, max-age=60" {
+		t.Errorf("Cache-Control = %q", got)
+	}
+
+	revalidated := get(h, query, http.Header{"If-None-Match": {etag}})
+	if revalidated.Code != http.StatusNotModified || revalidated.Body.Len() != 0 {
+		t.Errorf("revalidation: status %d, %d bytes", revalidated.Code, revalidated.Body.Len())
+	}
+
+	other := get(h, query, http.Header{"If-None-Match": {etag}, graph.ProfileHeader: {"someone"}})
+	if other.Code == http.StatusNotModified {
+		t.Error("ETag shared across profiles")
+	}
+
+	version = "2.secret-principal"
+	changed := get(h, query, http.Header{"If-None-Match": {etag}})
+	if changed.Code != http.StatusOK || changed.Header().Get("ETag") == etag {
+		t.Errorf("after version change: status %d, ETag %s", changed.Code, changed.Header().Get("ETag"))
+	}
+
+	failed := get(h, url.Values{"query": {"{ bogus }"}}, nil)
+	if failed.Header().Get("ETag") != "" || failed.Header().Get("Cache-Control") != "" {
+		t.Error("error response cacheable")
+	}
+}
+
+func sha256Hex(query string) string {
+	sum := sha256.Sum256([]byte(query))
+	return hex.EncodeToString(sum[:])
+}
+
+func persistedQuery(hash string) url.Values {
+	extensions, _ := json.Marshal(map[string]interface{}{
+		"persistedQuery": map[string]interface{}{"version": 1, "sha256Hash": hash},
+	})
+	return url.Values{"extensions": {string(extensions)}}
+}
+
+func errorCodes(t *testing.T, w *httptest.ResponseRecorder) []string {
+	t.Helper()
+	var response struct {
+		Errors []struct {
+			Extensions struct{ Code string }
+		}
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatal(err)
+	}
+	var codes []string
+	for _, e := range response.Errors {
+		codes = append(codes, e.Extensions.Code)
+	}
+	return codes
+}
+
+func TestPersistedQueriesAllowlist(t *testing.T) {
+	const known = "{ videos { title } }\n"
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "titles.graphql"), []byte(known), 0644); err != nil {
+		t.Fatal(err)
+	}
+	persisted, err := graph.NewPersistedQueries(true, filepath.Join(dir, "*.graphql"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := newTestServer(t)
+	s.srv.Use(extension.AutomaticPersistedQuery{Cache: persisted})
+	s.srv.Use(persisted)
+	h := s.handler(admin)
+
+	hash := sha256Hex(known)
+
+	if codes := errorCodes(t, get(h, persistedQuery(hash), nil)); len(codes) != 0 {
+		t.Errorf("known hash: errors %v", codes)
+	}
+	if codes := errorCodes(t, get(h, url.Values{"query": {known}}, nil)); len(codes) != 0 {
+		t.Errorf("known query: errors %v", codes)
+	}
+
+	unknown := get(h, url.Values{"query": {"{ videos { id } }"}}, nil)
+	if codes := errorCodes(t, unknown); len(codes) != 1 || codes[0] != "PERSISTED_QUERY_NOT_ALLOWED" {
+		t.Errorf("unknown query: errors %v", codes)
+	}
+
+	unregistered := get(h, persistedQuery(sha256Hex("{ series { name } }")), nil)
+	if codes := errorCodes(t, unregistered); len(codes) != 1 || codes[0] != "PERSISTED_QUERY_NOT_FOUND" {
+		t.Errorf("unregistered hash: errors %v", codes)
+	}
+}
+
+func TestPersistedQueriesLearned(t *testing.T) {
+	persisted, err := graph.NewPersistedQueries(false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := newTestServer(t)
+	s.srv.Use(extension.AutomaticPersistedQuery{Cache: persisted})
+	s.srv.Use(persisted)
+	h := s.handler(admin)
+
+	const query = "{ series { name } }"
+	hash := sha256Hex(query)
+
+	if codes := errorCodes(t, get(h, persistedQuery(hash), nil)); len(codes) != 1 || codes[0] != "PERSISTED_QUERY_NOT_FOUND" {
+		t.Errorf("before registration: errors %v", codes)
+	}
+
+	register := persistedQuery(hash)
+	register.Set("query", query)
+	if codes := errorCodes(t, get(h, register, nil)); len(codes) != 0 {
+		t.Errorf("registration: errors %v", codes)
+	}
+
+	if codes := errorCodes(t, get(h, persistedQuery(hash), nil)); len(codes) != 0 {
+		t.Errorf("after registration: errors %v", codes)
+	}
+}
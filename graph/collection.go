@@ -0,0 +1,117 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/idiomatic/tvql/auth"
+	"github.com/idiomatic/tvql/graph/model"
+)
+
+// node looks up any identifiable object, including collections.
+func (r *Resolver) node(id string) (model.Node, bool) {
+	if node, ok := r.library.Node(id); ok {
+		return node, true
+	}
+
+	collection, ok := r.collections.Get(id)
+	return collection, ok
+}
+
+// collectionMemberIDs maps member IDs (e.g., legacy) to global IDs.
+// Unless present is required, unknown IDs pass through, so members
+// missing from the library may still be removed.
+func (r *Resolver) collectionMemberIDs(ids []string, present bool) ([]string, error) {
+	memberIDs := make([]string, len(ids))
+	for i, id := range ids {
+		memberID, err := r.library.CollectionMemberID(id)
+		if err != nil {
+			if present {
+				return nil, err
+			}
+			memberID = id
+		}
+		memberIDs[i] = memberID
+	}
+	return memberIDs, nil
+}
+
+// admin reports whether the principal is an administrator.
+func admin(ctx context.Context) bool {
+	principal, ok := auth.PrincipalFrom(ctx)
+	return ok && principal.Admin
+}
+
+// collectionOwner returns the owner of a new collection: none if
+// shared (by default, for administrators), else the selected profile.
+func (r *Resolver) collectionOwner(ctx context.Context, shared *bool) (string, error) {
+	if shared == nil {
+		isAdmin := admin(ctx)
+		shared = &isAdmin
+	}
+	if *shared {
+		if !admin(ctx) {
+			return "", fmt.Errorf("shared collections are for administrators: %w", ErrForbidden)
+		}
+		return "", nil
+	}
+
+	profile, err := r.profile(ctx)
+	if err != nil {
+		return "", err
+	}
+	return profile.ID, nil
+}
+
+// seesCollection returns whether the selected profile sees a
+// collection: shared ones, and its own (administrators see all), unless
+// restricted from every member.
+func (r *Resolver) seesCollection(ctx context.Context) (func(collection *model.Collection) bool, error) {
+	profile, err := r.profile(ctx)
+	if err != nil {
+		return nil, err
+	}
+	isAdmin := admin(ctx)
+
+	return func(collection *model.Collection) bool {
+		if !isAdmin && !collection.Shared() && collection.OwnerID != profile.ID {
+			return false
+		}
+		if !profile.Restricted() {
+			return true
+		}
+
+		members := r.library.CollectionMembers(collection)
+		if len(members) == 0 {
+			return true
+		}
+		permitted, err := r.permittedMembers(ctx, members)
+		return err == nil && len(permitted) != 0
+	}, nil
+}
+
+// mayEditCollection refuses principals that may not edit a
+// collection: administrators edit any, others those of the selected
+// profile.
+func (r *Resolver) mayEditCollection(ctx context.Context, id string) error {
+	collection, ok := r.collections.Get(id)
+	if !ok {
+		return fmt.Errorf("collection not found")
+	}
+	if admin(ctx) {
+		return nil
+	}
+
+	profile, err := r.profile(ctx)
+	if err != nil {
+		return err
+	}
+	if collection.Shared() {
+		return fmt.Errorf("shared collections are for administrators: %w", ErrForbidden)
+	}
+	if collection.OwnerID != profile.ID {
+		// as if absent
+		return fmt.Errorf("collection not found")
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package graph_test
+
+import (
+	"testing"
+
+	"github.com/99designs/gqlgen/client"
+)
+
+type collectionResponse struct {
+	ID     string
+	Shared bool
+	Owner  *struct{ Name string }
+}
+
+// post discards the response, reporting only errors.
+func post(c *client.Client, query string, options ...client.Option) error {
+	var response interface{}
+	return c.Post(query, &response, options...)
+}
+
+func TestCollectionOwnership(t *testing.T) {
+	s := newTestServer(t)
+	kid := s.client(s.restricted(t, "kid", "PG"))
+	guest := s.client(s.restricted(t, "guest", "PG"))
+	administrator := s.client(admin)
+
+	var shared struct{ CreateCollection collectionResponse }
+	administrator.MustPost(`mutation { createCollection(name: "Favorites") { id shared owner { name } } }`, &shared)
+	if !shared.CreateCollection.Shared || shared.CreateCollection.Owner != nil {
+		t.Errorf("administrator's collection = %+v, want shared", shared.CreateCollection)
+	}
+
+	var own struct{ CreateCollection collectionResponse }
+	kid.MustPost(`mutation { createCollection(name: "Mine") { id shared owner { name } } }`, &own)
+	if own.CreateCollection.Shared || own.CreateCollection.Owner == nil || own.CreateCollection.Owner.Name != "kid" {
+		t.Errorf("kid's collection = %+v, want owned by kid", own.CreateCollection)
+	}
+
+	if err := post(kid, `mutation { createCollection(name: "Ours", shared: true) { id } }`); err == nil {
+		t.Error("kid created a shared collection")
+	}
+
+	rename := `mutation($id: ID!) { renameCollection(id: $id, name: "Renamed") { id } }`
+	if err := post(kid, rename, client.Var("id", own.CreateCollection.ID)); err != nil {
+		t.Errorf("kid renaming own collection: %v", err)
+	}
+	if err := post(kid, rename, client.Var("id", shared.CreateCollection.ID)); err == nil {
+		t.Error("kid renamed a shared collection")
+	}
+	if err := post(guest, rename, client.Var("id", own.CreateCollection.ID)); err == nil {
+		t.Error("guest renamed kid's collection")
+	}
+	if err := post(administrator, rename, client.Var("id", own.CreateCollection.ID)); err != nil {
+		t.Errorf("administrator renaming kid's collection: %v", err)
+	}
+
+	seen := func(c *client.Client) map[string]bool {
+		var collections struct{ Collections []struct{ ID string } }
+		c.MustPost(`{ collections { id } }`, &collections)
+		ids := make(map[string]bool)
+		for _, collection := range collections.Collections {
+			ids[collection.ID] = true
+		}
+		return ids
+	}
+	if ids := seen(kid); !ids[shared.CreateCollection.ID] || !ids[own.CreateCollection.ID] {
+		t.Errorf("kid sees %v, want shared and own", ids)
+	}
+	if ids := seen(guest); !ids[shared.CreateCollection.ID] || ids[own.CreateCollection.ID] {
+		t.Errorf("guest sees %v, want only shared", ids)
+	}
+
+	var node struct{ Node *struct{ ID string } }
+	guest.MustPost(`query($id: ID!) { node(id: $id) { id } }`, &node, client.Var("id", own.CreateCollection.ID))
+	if node.Node != nil {
+		t.Error("guest sees kid's collection by node")
+	}
+}
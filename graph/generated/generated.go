@@ -6,9 +6,12 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"fmt"
+	"io"
 	"strconv"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/99designs/gqlgen/graphql"
 	"github.com/99designs/gqlgen/graphql/introspection"
@@ -36,15 +39,21 @@ type Config struct {
 
 type ResolverRoot interface {
 	Artwork() ArtworkResolver
+	Collection() CollectionResolver
+	Episode() EpisodeResolver
+	Franchise() FranchiseResolver
+	Mutation() MutationResolver
 	Query() QueryResolver
 	Rendition() RenditionResolver
 	Renditions() RenditionsResolver
 	Season() SeasonResolver
 	Series() SeriesResolver
+	Subscription() SubscriptionResolver
 	Video() VideoResolver
 }
 
 type DirectiveRoot struct {
+	Admin func(ctx context.Context, obj interface{}, next graphql.Resolver) (res interface{}, err error)
 }
 
 type ComplexityRoot struct {
@@ -53,6 +62,17 @@ type ComplexityRoot struct {
 		URL    func(childComplexity int, geometry *model.GeometryFilter) int
 	}
 
+	Collection struct {
+		Artwork     func(childComplexity int) int
+		ID          func(childComplexity int) int
+		MemberCount func(childComplexity int) int
+		Members     func(childComplexity int) int
+		Name        func(childComplexity int) int
+		Owner       func(childComplexity int) int
+		Shared      func(childComplexity int) int
+		Smart       func(childComplexity int) int
+	}
+
 	Contributor struct {
 		Name func(childComplexity int) int
 	}
@@ -60,10 +80,48 @@ type ComplexityRoot struct {
 	Episode struct {
 		Episode   func(childComplexity int) int
 		EpisodeID func(childComplexity int) int
+		ID        func(childComplexity int) int
+		Next      func(childComplexity int) int
+		Previous  func(childComplexity int) int
 		Season    func(childComplexity int) int
 		Video     func(childComplexity int) int
 	}
 
+	Franchise struct {
+		Explicit   func(childComplexity int) int
+		ID         func(childComplexity int) int
+		Name       func(childComplexity int) int
+		VideoCount func(childComplexity int) int
+		Videos     func(childComplexity int, order *model.FranchiseOrder) int
+	}
+
+	Mutation struct {
+		AddToCollection      func(childComplexity int, id string, memberIds []string, position *int) int
+		CreateCollection     func(childComplexity int, name string, memberIds []string, filter *model.VideoFilter, shared *bool) int
+		CreateProfile        func(childComplexity int, name string) int
+		DeleteCollection     func(childComplexity int, id string) int
+		FilterCollection     func(childComplexity int, id string, filter model.VideoFilter) int
+		MarkWatched          func(childComplexity int, videoID string, watched *bool) int
+		MoveInCollection     func(childComplexity int, id string, memberID string, position int) int
+		RemoveFromCollection func(childComplexity int, id string, memberIds []string) int
+		RenameCollection     func(childComplexity int, id string, name string) int
+		ReportProgress       func(childComplexity int, videoID string, seconds int) int
+		RestrictProfile      func(childComplexity int, id string, maxContentRating *string, roots []string) int
+	}
+
+	Profile struct {
+		ID               func(childComplexity int) int
+		MaxContentRating func(childComplexity int) int
+		Name             func(childComplexity int) int
+		Restricted       func(childComplexity int) int
+	}
+
+	Progress struct {
+		LastWatched func(childComplexity int) int
+		Seconds     func(childComplexity int) int
+		Watched     func(childComplexity int) int
+	}
+
 	Quality struct {
 		Resolution      func(childComplexity int) int
 		TranscodeBudget func(childComplexity int) int
@@ -71,22 +129,33 @@ type ComplexityRoot struct {
 	}
 
 	Query struct {
-		EpisodeCount func(childComplexity int, series *model.SeriesFilter, season *model.SeasonFilter) int
-		Episodes     func(childComplexity int, series *model.SeriesFilter, season *model.SeasonFilter) int
-		Seasons      func(childComplexity int, series *model.SeriesFilter) int
-		Series       func(childComplexity int, paginate *model.Paginate) int
-		Video        func(childComplexity int, id string) int
-		Videos       func(childComplexity int, paginate *model.Paginate, title *string, contributor *model.ContributorFilter) int
+		Collection       func(childComplexity int, id string) int
+		Collections      func(childComplexity int) int
+		ContinueWatching func(childComplexity int, first *int) int
+		Episode          func(childComplexity int, seriesID string, season int, episode int) int
+		EpisodeCount     func(childComplexity int, series *model.SeriesFilter, season *model.SeasonFilter) int
+		Episodes         func(childComplexity int, series *model.SeriesFilter, season *model.SeasonFilter) int
+		Franchises       func(childComplexity int) int
+		Node             func(childComplexity int, id string) int
+		Nodes            func(childComplexity int, ids []string) int
+		Profiles         func(childComplexity int) int
+		Season           func(childComplexity int, seriesID string, number int) int
+		Seasons          func(childComplexity int, series *model.SeriesFilter) int
+		Series           func(childComplexity int, paginate *model.Paginate) int
+		SeriesByID       func(childComplexity int, id string) int
+		Video            func(childComplexity int, id string) int
+		Videos           func(childComplexity int, paginate *model.Paginate, title *string, contributor *model.ContributorFilter) int
 	}
 
 	Rendition struct {
-		Cut      func(childComplexity int) int
-		Duration func(childComplexity int) int
-		ID       func(childComplexity int) int
-		IsHd     func(childComplexity int) int
-		Quality  func(childComplexity int) int
-		Size     func(childComplexity int) int
-		URL      func(childComplexity int) int
+		Cut          func(childComplexity int) int
+		Duration     func(childComplexity int) int
+		ID           func(childComplexity int) int
+		IsHd         func(childComplexity int) int
+		IsStreamable func(childComplexity int) int
+		Quality      func(childComplexity int) int
+		Size         func(childComplexity int) int
+		URL          func(childComplexity int) int
 	}
 
 	Renditions struct {
@@ -94,36 +163,59 @@ type ComplexityRoot struct {
 		Rendition func(childComplexity int, quality *model.QualityFilter) int
 	}
 
+	ScanProgress struct {
+		Done         func(childComplexity int) int
+		FilesScanned func(childComplexity int) int
+		Root         func(childComplexity int) int
+		Videos       func(childComplexity int) int
+	}
+
 	Season struct {
-		EpisodeCount func(childComplexity int) int
-		Episodes     func(childComplexity int) int
-		Season       func(childComplexity int) int
-		Series       func(childComplexity int) int
+		EpisodeCount   func(childComplexity int) int
+		Episodes       func(childComplexity int) int
+		ID             func(childComplexity int) int
+		Season         func(childComplexity int) int
+		Series         func(childComplexity int) int
+		UnwatchedCount func(childComplexity int) int
 	}
 
 	Series struct {
 		Artwork      func(childComplexity int) int
 		EpisodeCount func(childComplexity int) int
 		Episodes     func(childComplexity int) int
+		ID           func(childComplexity int) int
 		Name         func(childComplexity int) int
+		NextUp       func(childComplexity int) int
 		Seasons      func(childComplexity int) int
 		SortName     func(childComplexity int) int
 	}
 
+	Subscription struct {
+		ScanProgress func(childComplexity int) int
+		VideoAdded   func(childComplexity int) int
+		VideoRemoved func(childComplexity int) int
+		VideoUpdated func(childComplexity int) int
+	}
+
 	Video struct {
 		Artwork       func(childComplexity int) int
 		Cast          func(childComplexity int) int
 		ContentRating func(childComplexity int) int
+		DashURL       func(childComplexity int) int
 		Description   func(childComplexity int) int
 		Directors     func(childComplexity int) int
 		Episode       func(childComplexity int) int
+		Franchise     func(childComplexity int) int
 		Genre         func(childComplexity int) int
+		HlsURL        func(childComplexity int) int
 		ID            func(childComplexity int) int
+		Progress      func(childComplexity int) int
 		ReleaseYear   func(childComplexity int) int
 		Renditions    func(childComplexity int) int
 		SortTitle     func(childComplexity int) int
 		Title         func(childComplexity int) int
 		Tomatometer   func(childComplexity int) int
+		Watched       func(childComplexity int) int
 		Writers       func(childComplexity int) int
 	}
 }
@@ -132,13 +224,50 @@ type ArtworkResolver interface {
 	URL(ctx context.Context, obj *model.Artwork, geometry *model.GeometryFilter) (string, error)
 	Base64(ctx context.Context, obj *model.Artwork, geometry *model.GeometryFilter) (string, error)
 }
+type CollectionResolver interface {
+	Owner(ctx context.Context, obj *model.Collection) (*model.Profile, error)
+	Members(ctx context.Context, obj *model.Collection) ([]model.CollectionMember, error)
+	MemberCount(ctx context.Context, obj *model.Collection) (int, error)
+	Artwork(ctx context.Context, obj *model.Collection) (*model.Artwork, error)
+}
+type EpisodeResolver interface {
+	Next(ctx context.Context, obj *model.Episode) (*model.Episode, error)
+	Previous(ctx context.Context, obj *model.Episode) (*model.Episode, error)
+}
+type FranchiseResolver interface {
+	Videos(ctx context.Context, obj *model.Franchise, order *model.FranchiseOrder) ([]*model.Video, error)
+	VideoCount(ctx context.Context, obj *model.Franchise) (int, error)
+}
+type MutationResolver interface {
+	CreateProfile(ctx context.Context, name string) (*model.Profile, error)
+	RestrictProfile(ctx context.Context, id string, maxContentRating *string, roots []string) (*model.Profile, error)
+	ReportProgress(ctx context.Context, videoID string, seconds int) (*model.Video, error)
+	MarkWatched(ctx context.Context, videoID string, watched *bool) (*model.Video, error)
+	CreateCollection(ctx context.Context, name string, memberIds []string, filter *model.VideoFilter, shared *bool) (*model.Collection, error)
+	RenameCollection(ctx context.Context, id string, name string) (*model.Collection, error)
+	FilterCollection(ctx context.Context, id string, filter model.VideoFilter) (*model.Collection, error)
+	DeleteCollection(ctx context.Context, id string) (string, error)
+	AddToCollection(ctx context.Context, id string, memberIds []string, position *int) (*model.Collection, error)
+	RemoveFromCollection(ctx context.Context, id string, memberIds []string) (*model.Collection, error)
+	MoveInCollection(ctx context.Context, id string, memberID string, position int) (*model.Collection, error)
+}
 type QueryResolver interface {
+	Node(ctx context.Context, id string) (model.Node, error)
+	Nodes(ctx context.Context, ids []string) ([]model.Node, error)
 	Video(ctx context.Context, id string) (*model.Video, error)
 	Videos(ctx context.Context, paginate *model.Paginate, title *string, contributor *model.ContributorFilter) ([]*model.Video, error)
 	Series(ctx context.Context, paginate *model.Paginate) ([]*model.Series, error)
+	SeriesByID(ctx context.Context, id string) (*model.Series, error)
+	Season(ctx context.Context, seriesID string, number int) (*model.Season, error)
+	Episode(ctx context.Context, seriesID string, season int, episode int) (*model.Episode, error)
 	Seasons(ctx context.Context, series *model.SeriesFilter) ([]*model.Season, error)
 	Episodes(ctx context.Context, series *model.SeriesFilter, season *model.SeasonFilter) ([]*model.Episode, error)
 	EpisodeCount(ctx context.Context, series *model.SeriesFilter, season *model.SeasonFilter) (int, error)
+	Profiles(ctx context.Context) ([]*model.Profile, error)
+	ContinueWatching(ctx context.Context, first *int) ([]*model.Video, error)
+	Collections(ctx context.Context) ([]*model.Collection, error)
+	Collection(ctx context.Context, id string) (*model.Collection, error)
+	Franchises(ctx context.Context) ([]*model.Franchise, error)
 }
 type RenditionResolver interface {
 	URL(ctx context.Context, obj *model.Rendition) (string, error)
@@ -149,14 +278,28 @@ type RenditionsResolver interface {
 type SeasonResolver interface {
 	Episodes(ctx context.Context, obj *model.Season) ([]*model.Episode, error)
 	EpisodeCount(ctx context.Context, obj *model.Season) (int, error)
+	UnwatchedCount(ctx context.Context, obj *model.Season) (int, error)
 }
 type SeriesResolver interface {
 	Seasons(ctx context.Context, obj *model.Series) ([]*model.Season, error)
 	Episodes(ctx context.Context, obj *model.Series) ([]*model.Episode, error)
 	EpisodeCount(ctx context.Context, obj *model.Series) (int, error)
+	NextUp(ctx context.Context, obj *model.Series) (*model.Episode, error)
+}
+type SubscriptionResolver interface {
+	VideoAdded(ctx context.Context) (<-chan *model.Video, error)
+	VideoUpdated(ctx context.Context) (<-chan *model.Video, error)
+	VideoRemoved(ctx context.Context) (<-chan string, error)
+	ScanProgress(ctx context.Context) (<-chan *model.ScanProgress, error)
 }
 type VideoResolver interface {
+	HlsURL(ctx context.Context, obj *model.Video) (*string, error)
+	DashURL(ctx context.Context, obj *model.Video) (*string, error)
 	Artwork(ctx context.Context, obj *model.Video) (*model.Artwork, error)
+
+	Franchise(ctx context.Context, obj *model.Video) (*model.Franchise, error)
+	Progress(ctx context.Context, obj *model.Video) (*model.Progress, error)
+	Watched(ctx context.Context, obj *model.Video) (bool, error)
 }
 
 type executableSchema struct {
@@ -198,6 +341,62 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.Artwork.URL(childComplexity, args["geometry"].(*model.GeometryFilter)), true
 
+	case "Collection.artwork":
+		if e.complexity.Collection.Artwork == nil {
+			break
+		}
+
+		return e.complexity.Collection.Artwork(childComplexity), true
+
+	case "Collection.id":
+		if e.complexity.Collection.ID == nil {
+			break
+		}
+
+		return e.complexity.Collection.ID(childComplexity), true
+
+	case "Collection.memberCount":
+		if e.complexity.Collection.MemberCount == nil {
+			break
+		}
+
+		return e.complexity.Collection.MemberCount(childComplexity), true
+
+	case "Collection.members":
+		if e.complexity.Collection.Members == nil {
+			break
+		}
+
+		return e.complexity.Collection.Members(childComplexity), true
+
+	case "Collection.name":
+		if e.complexity.Collection.Name == nil {
+			break
+		}
+
+		return e.complexity.Collection.Name(childComplexity), true
+
+	case "Collection.owner":
+		if e.complexity.Collection.Owner == nil {
+			break
+		}
+
+		return e.complexity.Collection.Owner(childComplexity), true
+
+	case "Collection.shared":
+		if e.complexity.Collection.Shared == nil {
+			break
+		}
+
+		return e.complexity.Collection.Shared(childComplexity), true
+
+	case "Collection.smart":
+		if e.complexity.Collection.Smart == nil {
+			break
+		}
+
+		return e.complexity.Collection.Smart(childComplexity), true
+
 	case "Contributor.name":
 		if e.complexity.Contributor.Name == nil {
 			break
@@ -219,6 +418,27 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.Episode.EpisodeID(childComplexity), true
 
+	case "Episode.id":
+		if e.complexity.Episode.ID == nil {
+			break
+		}
+
+		return e.complexity.Episode.ID(childComplexity), true
+
+	case "Episode.next":
+		if e.complexity.Episode.Next == nil {
+			break
+		}
+
+		return e.complexity.Episode.Next(childComplexity), true
+
+	case "Episode.previous":
+		if e.complexity.Episode.Previous == nil {
+			break
+		}
+
+		return e.complexity.Episode.Previous(childComplexity), true
+
 	case "Episode.season":
 		if e.complexity.Episode.Season == nil {
 			break
@@ -233,957 +453,4520 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.Episode.Video(childComplexity), true
 
-	case "Quality.resolution":
-		if e.complexity.Quality.Resolution == nil {
+	case "Franchise.explicit":
+		if e.complexity.Franchise.Explicit == nil {
 			break
 		}
 
-		return e.complexity.Quality.Resolution(childComplexity), true
+		return e.complexity.Franchise.Explicit(childComplexity), true
 
-	case "Quality.transcodeBudget":
-		if e.complexity.Quality.TranscodeBudget == nil {
+	case "Franchise.id":
+		if e.complexity.Franchise.ID == nil {
 			break
 		}
 
-		return e.complexity.Quality.TranscodeBudget(childComplexity), true
+		return e.complexity.Franchise.ID(childComplexity), true
 
-	case "Quality.videoCodec":
-		if e.complexity.Quality.VideoCodec == nil {
+	case "Franchise.name":
+		if e.complexity.Franchise.Name == nil {
 			break
 		}
 
-		return e.complexity.Quality.VideoCodec(childComplexity), true
+		return e.complexity.Franchise.Name(childComplexity), true
 
-	case "Query.episodeCount":
-		if e.complexity.Query.EpisodeCount == nil {
+	case "Franchise.videoCount":
+		if e.complexity.Franchise.VideoCount == nil {
 			break
 		}
 
-		args, err := ec.field_Query_episodeCount_args(context.TODO(), rawArgs)
+		return e.complexity.Franchise.VideoCount(childComplexity), true
+
+	case "Franchise.videos":
+		if e.complexity.Franchise.Videos == nil {
+			break
+		}
+
+		args, err := ec.field_Franchise_videos_args(context.TODO(), rawArgs)
 		if err != nil {
 			return 0, false
 		}
 
-		return e.complexity.Query.EpisodeCount(childComplexity, args["series"].(*model.SeriesFilter), args["season"].(*model.SeasonFilter)), true
+		return e.complexity.Franchise.Videos(childComplexity, args["order"].(*model.FranchiseOrder)), true
 
-	case "Query.episodes":
-		if e.complexity.Query.Episodes == nil {
+	case "Mutation.addToCollection":
+		if e.complexity.Mutation.AddToCollection == nil {
 			break
 		}
 
-		args, err := ec.field_Query_episodes_args(context.TODO(), rawArgs)
+		args, err := ec.field_Mutation_addToCollection_args(context.TODO(), rawArgs)
 		if err != nil {
 			return 0, false
 		}
 
-		return e.complexity.Query.Episodes(childComplexity, args["series"].(*model.SeriesFilter), args["season"].(*model.SeasonFilter)), true
+		return e.complexity.Mutation.AddToCollection(childComplexity, args["id"].(string), args["memberIds"].([]string), args["position"].(*int)), true
 
-	case "Query.seasons":
-		if e.complexity.Query.Seasons == nil {
+	case "Mutation.createCollection":
+		if e.complexity.Mutation.CreateCollection == nil {
 			break
 		}
 
-		args, err := ec.field_Query_seasons_args(context.TODO(), rawArgs)
+		args, err := ec.field_Mutation_createCollection_args(context.TODO(), rawArgs)
 		if err != nil {
 			return 0, false
 		}
 
-		return e.complexity.Query.Seasons(childComplexity, args["series"].(*model.SeriesFilter)), true
+		return e.complexity.Mutation.CreateCollection(childComplexity, args["name"].(string), args["memberIds"].([]string), args["filter"].(*model.VideoFilter), args["shared"].(*bool)), true
 
-	case "Query.series":
-		if e.complexity.Query.Series == nil {
+	case "Mutation.createProfile":
+		if e.complexity.Mutation.CreateProfile == nil {
 			break
 		}
 
-		args, err := ec.field_Query_series_args(context.TODO(), rawArgs)
+		args, err := ec.field_Mutation_createProfile_args(context.TODO(), rawArgs)
 		if err != nil {
 			return 0, false
 		}
 
-		return e.complexity.Query.Series(childComplexity, args["paginate"].(*model.Paginate)), true
+		return e.complexity.Mutation.CreateProfile(childComplexity, args["name"].(string)), true
 
-	case "Query.video":
-		if e.complexity.Query.Video == nil {
+	case "Mutation.deleteCollection":
+		if e.complexity.Mutation.DeleteCollection == nil {
 			break
 		}
 
-		args, err := ec.field_Query_video_args(context.TODO(), rawArgs)
+		args, err := ec.field_Mutation_deleteCollection_args(context.TODO(), rawArgs)
 		if err != nil {
 			return 0, false
 		}
 
-		return e.complexity.Query.Video(childComplexity, args["id"].(string)), true
+		return e.complexity.Mutation.DeleteCollection(childComplexity, args["id"].(string)), true
 
-	case "Query.videos":
-		if e.complexity.Query.Videos == nil {
+	case "Mutation.filterCollection":
+		if e.complexity.Mutation.FilterCollection == nil {
 			break
 		}
 
-		args, err := ec.field_Query_videos_args(context.TODO(), rawArgs)
+		args, err := ec.field_Mutation_filterCollection_args(context.TODO(), rawArgs)
 		if err != nil {
 			return 0, false
 		}
 
-		return e.complexity.Query.Videos(childComplexity, args["paginate"].(*model.Paginate), args["title"].(*string), args["contributor"].(*model.ContributorFilter)), true
+		return e.complexity.Mutation.FilterCollection(childComplexity, args["id"].(string), args["filter"].(model.VideoFilter)), true
 
-	case "Rendition.cut":
-		if e.complexity.Rendition.Cut == nil {
+	case "Mutation.markWatched":
+		if e.complexity.Mutation.MarkWatched == nil {
 			break
 		}
 
-		return e.complexity.Rendition.Cut(childComplexity), true
-
-	case "Rendition.duration":
-		if e.complexity.Rendition.Duration == nil {
-			break
+		args, err := ec.field_Mutation_markWatched_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
 		}
 
-		return e.complexity.Rendition.Duration(childComplexity), true
+		return e.complexity.Mutation.MarkWatched(childComplexity, args["videoId"].(string), args["watched"].(*bool)), true
 
-	case "Rendition.id":
-		if e.complexity.Rendition.ID == nil {
+	case "Mutation.moveInCollection":
+		if e.complexity.Mutation.MoveInCollection == nil {
 			break
 		}
 
-		return e.complexity.Rendition.ID(childComplexity), true
-
-	case "Rendition.isHD":
-		if e.complexity.Rendition.IsHd == nil {
-			break
+		args, err := ec.field_Mutation_moveInCollection_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
 		}
 
-		return e.complexity.Rendition.IsHd(childComplexity), true
+		return e.complexity.Mutation.MoveInCollection(childComplexity, args["id"].(string), args["memberId"].(string), args["position"].(int)), true
 
-	case "Rendition.quality":
-		if e.complexity.Rendition.Quality == nil {
+	case "Mutation.removeFromCollection":
+		if e.complexity.Mutation.RemoveFromCollection == nil {
 			break
 		}
 
-		return e.complexity.Rendition.Quality(childComplexity), true
-
-	case "Rendition.size":
-		if e.complexity.Rendition.Size == nil {
-			break
+		args, err := ec.field_Mutation_removeFromCollection_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
 		}
 
-		return e.complexity.Rendition.Size(childComplexity), true
+		return e.complexity.Mutation.RemoveFromCollection(childComplexity, args["id"].(string), args["memberIds"].([]string)), true
 
-	case "Rendition.url":
-		if e.complexity.Rendition.URL == nil {
+	case "Mutation.renameCollection":
+		if e.complexity.Mutation.RenameCollection == nil {
 			break
 		}
 
-		return e.complexity.Rendition.URL(childComplexity), true
-
-	case "Renditions.all":
-		if e.complexity.Renditions.All == nil {
-			break
+		args, err := ec.field_Mutation_renameCollection_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
 		}
 
-		return e.complexity.Renditions.All(childComplexity), true
+		return e.complexity.Mutation.RenameCollection(childComplexity, args["id"].(string), args["name"].(string)), true
 
-	case "Renditions.rendition":
-		if e.complexity.Renditions.Rendition == nil {
+	case "Mutation.reportProgress":
+		if e.complexity.Mutation.ReportProgress == nil {
 			break
 		}
 
-		args, err := ec.field_Renditions_rendition_args(context.TODO(), rawArgs)
+		args, err := ec.field_Mutation_reportProgress_args(context.TODO(), rawArgs)
 		if err != nil {
 			return 0, false
 		}
 
-		return e.complexity.Renditions.Rendition(childComplexity, args["quality"].(*model.QualityFilter)), true
+		return e.complexity.Mutation.ReportProgress(childComplexity, args["videoId"].(string), args["seconds"].(int)), true
 
-	case "Season.episodeCount":
-		if e.complexity.Season.EpisodeCount == nil {
+	case "Mutation.restrictProfile":
+		if e.complexity.Mutation.RestrictProfile == nil {
 			break
 		}
 
-		return e.complexity.Season.EpisodeCount(childComplexity), true
-
-	case "Season.episodes":
-		if e.complexity.Season.Episodes == nil {
-			break
+		args, err := ec.field_Mutation_restrictProfile_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
 		}
 
-		return e.complexity.Season.Episodes(childComplexity), true
+		return e.complexity.Mutation.RestrictProfile(childComplexity, args["id"].(string), args["maxContentRating"].(*string), args["roots"].([]string)), true
 
-	case "Season.season":
-		if e.complexity.Season.Season == nil {
+	case "Profile.id":
+		if e.complexity.Profile.ID == nil {
 			break
 		}
 
-		return e.complexity.Season.Season(childComplexity), true
+		return e.complexity.Profile.ID(childComplexity), true
 
-	case "Season.series":
-		if e.complexity.Season.Series == nil {
+	case "Profile.maxContentRating":
+		if e.complexity.Profile.MaxContentRating == nil {
 			break
 		}
 
-		return e.complexity.Season.Series(childComplexity), true
+		return e.complexity.Profile.MaxContentRating(childComplexity), true
 
-	case "Series.artwork":
-		if e.complexity.Series.Artwork == nil {
+	case "Profile.name":
+		if e.complexity.Profile.Name == nil {
 			break
 		}
 
-		return e.complexity.Series.Artwork(childComplexity), true
+		return e.complexity.Profile.Name(childComplexity), true
 
-	case "Series.episodeCount":
-		if e.complexity.Series.EpisodeCount == nil {
+	case "Profile.restricted":
+		if e.complexity.Profile.Restricted == nil {
 			break
 		}
 
-		return e.complexity.Series.EpisodeCount(childComplexity), true
+		return e.complexity.Profile.Restricted(childComplexity), true
 
-	case "Series.episodes":
-		if e.complexity.Series.Episodes == nil {
+	case "Progress.lastWatched":
+		if e.complexity.Progress.LastWatched == nil {
 			break
 		}
 
-		return e.complexity.Series.Episodes(childComplexity), true
+		return e.complexity.Progress.LastWatched(childComplexity), true
 
-	case "Series.name":
-		if e.complexity.Series.Name == nil {
+	case "Progress.seconds":
+		if e.complexity.Progress.Seconds == nil {
 			break
 		}
 
-		return e.complexity.Series.Name(childComplexity), true
+		return e.complexity.Progress.Seconds(childComplexity), true
 
-	case "Series.seasons":
-		if e.complexity.Series.Seasons == nil {
+	case "Progress.watched":
+		if e.complexity.Progress.Watched == nil {
 			break
 		}
 
-		return e.complexity.Series.Seasons(childComplexity), true
+		return e.complexity.Progress.Watched(childComplexity), true
 
-	case "Series.sortName":
-		if e.complexity.Series.SortName == nil {
+	case "Quality.resolution":
+		if e.complexity.Quality.Resolution == nil {
 			break
 		}
 
-		return e.complexity.Series.SortName(childComplexity), true
+		return e.complexity.Quality.Resolution(childComplexity), true
 
-	case "Video.artwork":
-		if e.complexity.Video.Artwork == nil {
+	case "Quality.transcodeBudget":
+		if e.complexity.Quality.TranscodeBudget == nil {
 			break
 		}
 
-		return e.complexity.Video.Artwork(childComplexity), true
+		return e.complexity.Quality.TranscodeBudget(childComplexity), true
 
-	case "Video.cast":
-		if e.complexity.Video.Cast == nil {
+	case "Quality.videoCodec":
+		if e.complexity.Quality.VideoCodec == nil {
 			break
 		}
 
-		return e.complexity.Video.Cast(childComplexity), true
+		return e.complexity.Quality.VideoCodec(childComplexity), true
 
-	case "Video.contentRating":
-		if e.complexity.Video.ContentRating == nil {
+	case "Query.collection":
+		if e.complexity.Query.Collection == nil {
 			break
 		}
 
-		return e.complexity.Video.ContentRating(childComplexity), true
-
-	case "Video.description":
-		if e.complexity.Video.Description == nil {
-			break
+		args, err := ec.field_Query_collection_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
 		}
 
-		return e.complexity.Video.Description(childComplexity), true
+		return e.complexity.Query.Collection(childComplexity, args["id"].(string)), true
 
-	case "Video.directors":
-		if e.complexity.Video.Directors == nil {
+	case "Query.collections":
+		if e.complexity.Query.Collections == nil {
 			break
 		}
 
-		return e.complexity.Video.Directors(childComplexity), true
+		return e.complexity.Query.Collections(childComplexity), true
 
-	case "Video.episode":
-		if e.complexity.Video.Episode == nil {
+	case "Query.continueWatching":
+		if e.complexity.Query.ContinueWatching == nil {
 			break
 		}
 
-		return e.complexity.Video.Episode(childComplexity), true
-
-	case "Video.genre":
-		if e.complexity.Video.Genre == nil {
-			break
+		args, err := ec.field_Query_continueWatching_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
 		}
 
-		return e.complexity.Video.Genre(childComplexity), true
+		return e.complexity.Query.ContinueWatching(childComplexity, args["first"].(*int)), true
 
-	case "Video.id":
-		if e.complexity.Video.ID == nil {
+	case "Query.episode":
+		if e.complexity.Query.Episode == nil {
 			break
 		}
 
-		return e.complexity.Video.ID(childComplexity), true
-
-	case "Video.releaseYear":
-		if e.complexity.Video.ReleaseYear == nil {
-			break
+		args, err := ec.field_Query_episode_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
 		}
 
-		return e.complexity.Video.ReleaseYear(childComplexity), true
+		return e.complexity.Query.Episode(childComplexity, args["seriesId"].(string), args["season"].(int), args["episode"].(int)), true
 
-	case "Video.renditions":
-		if e.complexity.Video.Renditions == nil {
+	case "Query.episodeCount":
+		if e.complexity.Query.EpisodeCount == nil {
 			break
 		}
 
-		return e.complexity.Video.Renditions(childComplexity), true
-
-	case "Video.sortTitle":
-		if e.complexity.Video.SortTitle == nil {
-			break
+		args, err := ec.field_Query_episodeCount_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
 		}
 
-		return e.complexity.Video.SortTitle(childComplexity), true
+		return e.complexity.Query.EpisodeCount(childComplexity, args["series"].(*model.SeriesFilter), args["season"].(*model.SeasonFilter)), true
 
-	case "Video.title":
-		if e.complexity.Video.Title == nil {
+	case "Query.episodes":
+		if e.complexity.Query.Episodes == nil {
 			break
 		}
 
-		return e.complexity.Video.Title(childComplexity), true
+		args, err := ec.field_Query_episodes_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
 
-	case "Video.tomatometer":
-		if e.complexity.Video.Tomatometer == nil {
+		return e.complexity.Query.Episodes(childComplexity, args["series"].(*model.SeriesFilter), args["season"].(*model.SeasonFilter)), true
+
+	case "Query.franchises":
+		if e.complexity.Query.Franchises == nil {
 			break
 		}
 
-		return e.complexity.Video.Tomatometer(childComplexity), true
+		return e.complexity.Query.Franchises(childComplexity), true
 
-	case "Video.writers":
-		if e.complexity.Video.Writers == nil {
+	case "Query.node":
+		if e.complexity.Query.Node == nil {
 			break
 		}
 
-		return e.complexity.Video.Writers(childComplexity), true
-
-	}
-	return 0, false
-}
+		args, err := ec.field_Query_node_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
 
-func (e *executableSchema) Exec(ctx context.Context) graphql.ResponseHandler {
-	rc := graphql.GetOperationContext(ctx)
-	ec := executionContext{rc, e}
-	first := true
+		return e.complexity.Query.Node(childComplexity, args["id"].(string)), true
 
-	switch rc.Operation.Operation {
-	case ast.Query:
-		return func(ctx context.Context) *graphql.Response {
-			if !first {
-				return nil
-			}
-			first = false
-			data := ec._Query(ctx, rc.Operation.SelectionSet)
-			var buf bytes.Buffer
-			data.MarshalGQL(&buf)
+	case "Query.nodes":
+		if e.complexity.Query.Nodes == nil {
+			break
+		}
 
-			return &graphql.Response{
-				Data: buf.Bytes(),
-			}
+		args, err := ec.field_Query_nodes_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
 		}
 
-	default:
-		return graphql.OneShot(graphql.ErrorResponse(ctx, "unsupported GraphQL operation"))
-	}
-}
+		return e.complexity.Query.Nodes(childComplexity, args["ids"].([]string)), true
 
-type executionContext struct {
-	*graphql.OperationContext
-	*executableSchema
-}
+	case "Query.profiles":
+		if e.complexity.Query.Profiles == nil {
+			break
+		}
 
-func (ec *executionContext) introspectSchema() (*introspection.Schema, error) {
-	if ec.DisableIntrospection {
-		return nil, errors.New("introspection disabled")
-	}
-	return introspection.WrapSchema(parsedSchema), nil
-}
+		return e.complexity.Query.Profiles(childComplexity), true
 
-func (ec *executionContext) introspectType(name string) (*introspection.Type, error) {
-	if ec.DisableIntrospection {
-		return nil, errors.New("introspection disabled")
-	}
-	return introspection.WrapTypeFromDef(parsedSchema, parsedSchema.Types[name]), nil
-}
+	case "Query.season":
+		if e.complexity.Query.Season == nil {
+			break
+		}
 
-var sources = []*ast.Source{
-	{Name: "graph/schema.graphqls", Input: `"Queries."
-type Query {
-  "Get a specific video."
-  video(id: ID!): Video!
+		args, err := ec.field_Query_season_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
 
-  """
-  Get a slice of videos.
-  Filter by title and/or contributor (if specified).
-  Ordered by sortTitle.
-  """
-  videos(paginate: Paginate, title: String, contributor: ContributorFilter): [Video!]!
+		return e.complexity.Query.Season(childComplexity, args["seriesId"].(string), args["number"].(int)), true
 
-  """
-  Get a slice of TV series.
-  Ordered by series sortName.
-  """
-  series(paginate: Paginate): [Series!]!
+	case "Query.seasons":
+		if e.complexity.Query.Seasons == nil {
+			break
+		}
 
-  """
-  Get a list of TV seasons.
-  Filter by series details (if specified).
-  Ordered by series sortName then season number.
-  """
-  seasons(series: SeriesFilter): [Season!]!
+		args, err := ec.field_Query_seasons_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
 
-  """
-  Get a list of TV episodes.
-  Filter by season and series (if specified).
-  Ordered by series sortName, season number, then episode number.
-  """
-  episodes(series: SeriesFilter, season: SeasonFilter): [Episode!]!
+		return e.complexity.Query.Seasons(childComplexity, args["series"].(*model.SeriesFilter)), true
 
-  """
-  Count of TV episodes.
-  Filter by season and series (if specified).
-  """
-  episodeCount(series: SeriesFilter, season: SeasonFilter): Int!
-}
+	case "Query.series":
+		if e.complexity.Query.Series == nil {
+			break
+		}
 
+		args, err := ec.field_Query_series_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
 
-"Video details."
-type Video {
-  """
-  Video identity.
-  Currently a hash of title + releaseYear for idempotence.
-  """
-  id: ID!
+		return e.complexity.Query.Series(childComplexity, args["paginate"].(*model.Paginate)), true
 
-  """
-  Title, in en-US, without cut or year parenthetical qualifiers.
-  Currently obtained from the mp4 moov.udta.meta.ilst.©nam.data atom.
-  """
-  title: String!
+	case "Query.seriesById":
+		if e.complexity.Query.SeriesByID == nil {
+			break
+		}
 
-  """
-  Sortable title.
-  Omits leading articles such as "The", "A", or "An".
-  Destyleized and normalized (e.g., "Se7en" => "Seven").
-  Normalized the series (e.g., "Fast & Furious 2").
-  Includes explicit episode arabic-number for sequels (as roman numerals are not readily sortable).
-  Currently obtained from the mp4 moov.udta.meta.ilst.sonm.data atom else derived from title.
-  """
-  sortTitle: String!
+		args, err := ec.field_Query_seriesById_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
 
-  """
-  Year of initial/theatrical release.
-  Per Gregorian calendar.
-  Required due to remake ambiguity.
-  Currently obtained from the mp4 moov.udta.meta.ilst.©day.data atom.
-  """
-  releaseYear: Int!
+		return e.complexity.Query.SeriesByID(childComplexity, args["id"].(string)), true
 
-  """
-  List of various renditions of this video.
-  Filter by rendition quality (if specified).
-  Null or empty list implies this video is a placeholder, and renditions are coming soon.
-  """
-  renditions: Renditions
+	case "Query.video":
+		if e.complexity.Query.Video == nil {
+			break
+		}
 
-  """
-  Cover art image (optional).
-  Currently obtained from the mp4 moov.udta.meta.ilst.covr.data atom.
-  """
-  artwork: Artwork
+		args, err := ec.field_Query_video_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
 
-  """
-  Description paragraph (optional).
-  Currently obtained from the mp4 moov.udta.meta.ilst.desc.data atom.
-  """
-  description: String
+		return e.complexity.Query.Video(childComplexity, args["id"].(string)), true
 
-  "NYI"
-  directors: [Contributor!]
+	case "Query.videos":
+		if e.complexity.Query.Videos == nil {
+			break
+		}
 
-  "NYI"
-  writers: [Contributor!]
+		args, err := ec.field_Query_videos_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
 
-  "NYI"
-  cast: [Contributor!]	        # TODO add other kinds of contributors
+		return e.complexity.Query.Videos(childComplexity, args["paginate"].(*model.Paginate), args["title"].(*string), args["contributor"].(*model.ContributorFilter)), true
 
-  """
-  Primary genre (optional).
-  Currently obtained from the mp4 moov.udta.meta.ilst.©gen.data atom.
-  """
-  genre: String
+	case "Rendition.cut":
+		if e.complexity.Rendition.Cut == nil {
+			break
+		}
 
-  "Content advisory rating (optional)."
-  contentRating: String
+		return e.complexity.Rendition.Cut(childComplexity), true
 
-  "Rotten Tomatoes reviewer score (optional)."
-  tomatometer: Int
+	case "Rendition.duration":
+		if e.complexity.Rendition.Duration == nil {
+			break
+		}
 
-  "Episodic details (optional)."
-  episode: Episode
-}
+		return e.complexity.Rendition.Duration(childComplexity), true
 
+	case "Rendition.id":
+		if e.complexity.Rendition.ID == nil {
+			break
+		}
 
-"NYI"
-type Contributor {
-  name: String!
-}
+		return e.complexity.Rendition.ID(childComplexity), true
 
-"NYI"
-input ContributorFilter {
-  name: String
-}
+	case "Rendition.isHD":
+		if e.complexity.Rendition.IsHd == nil {
+			break
+		}
 
+		return e.complexity.Rendition.IsHd(childComplexity), true
 
-"Series details."
-type Series {
-  """
-  Series name.
-  May include reboot qualifiers (e.g., "The Twilight Zone (2019)").
-  Currently obtained from the mp4 moov.udta.meta.ilst.tvsh.data atom.
-  """
-  name: String!
+	case "Rendition.isStreamable":
+		if e.complexity.Rendition.IsStreamable == nil {
+			break
+		}
 
-  """
-  Sortable name.
-  Omits leading articles such as "The", "A", or "An".
-  Currently obtained from the mp4 moov.udta.meta.ilst.sosn.data atom else derived from name.
-  """
-  sortName: String!
+		return e.complexity.Rendition.IsStreamable(childComplexity), true
 
-  """
-  Series image (optional).
-  NYI.
-  """
-  artwork: Artwork
+	case "Rendition.quality":
+		if e.complexity.Rendition.Quality == nil {
+			break
+		}
 
-  "List of seasons."
-  seasons: [Season!]!
+		return e.complexity.Rendition.Quality(childComplexity), true
 
-  "List of episodes, regardless of season."
-  episodes: [Episode!]!
+	case "Rendition.size":
+		if e.complexity.Rendition.Size == nil {
+			break
+		}
 
-  "Count of episodes, regardless of season."
-  episodeCount: Int!
-}
+		return e.complexity.Rendition.Size(childComplexity), true
 
-"Series selection."
-input SeriesFilter {
-  name: String
-}
+	case "Rendition.url":
+		if e.complexity.Rendition.URL == nil {
+			break
+		}
 
+		return e.complexity.Rendition.URL(childComplexity), true
 
-"Season details."
-type Season {
-  "Series."
-  series: Series!
+	case "Renditions.all":
+		if e.complexity.Renditions.All == nil {
+			break
+		}
 
-  """
-  Season number, within a series.
-  Currently obtained from the mp4 moov.udta.meta.ilst.tvsn.data atom.
-  """
-  season: Int!
+		return e.complexity.Renditions.All(childComplexity), true
 
-  "List of episodes in season."
-  episodes: [Episode!]!
+	case "Renditions.rendition":
+		if e.complexity.Renditions.Rendition == nil {
+			break
+		}
 
-  "Count of episodes in season."
-  episodeCount: Int!
-}
+		args, err := ec.field_Renditions_rendition_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
 
-"Season selection."
-input SeasonFilter {
-  season: Int			# XXX paginator?
-}
+		return e.complexity.Renditions.Rendition(childComplexity, args["quality"].(*model.QualityFilter)), true
 
+	case "ScanProgress.done":
+		if e.complexity.ScanProgress.Done == nil {
+			break
+		}
 
-"Episode (i.e., TV Show) details."
-type Episode {
-  "Season."
-  season: Season!
+		return e.complexity.ScanProgress.Done(childComplexity), true
 
-  """
-  Episode number, within a season.
-  Currently obtained from the mp4 moov.udta.meta.ilst.tves.data atom.
-  """
-  episode: Int!
+	case "ScanProgress.filesScanned":
+		if e.complexity.ScanProgress.FilesScanned == nil {
+			break
+		}
 
-  """
-  Episode ID, within a series.
-  Currently obtained from the mp4 moov.udta.meta.ilst.tven.data atom.
-  """
-  episodeID: String
+		return e.complexity.ScanProgress.FilesScanned(childComplexity), true
 
-  "Video."
-  video: Video!
-}
+	case "ScanProgress.root":
+		if e.complexity.ScanProgress.Root == nil {
+			break
+		}
 
+		return e.complexity.ScanProgress.Root(childComplexity), true
 
-"Episode selection."
-input EpisodeFilter {
-  episode: Int			# XXX paginator?
-}
+	case "ScanProgress.videos":
+		if e.complexity.ScanProgress.Videos == nil {
+			break
+		}
 
+		return e.complexity.ScanProgress.Videos(childComplexity), true
 
-type Renditions {
-  all: [Rendition!]
-  rendition(quality: QualityFilter): Rendition
-}
+	case "Season.episodeCount":
+		if e.complexity.Season.EpisodeCount == nil {
+			break
+		}
 
+		return e.complexity.Season.EpisodeCount(childComplexity), true
 
-"Video rendition details."
-type Rendition {
-  """
-  Rendition identity.
-  Currently a hash of local path for idempotence.
-  """
-  id: ID!
+	case "Season.episodes":
+		if e.complexity.Season.Episodes == nil {
+			break
+		}
 
-  "Video rendition download URL."
-  url: String!
+		return e.complexity.Season.Episodes(childComplexity), true
 
-  """
-  Cut (optional).
-  Omit wrapping parenthesis.
-  If absent, "theatrical" is implied.
-  """
-  cut: String
+	case "Season.id":
+		if e.complexity.Season.ID == nil {
+			break
+		}
 
-  "Quality details."
-  quality: Quality!
+		return e.complexity.Season.ID(childComplexity), true
 
-  "Length of video, in minutes."
-  duration: Int
+	case "Season.season":
+		if e.complexity.Season.Season == nil {
+			break
+		}
 
-  """
-  Is video high definition, i.e., 1080p?
-  Currently obtained from the mp4 moov.udta.meta.ilst.hdvd.data atom.
-  """
-  isHD: Boolean
+		return e.complexity.Season.Season(childComplexity), true
 
-  "Size of the video, in bytes."
-  size: Int!
-}
+	case "Season.series":
+		if e.complexity.Season.Series == nil {
+			break
+		}
 
+		return e.complexity.Season.Series(childComplexity), true
 
-"Quality details."
-type Quality {
-  videoCodec: VideoCodec!
-  resolution: Resolution!
-  transcodeBudget: TranscodeBudget
-}
+	case "Season.unwatchedCount":
+		if e.complexity.Season.UnwatchedCount == nil {
+			break
+		}
 
-"Quality selection."
-input QualityFilter {
-  videoCodec: VideoCodec
-  resolution: Resolution
-}
+		return e.complexity.Season.UnwatchedCount(childComplexity), true
 
-"""
-Video codec.
-Useful for playback hardware limitations.
-"""
-enum VideoCodec {
-  "h.265"
-  h265
+	case "Series.artwork":
+		if e.complexity.Series.Artwork == nil {
+			break
+		}
 
-  "h.264 (min-spec, default)"
-  h264
-}
+		return e.complexity.Series.Artwork(childComplexity), true
 
-"""
-Label to describe resolution.
-Examples: 480p, 720p, 1080p.
-[Not an enum because of leading-digit values.]
-"""
-scalar Resolution
+	case "Series.episodeCount":
+		if e.complexity.Series.EpisodeCount == nil {
+			break
+		}
 
-"Amount of time and bitrate afforded to HandBrake transcode."
-enum TranscodeBudget {
-  Super_HQ
-  HQ
-  Fast
-  Very_Fast
-}
+		return e.complexity.Series.EpisodeCount(childComplexity), true
 
+	case "Series.episodes":
+		if e.complexity.Series.Episodes == nil {
+			break
+		}
 
-"Geometry selection."
-input GeometryFilter {
-  width: Int
-  height: Int
-}
+		return e.complexity.Series.Episodes(childComplexity), true
 
+	case "Series.id":
+		if e.complexity.Series.ID == nil {
+			break
+		}
 
-"""
-Artwork.
-"""
-type Artwork {
-  """
-  URL to image/jpeg.
-  Eventually downsampled per geometry.
-  """
-  url(geometry: GeometryFilter): String!
+		return e.complexity.Series.ID(childComplexity), true
 
-  """
-  JPEG encoded in base64.
-  Downsampled per geometry.
-  """
-  base64(geometry: GeometryFilter): String!
-}
+	case "Series.name":
+		if e.complexity.Series.Name == nil {
+			break
+		}
 
+		return e.complexity.Series.Name(childComplexity), true
 
-"Select a slice of identifiable objects."
-input Paginate {
-  """
-  Maximum length of list returned (optional).
-  If omitted, return remaining objects.
-  """
-  first: Int
+	case "Series.nextUp":
+		if e.complexity.Series.NextUp == nil {
+			break
+		}
 
-  """
-  Return objects after this identified one (optional).
-  Requires a stable list.
-  """
-  after: ID
-}
-`, BuiltIn: false},
-}
-var parsedSchema = gqlparser.MustLoadSchema(sources...)
+		return e.complexity.Series.NextUp(childComplexity), true
 
-// endregion ************************** generated!.gotpl **************************
+	case "Series.seasons":
+		if e.complexity.Series.Seasons == nil {
+			break
+		}
 
-// region    ***************************** args.gotpl *****************************
+		return e.complexity.Series.Seasons(childComplexity), true
 
-func (ec *executionContext) field_Artwork_base64_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
-	var err error
-	args := map[string]interface{}{}
-	var arg0 *model.GeometryFilter
-	if tmp, ok := rawArgs["geometry"]; ok {
-		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("geometry"))
-		arg0, err = ec.unmarshalOGeometryFilter2ᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐGeometryFilter(ctx, tmp)
-		if err != nil {
-			return nil, err
+	case "Series.sortName":
+		if e.complexity.Series.SortName == nil {
+			break
 		}
-	}
-	args["geometry"] = arg0
-	return args, nil
-}
 
-func (ec *executionContext) field_Artwork_url_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
-	var err error
-	args := map[string]interface{}{}
-	var arg0 *model.GeometryFilter
-	if tmp, ok := rawArgs["geometry"]; ok {
-		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("geometry"))
-		arg0, err = ec.unmarshalOGeometryFilter2ᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐGeometryFilter(ctx, tmp)
-		if err != nil {
-			return nil, err
-		}
-	}
-	args["geometry"] = arg0
-	return args, nil
-}
+		return e.complexity.Series.SortName(childComplexity), true
 
-func (ec *executionContext) field_Query___type_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
-	var err error
-	args := map[string]interface{}{}
-	var arg0 string
-	if tmp, ok := rawArgs["name"]; ok {
-		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("name"))
-		arg0, err = ec.unmarshalNString2string(ctx, tmp)
-		if err != nil {
-			return nil, err
+	case "Subscription.scanProgress":
+		if e.complexity.Subscription.ScanProgress == nil {
+			break
 		}
+
+		return e.complexity.Subscription.ScanProgress(childComplexity), true
+
+	case "Subscription.videoAdded":
+		if e.complexity.Subscription.VideoAdded == nil {
+			break
+		}
+
+		return e.complexity.Subscription.VideoAdded(childComplexity), true
+
+	case "Subscription.videoRemoved":
+		if e.complexity.Subscription.VideoRemoved == nil {
+			break
+		}
+
+		return e.complexity.Subscription.VideoRemoved(childComplexity), true
+
+	case "Subscription.videoUpdated":
+		if e.complexity.Subscription.VideoUpdated == nil {
+			break
+		}
+
+		return e.complexity.Subscription.VideoUpdated(childComplexity), true
+
+	case "Video.artwork":
+		if e.complexity.Video.Artwork == nil {
+			break
+		}
+
+		return e.complexity.Video.Artwork(childComplexity), true
+
+	case "Video.cast":
+		if e.complexity.Video.Cast == nil {
+			break
+		}
+
+		return e.complexity.Video.Cast(childComplexity), true
+
+	case "Video.contentRating":
+		if e.complexity.Video.ContentRating == nil {
+			break
+		}
+
+		return e.complexity.Video.ContentRating(childComplexity), true
+
+	case "Video.dashUrl":
+		if e.complexity.Video.DashURL == nil {
+			break
+		}
+
+		return e.complexity.Video.DashURL(childComplexity), true
+
+	case "Video.description":
+		if e.complexity.Video.Description == nil {
+			break
+		}
+
+		return e.complexity.Video.Description(childComplexity), true
+
+	case "Video.directors":
+		if e.complexity.Video.Directors == nil {
+			break
+		}
+
+		return e.complexity.Video.Directors(childComplexity), true
+
+	case "Video.episode":
+		if e.complexity.Video.Episode == nil {
+			break
+		}
+
+		return e.complexity.Video.Episode(childComplexity), true
+
+	case "Video.franchise":
+		if e.complexity.Video.Franchise == nil {
+			break
+		}
+
+		return e.complexity.Video.Franchise(childComplexity), true
+
+	case "Video.genre":
+		if e.complexity.Video.Genre == nil {
+			break
+		}
+
+		return e.complexity.Video.Genre(childComplexity), true
+
+	case "Video.hlsUrl":
+		if e.complexity.Video.HlsURL == nil {
+			break
+		}
+
+		return e.complexity.Video.HlsURL(childComplexity), true
+
+	case "Video.id":
+		if e.complexity.Video.ID == nil {
+			break
+		}
+
+		return e.complexity.Video.ID(childComplexity), true
+
+	case "Video.progress":
+		if e.complexity.Video.Progress == nil {
+			break
+		}
+
+		return e.complexity.Video.Progress(childComplexity), true
+
+	case "Video.releaseYear":
+		if e.complexity.Video.ReleaseYear == nil {
+			break
+		}
+
+		return e.complexity.Video.ReleaseYear(childComplexity), true
+
+	case "Video.renditions":
+		if e.complexity.Video.Renditions == nil {
+			break
+		}
+
+		return e.complexity.Video.Renditions(childComplexity), true
+
+	case "Video.sortTitle":
+		if e.complexity.Video.SortTitle == nil {
+			break
+		}
+
+		return e.complexity.Video.SortTitle(childComplexity), true
+
+	case "Video.title":
+		if e.complexity.Video.Title == nil {
+			break
+		}
+
+		return e.complexity.Video.Title(childComplexity), true
+
+	case "Video.tomatometer":
+		if e.complexity.Video.Tomatometer == nil {
+			break
+		}
+
+		return e.complexity.Video.Tomatometer(childComplexity), true
+
+	case "Video.watched":
+		if e.complexity.Video.Watched == nil {
+			break
+		}
+
+		return e.complexity.Video.Watched(childComplexity), true
+
+	case "Video.writers":
+		if e.complexity.Video.Writers == nil {
+			break
+		}
+
+		return e.complexity.Video.Writers(childComplexity), true
+
+	}
+	return 0, false
+}
+
+func (e *executableSchema) Exec(ctx context.Context) graphql.ResponseHandler {
+	rc := graphql.GetOperationContext(ctx)
+	ec := executionContext{rc, e}
+	first := true
+
+	switch rc.Operation.Operation {
+	case ast.Query:
+		return func(ctx context.Context) *graphql.Response {
+			if !first {
+				return nil
+			}
+			first = false
+			data := ec._Query(ctx, rc.Operation.SelectionSet)
+			var buf bytes.Buffer
+			data.MarshalGQL(&buf)
+
+			return &graphql.Response{
+				Data: buf.Bytes(),
+			}
+		}
+	case ast.Mutation:
+		return func(ctx context.Context) *graphql.Response {
+			if !first {
+				return nil
+			}
+			first = false
+			data := ec._Mutation(ctx, rc.Operation.SelectionSet)
+			var buf bytes.Buffer
+			data.MarshalGQL(&buf)
+
+			return &graphql.Response{
+				Data: buf.Bytes(),
+			}
+		}
+	case ast.Subscription:
+		next := ec._Subscription(ctx, rc.Operation.SelectionSet)
+
+		var buf bytes.Buffer
+		return func(ctx context.Context) *graphql.Response {
+			buf.Reset()
+			data := next()
+
+			if data == nil {
+				return nil
+			}
+			data.MarshalGQL(&buf)
+
+			return &graphql.Response{
+				Data: buf.Bytes(),
+			}
+		}
+
+	default:
+		return graphql.OneShot(graphql.ErrorResponse(ctx, "unsupported GraphQL operation"))
+	}
+}
+
+type executionContext struct {
+	*graphql.OperationContext
+	*executableSchema
+}
+
+func (ec *executionContext) introspectSchema() (*introspection.Schema, error) {
+	if ec.DisableIntrospection {
+		return nil, errors.New("introspection disabled")
+	}
+	return introspection.WrapSchema(parsedSchema), nil
+}
+
+func (ec *executionContext) introspectType(name string) (*introspection.Type, error) {
+	if ec.DisableIntrospection {
+		return nil, errors.New("introspection disabled")
+	}
+	return introspection.WrapTypeFromDef(parsedSchema, parsedSchema.Types[name]), nil
+}
+
+var sources = []*ast.Source{
+	{Name: "graph/schema.graphqls", Input: `"Restricted to administrators."
+directive @admin on FIELD_DEFINITION
+
+
+"Queries."
+type Query {
+  """
+  Get any identifiable object.
+  Legacy IDs (e.g., "Title (1999)") are also accepted.
+  """
+  node(id: ID!): Node
+
+  """
+  Get several identifiable objects.
+  Unknown IDs yield null entries.
+  """
+  nodes(ids: [ID!]!): [Node]!
+
+  "Get a specific video."
+  video(id: ID!): Video!
+
+  """
+  Get a slice of videos.
+  Filter by title and/or contributor (if specified).
+  Ordered by sortTitle.
+  """
+  videos(paginate: Paginate, title: String, contributor: ContributorFilter): [Video!]!
+
+  """
+  Get a slice of TV series.
+  Ordered by series sortName.
+  """
+  series(paginate: Paginate): [Series!]!
+
+  "Get a specific TV series."
+  seriesById(id: ID!): Series
+
+  "Get a specific TV season."
+  season(seriesId: ID!, number: Int!): Season
+
+  "Get a specific TV episode."
+  episode(seriesId: ID!, season: Int!, episode: Int!): Episode
+
+  """
+  Get a list of TV seasons.
+  Filter by series details (if specified).
+  Ordered by series sortName then season number.
+  """
+  seasons(series: SeriesFilter): [Season!]!
+
+  """
+  Get a list of TV episodes.
+  Filter by season and series (if specified).
+  Ordered by series sortName, season number, then episode number.
+  """
+  episodes(series: SeriesFilter, season: SeasonFilter): [Episode!]!
+
+  """
+  Count of TV episodes.
+  Filter by season and series (if specified).
+  """
+  episodeCount(series: SeriesFilter, season: SeasonFilter): Int!
+
+  """
+  List of viewer profiles.
+  Ordered by name.
+  """
+  profiles: [Profile!]! @admin
+
+  """
+  Partially watched movies and the next-up episode of each series underway, for the selected profile.
+  Ordered by most recently watched.
+  """
+  continueWatching(first: Int): [Video!]!
+
+  """
+  List of collections: those shared, and those of the selected profile.
+  Administrators see every collection.
+  Ordered by name.
+  """
+  collections: [Collection!]!
+
+  "Get a specific collection."
+  collection(id: ID!): Collection
+
+  """
+  List of franchises.
+  Ordered by sortable name.
+  """
+  franchises: [Franchise!]!
+}
+
+
+"""
+Mutations.
+Watch state applies to the profile selected by the X-Profile request header (else the default profile).
+"""
+type Mutation {
+  "Create a viewer profile."
+  createProfile(name: String!): Profile! @admin
+
+  """
+  Restrict a profile to videos rated at most maxContentRating and/or found under roots (directories).
+  Null or empty lifts a restriction.
+  """
+  restrictProfile(id: ID!, maxContentRating: String, roots: [String!]): Profile! @admin
+
+  "Record playback position, in seconds."
+  reportProgress(videoId: ID!, seconds: Int!): Video!
+
+  "Record (or clear) completion."
+  markWatched(videoId: ID!, watched: Boolean = true): Video!
+
+  """
+  Create a collection of videos, series, and/or episodes.
+  With a filter, create a smart collection instead.
+  Shared collections (the default for administrators) are seen by every profile, and only administrators may create or edit them.
+  Otherwise, the collection belongs to the selected profile, which alone sees and edits it.
+  """
+  createCollection(name: String!, memberIds: [ID!], filter: VideoFilter, shared: Boolean): Collection!
+
+  "Rename a collection."
+  renameCollection(id: ID!, name: String!): Collection!
+
+  "Change the filter of a smart collection."
+  filterCollection(id: ID!, filter: VideoFilter!): Collection!
+
+  "Delete a collection.  Yields its ID."
+  deleteCollection(id: ID!): ID!
+
+  """
+  Add members to a collection, at position (else at the end).
+  Members already present are ignored.
+  """
+  addToCollection(id: ID!, memberIds: [ID!]!, position: Int): Collection!
+
+  "Remove members from a collection."
+  removeFromCollection(id: ID!, memberIds: [ID!]!): Collection!
+
+  "Move a member to another position within a collection."
+  moveInCollection(id: ID!, memberId: ID!, position: Int!): Collection!
+}
+
+
+"""
+Identifiable object.
+IDs are opaque, URL-safe, and stable across rescans.
+"""
+interface Node {
+  id: ID!
+}
+
+
+"""
+Subscriptions.
+Slow subscribers miss their oldest undelivered events.
+"""
+type Subscription {
+  "A video was found."
+  videoAdded: Video!
+
+  "A video gained or lost renditions."
+  videoUpdated: Video!
+
+  "A video lost all of its renditions.  Yields its ID."
+  videoRemoved: ID!
+
+  "Survey progress, periodically and upon completion."
+  scanProgress: ScanProgress!
+}
+
+
+"Survey progress."
+type ScanProgress {
+  "Library root being surveyed."
+  root: String!
+
+  "Count of video files scanned so far."
+  filesScanned: Int!
+
+  "Count of videos found so far."
+  videos: Int!
+
+  "Has the survey completed?"
+  done: Boolean!
+}
+
+
+"Video details."
+type Video implements Node {
+  """
+  Video identity.
+  Currently a hash of title + releaseYear (plus episode details, if any) for idempotence.
+  """
+  id: ID!
+
+  """
+  Title, in en-US, without cut or year parenthetical qualifiers.
+  Currently obtained from the mp4 moov.udta.meta.ilst.©nam.data atom.
+  """
+  title: String!
+
+  """
+  Sortable title.
+  Omits leading articles such as "The", "A", or "An".
+  Destyleized and normalized (e.g., "Se7en" => "Seven").
+  Normalized the series (e.g., "Fast & Furious 2").
+  Includes explicit episode arabic-number for sequels (as roman numerals are not readily sortable).
+  Currently obtained from the mp4 moov.udta.meta.ilst.sonm.data atom else derived from title.
+  """
+  sortTitle: String!
+
+  """
+  Year of initial/theatrical release.
+  Per Gregorian calendar.
+  Required due to remake ambiguity.
+  Currently obtained from the mp4 moov.udta.meta.ilst.©day.data atom.
+  """
+  releaseYear: Int!
+
+  """
+  List of various renditions of this video.
+  Filter by rendition quality (if specified).
+  Null or empty list implies this video is a placeholder, and renditions are coming soon.
+  """
+  renditions: Renditions
+
+  """
+  HLS master playlist URL (optional), listing each rendition as a variant.
+  Segments are remuxed from the renditions on the fly.
+  Null without renditions.
+  """
+  hlsUrl: String
+
+  """
+  DASH manifest URL (optional), listing each rendition as a representation.
+  Shares segments with hlsUrl.
+  Null without renditions.
+  """
+  dashUrl: String
+
+  """
+  Cover art image (optional).
+  Currently obtained from the mp4 moov.udta.meta.ilst.covr.data atom.
+  """
+  artwork: Artwork
+
+  """
+  Description paragraph (optional).
+  Currently obtained from the mp4 moov.udta.meta.ilst.desc.data atom.
+  """
+  description: String
+
+  "NYI"
+  directors: [Contributor!]
+
+  "NYI"
+  writers: [Contributor!]
+
+  "NYI"
+  cast: [Contributor!]	        # TODO add other kinds of contributors
+
+  """
+  Primary genre (optional).
+  Currently obtained from the mp4 moov.udta.meta.ilst.©gen.data atom.
+  """
+  genre: String
+
+  """
+  Content advisory rating (optional), e.g., "PG-13" or "TV-14".
+  Currently obtained from the mp4 moov.udta.meta.ilst.----.iTunEXTC atom.
+  """
+  contentRating: String
+
+  "Rotten Tomatoes reviewer score (optional)."
+  tomatometer: Int
+
+  "Episodic details (optional)."
+  episode: Episode
+
+  "Franchise, e.g., of sequels and prequels (optional)."
+  franchise: Franchise
+
+  "Watch state of the selected profile (optional)."
+  progress: Progress
+
+  "Has the selected profile watched this video?"
+  watched: Boolean!
+}
+
+
+"Viewer profile."
+type Profile {
+  id: ID!
+  name: String!
+
+  "Does the profile see less than the whole library?"
+  restricted: Boolean!
+
+  """
+  Most mature content rating the profile sees (optional), e.g., "PG" or "TV-PG".
+  Unrated videos are hidden from restricted profiles.
+  """
+  maxContentRating: String
+}
+
+
+"Watch state of one video."
+type Progress {
+  "Playback position, in seconds."
+  seconds: Int!
+
+  "Has the video been watched to completion?"
+  watched: Boolean!
+
+  "When the watch state was last reported."
+  lastWatched: Time!
+}
+
+scalar Time
+
+
+"Curated (or smart) list of videos, series, and episodes."
+type Collection implements Node {
+  id: ID!
+
+  name: String!
+
+  "Is membership defined by a video filter, rather than curated?"
+  smart: Boolean!
+
+  "Is the collection seen by every profile, rather than owned by one?"
+  shared: Boolean!
+
+  "Profile owning the collection, unless shared (optional)."
+  owner: Profile
+
+  """
+  Members, in curated order.
+  Smart collection members are the videos matching its filter, ordered by sortTitle, and follow library changes.
+  Members missing from the library are omitted.
+  """
+  members: [CollectionMember!]!
+
+  "Count of members."
+  memberCount: Int!
+
+  "Artwork of the first member having some (optional)."
+  artwork: Artwork
+}
+
+union CollectionMember = Video | Series | Episode
+
+"Video selection."
+input VideoFilter {
+  title: String
+
+  "Case-insensitive title substring."
+  titleContains: String
+
+  genre: String
+
+  contentRating: String
+
+  "Earliest release year, inclusive."
+  releasedFrom: Int
+
+  "Latest release year, inclusive."
+  releasedUntil: Int
+
+  "Episodes only (true) or movies only (false)."
+  episodic: Boolean
+
+  "Episodes of matching series only."
+  series: SeriesFilter
+}
+
+
+"""
+Related movies, e.g., sequels and prequels.
+Grouped per the FRANCHISE (and FRANCHISE ORDER) mp4 ---- atoms, else a .franchise sidecar file, else similar titles (e.g., "Alien" and "Alien 3").
+"""
+type Franchise implements Node {
+  id: ID!
+
+  name: String!
+
+  "Is grouping per metadata, rather than title similarity?"
+  explicit: Boolean!
+
+  "Videos, in the order specified."
+  videos(order: FranchiseOrder = release): [Video!]!
+
+  "Count of videos."
+  videoCount: Int!
+}
+
+"Franchise video ordering."
+enum FranchiseOrder {
+  "By release year."
+  release
+
+  """
+  In-universe, per FRANCHISE ORDER metadata.
+  Videos lacking an order follow, by release year.
+  """
+  chronological
+}
+
+
+"NYI"
+type Contributor {
+  name: String!
+}
+
+"NYI"
+input ContributorFilter {
+  name: String
+}
+
+
+"Series details."
+type Series implements Node {
+  """
+  Series identity.
+  Currently a hash of name for idempotence.
+  """
+  id: ID!
+
+  """
+  Series name.
+  May include reboot qualifiers (e.g., "The Twilight Zone (2019)").
+  Currently obtained from the mp4 moov.udta.meta.ilst.tvsh.data atom.
+  """
+  name: String!
+
+  """
+  Sortable name.
+  Omits leading articles such as "The", "A", or "An".
+  Currently obtained from the mp4 moov.udta.meta.ilst.sosn.data atom else derived from name.
+  """
+  sortName: String!
+
+  """
+  Series image (optional).
+  NYI.
+  """
+  artwork: Artwork
+
+  "List of seasons."
+  seasons: [Season!]!
+
+  "List of episodes, regardless of season."
+  episodes: [Episode!]!
+
+  "Count of episodes, regardless of season."
+  episodeCount: Int!
+
+  """
+  Episode the selected profile should watch next (optional).
+  Resumes an episode in progress, else follows the most recently watched episode.
+  Null once all remaining episodes are watched.
+  """
+  nextUp: Episode
+}
+
+"Series selection."
+input SeriesFilter {
+  id: ID
+  name: String
+}
+
+
+"Season details."
+type Season implements Node {
+  """
+  Season identity.
+  Currently a hash of series name + season number for idempotence.
+  """
+  id: ID!
+
+  "Series."
+  series: Series!
+
+  """
+  Season number, within a series.
+  Currently obtained from the mp4 moov.udta.meta.ilst.tvsn.data atom.
+  """
+  season: Int!
+
+  "List of episodes in season."
+  episodes: [Episode!]!
+
+  "Count of episodes in season."
+  episodeCount: Int!
+
+  "Count of episodes in season the selected profile has not watched."
+  unwatchedCount: Int!
+}
+
+"Season selection."
+input SeasonFilter {
+  season: Int			# XXX paginator?
+}
+
+
+"Episode (i.e., TV Show) details."
+type Episode implements Node {
+  """
+  Episode identity.
+  Distinct from the identity of its video.
+  """
+  id: ID!
+
+  "Season."
+  season: Season!
+
+  """
+  Episode number, within a season.
+  Currently obtained from the mp4 moov.udta.meta.ilst.tves.data atom.
+  """
+  episode: Int!
+
+  """
+  Episode ID, within a series.
+  Currently obtained from the mp4 moov.udta.meta.ilst.tven.data atom.
+  """
+  episodeID: String
+
+  "Video."
+  video: Video!
+
+  "Following episode in the series, crossing seasons (optional)."
+  next: Episode
+
+  "Preceding episode in the series, crossing seasons (optional)."
+  previous: Episode
+}
+
+
+"Episode selection."
+input EpisodeFilter {
+  episode: Int			# XXX paginator?
+}
+
+
+type Renditions {
+  all: [Rendition!]
+  rendition(quality: QualityFilter): Rendition
+}
+
+
+"Video rendition details."
+type Rendition implements Node {
+  """
+  Rendition identity.
+  Currently a hash of path, relative to the library root, for idempotence.
+  """
+  id: ID!
+
+  "Video rendition download URL."
+  url: String!
+
+  """
+  Cut (optional).
+  Omit wrapping parenthesis.
+  If absent, "theatrical" is implied.
+  """
+  cut: String
+
+  "Quality details."
+  quality: Quality!
+
+  "Length of video, in minutes."
+  duration: Int
+
+  """
+  Is video high definition, i.e., 1080p?
+  Currently obtained from the mp4 moov.udta.meta.ilst.hdvd.data atom.
+  """
+  isHD: Boolean
+
+  """
+  Does the moov atom precede mdat, so playback may begin before download completes?
+  Regardless, url serves the moov first, relocated on the fly.
+  """
+  isStreamable: Boolean!
+
+  "Size of the video, in bytes."
+  size: Int!
+}
+
+
+"Quality details."
+type Quality {
+  videoCodec: VideoCodec!
+  resolution: Resolution!
+  transcodeBudget: TranscodeBudget
+}
+
+"Quality selection."
+input QualityFilter {
+  videoCodec: VideoCodec
+  resolution: Resolution
+}
+
+"""
+Video codec.
+Useful for playback hardware limitations.
+"""
+enum VideoCodec {
+  "h.265"
+  h265
+
+  "h.264 (min-spec, default)"
+  h264
+}
+
+"""
+Label to describe resolution.
+Examples: 480p, 720p, 1080p.
+[Not an enum because of leading-digit values.]
+"""
+scalar Resolution
+
+"Amount of time and bitrate afforded to HandBrake transcode."
+enum TranscodeBudget {
+  Super_HQ
+  HQ
+  Fast
+  Very_Fast
+}
+
+
+"""
+Geometry selection.
+Artwork is downsampled, never upsampled.
+"""
+input GeometryFilter {
+  "Width, in CSS pixels (optional)."
+  width: Int
+
+  "Height, in CSS pixels (optional)."
+  height: Int
+
+  "How artwork fits both width and height (if specified); by default, FILL."
+  fit: ArtworkFit
+
+  "Where artwork is cropped from (per COVER) or placed (per PAD); by default, CENTER."
+  anchor: ArtworkAnchor
+
+  """
+  Padding color (per PAD), as "#rgb", "#rrggbb", or "#rrggbbaa"; by default, black.
+  Alpha applies to PNG and WEBP only.
+  """
+  background: String
+
+  "Device pixel ratio, scaling width and height, 1 to 4 (optional)."
+  dpr: Float
+
+  "Encoding (optional); by default, that of the cover art."
+  format: ArtworkFormat
+
+  "Lossy encoding quality, 1 to 100 (optional)."
+  quality: Int
+}
+
+
+"How artwork fits a width and height."
+enum ArtworkFit {
+  "Scale to fit within, preserving aspect ratio."
+  CONTAIN
+
+  "Scale to cover, preserving aspect ratio, and crop the excess."
+  COVER
+
+  "Scale to exactly, distorting aspect ratio (unless shrunk lest artwork be upsampled)."
+  FILL
+
+  "Scale to fit within, preserving aspect ratio, and pad with background."
+  PAD
+}
+
+
+"Artwork gravity, for cropping or padding."
+enum ArtworkAnchor {
+  CENTER
+  TOP
+  BOTTOM
+  LEFT
+  RIGHT
+  TOP_LEFT
+  TOP_RIGHT
+  BOTTOM_LEFT
+  BOTTOM_RIGHT
+}
+
+
+"""
+Image encodings.
+WEBP is available only if tvql was built with an encoder.
+"""
+enum ArtworkFormat {
+  JPEG
+  PNG
+  WEBP
+}
+
+
+"""
+Artwork.
+"""
+type Artwork {
+  """
+  URL to the image.
+  Eventually downsampled and encoded per geometry.
+  Absent a format, one is negotiated per the Accept header.
+  """
+  url(geometry: GeometryFilter): String!
+
+  """
+  Image encoded in base64.
+  Downsampled and encoded per geometry.
+  """
+  base64(geometry: GeometryFilter): String!
+}
+
+
+"Select a slice of identifiable objects."
+input Paginate {
+  """
+  Maximum length of list returned (optional).
+  If omitted, return remaining objects.
+  """
+  first: Int
+
+  """
+  Return objects after this identified one (optional).
+  Requires a stable list.
+  """
+  after: ID
+}
+`, BuiltIn: false},
+}
+var parsedSchema = gqlparser.MustLoadSchema(sources...)
+
+// endregion ************************** generated!.gotpl **************************
+
+// region    ***************************** args.gotpl *****************************
+
+func (ec *executionContext) field_Artwork_base64_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+	var err error
+	args := map[string]interface{}{}
+	var arg0 *model.GeometryFilter
+	if tmp, ok := rawArgs["geometry"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("geometry"))
+		arg0, err = ec.unmarshalOGeometryFilter2ᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐGeometryFilter(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["geometry"] = arg0
+	return args, nil
+}
+
+func (ec *executionContext) field_Artwork_url_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+	var err error
+	args := map[string]interface{}{}
+	var arg0 *model.GeometryFilter
+	if tmp, ok := rawArgs["geometry"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("geometry"))
+		arg0, err = ec.unmarshalOGeometryFilter2ᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐGeometryFilter(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["geometry"] = arg0
+	return args, nil
+}
+
+func (ec *executionContext) field_Franchise_videos_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+	var err error
+	args := map[string]interface{}{}
+	var arg0 *model.FranchiseOrder
+	if tmp, ok := rawArgs["order"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("order"))
+		arg0, err = ec.unmarshalOFranchiseOrder2ᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐFranchiseOrder(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["order"] = arg0
+	return args, nil
+}
+
+func (ec *executionContext) field_Mutation_addToCollection_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+	var err error
+	args := map[string]interface{}{}
+	var arg0 string
+	if tmp, ok := rawArgs["id"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("id"))
+		arg0, err = ec.unmarshalNID2string(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["id"] = arg0
+	var arg1 []string
+	if tmp, ok := rawArgs["memberIds"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("memberIds"))
+		arg1, err = ec.unmarshalNID2ᚕstringᚄ(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["memberIds"] = arg1
+	var arg2 *int
+	if tmp, ok := rawArgs["position"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("position"))
+		arg2, err = ec.unmarshalOInt2ᚖint(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["position"] = arg2
+	return args, nil
+}
+
+func (ec *executionContext) field_Mutation_createCollection_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+	var err error
+	args := map[string]interface{}{}
+	var arg0 string
+	if tmp, ok := rawArgs["name"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("name"))
+		arg0, err = ec.unmarshalNString2string(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["name"] = arg0
+	var arg1 []string
+	if tmp, ok := rawArgs["memberIds"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("memberIds"))
+		arg1, err = ec.unmarshalOID2ᚕstringᚄ(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["memberIds"] = arg1
+	var arg2 *model.VideoFilter
+	if tmp, ok := rawArgs["filter"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("filter"))
+		arg2, err = ec.unmarshalOVideoFilter2ᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐVideoFilter(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["filter"] = arg2
+	var arg3 *bool
+	if tmp, ok := rawArgs["shared"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("shared"))
+		arg3, err = ec.unmarshalOBoolean2ᚖbool(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["shared"] = arg3
+	return args, nil
+}
+
+func (ec *executionContext) field_Mutation_createProfile_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+	var err error
+	args := map[string]interface{}{}
+	var arg0 string
+	if tmp, ok := rawArgs["name"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("name"))
+		arg0, err = ec.unmarshalNString2string(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["name"] = arg0
+	return args, nil
+}
+
+func (ec *executionContext) field_Mutation_deleteCollection_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+	var err error
+	args := map[string]interface{}{}
+	var arg0 string
+	if tmp, ok := rawArgs["id"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("id"))
+		arg0, err = ec.unmarshalNID2string(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["id"] = arg0
+	return args, nil
+}
+
+func (ec *executionContext) field_Mutation_filterCollection_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+	var err error
+	args := map[string]interface{}{}
+	var arg0 string
+	if tmp, ok := rawArgs["id"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("id"))
+		arg0, err = ec.unmarshalNID2string(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["id"] = arg0
+	var arg1 model.VideoFilter
+	if tmp, ok := rawArgs["filter"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("filter"))
+		arg1, err = ec.unmarshalNVideoFilter2githubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐVideoFilter(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["filter"] = arg1
+	return args, nil
+}
+
+func (ec *executionContext) field_Mutation_markWatched_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+	var err error
+	args := map[string]interface{}{}
+	var arg0 string
+	if tmp, ok := rawArgs["videoId"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("videoId"))
+		arg0, err = ec.unmarshalNID2string(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["videoId"] = arg0
+	var arg1 *bool
+	if tmp, ok := rawArgs["watched"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("watched"))
+		arg1, err = ec.unmarshalOBoolean2ᚖbool(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["watched"] = arg1
+	return args, nil
+}
+
+func (ec *executionContext) field_Mutation_moveInCollection_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+	var err error
+	args := map[string]interface{}{}
+	var arg0 string
+	if tmp, ok := rawArgs["id"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("id"))
+		arg0, err = ec.unmarshalNID2string(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["id"] = arg0
+	var arg1 string
+	if tmp, ok := rawArgs["memberId"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("memberId"))
+		arg1, err = ec.unmarshalNID2string(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["memberId"] = arg1
+	var arg2 int
+	if tmp, ok := rawArgs["position"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("position"))
+		arg2, err = ec.unmarshalNInt2int(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["position"] = arg2
+	return args, nil
+}
+
+func (ec *executionContext) field_Mutation_removeFromCollection_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+	var err error
+	args := map[string]interface{}{}
+	var arg0 string
+	if tmp, ok := rawArgs["id"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("id"))
+		arg0, err = ec.unmarshalNID2string(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["id"] = arg0
+	var arg1 []string
+	if tmp, ok := rawArgs["memberIds"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("memberIds"))
+		arg1, err = ec.unmarshalNID2ᚕstringᚄ(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["memberIds"] = arg1
+	return args, nil
+}
+
+func (ec *executionContext) field_Mutation_renameCollection_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+	var err error
+	args := map[string]interface{}{}
+	var arg0 string
+	if tmp, ok := rawArgs["id"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("id"))
+		arg0, err = ec.unmarshalNID2string(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["id"] = arg0
+	var arg1 string
+	if tmp, ok := rawArgs["name"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("name"))
+		arg1, err = ec.unmarshalNString2string(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["name"] = arg1
+	return args, nil
+}
+
+func (ec *executionContext) field_Mutation_reportProgress_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+	var err error
+	args := map[string]interface{}{}
+	var arg0 string
+	if tmp, ok := rawArgs["videoId"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("videoId"))
+		arg0, err = ec.unmarshalNID2string(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["videoId"] = arg0
+	var arg1 int
+	if tmp, ok := rawArgs["seconds"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("seconds"))
+		arg1, err = ec.unmarshalNInt2int(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["seconds"] = arg1
+	return args, nil
+}
+
+func (ec *executionContext) field_Mutation_restrictProfile_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+	var err error
+	args := map[string]interface{}{}
+	var arg0 string
+	if tmp, ok := rawArgs["id"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("id"))
+		arg0, err = ec.unmarshalNID2string(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["id"] = arg0
+	var arg1 *string
+	if tmp, ok := rawArgs["maxContentRating"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("maxContentRating"))
+		arg1, err = ec.unmarshalOString2ᚖstring(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["maxContentRating"] = arg1
+	var arg2 []string
+	if tmp, ok := rawArgs["roots"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("roots"))
+		arg2, err = ec.unmarshalOString2ᚕstringᚄ(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["roots"] = arg2
+	return args, nil
+}
+
+func (ec *executionContext) field_Query___type_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+	var err error
+	args := map[string]interface{}{}
+	var arg0 string
+	if tmp, ok := rawArgs["name"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("name"))
+		arg0, err = ec.unmarshalNString2string(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["name"] = arg0
+	return args, nil
+}
+
+func (ec *executionContext) field_Query_collection_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+	var err error
+	args := map[string]interface{}{}
+	var arg0 string
+	if tmp, ok := rawArgs["id"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("id"))
+		arg0, err = ec.unmarshalNID2string(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["id"] = arg0
+	return args, nil
+}
+
+func (ec *executionContext) field_Query_continueWatching_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+	var err error
+	args := map[string]interface{}{}
+	var arg0 *int
+	if tmp, ok := rawArgs["first"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("first"))
+		arg0, err = ec.unmarshalOInt2ᚖint(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["first"] = arg0
+	return args, nil
+}
+
+func (ec *executionContext) field_Query_episodeCount_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+	var err error
+	args := map[string]interface{}{}
+	var arg0 *model.SeriesFilter
+	if tmp, ok := rawArgs["series"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("series"))
+		arg0, err = ec.unmarshalOSeriesFilter2ᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐSeriesFilter(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["series"] = arg0
+	var arg1 *model.SeasonFilter
+	if tmp, ok := rawArgs["season"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("season"))
+		arg1, err = ec.unmarshalOSeasonFilter2ᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐSeasonFilter(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["season"] = arg1
+	return args, nil
+}
+
+func (ec *executionContext) field_Query_episode_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+	var err error
+	args := map[string]interface{}{}
+	var arg0 string
+	if tmp, ok := rawArgs["seriesId"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("seriesId"))
+		arg0, err = ec.unmarshalNID2string(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["seriesId"] = arg0
+	var arg1 int
+	if tmp, ok := rawArgs["season"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("season"))
+		arg1, err = ec.unmarshalNInt2int(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["season"] = arg1
+	var arg2 int
+	if tmp, ok := rawArgs["episode"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("episode"))
+		arg2, err = ec.unmarshalNInt2int(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["episode"] = arg2
+	return args, nil
+}
+
+func (ec *executionContext) field_Query_episodes_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+	var err error
+	args := map[string]interface{}{}
+	var arg0 *model.SeriesFilter
+	if tmp, ok := rawArgs["series"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("series"))
+		arg0, err = ec.unmarshalOSeriesFilter2ᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐSeriesFilter(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["series"] = arg0
+	var arg1 *model.SeasonFilter
+	if tmp, ok := rawArgs["season"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("season"))
+		arg1, err = ec.unmarshalOSeasonFilter2ᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐSeasonFilter(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["season"] = arg1
+	return args, nil
+}
+
+func (ec *executionContext) field_Query_node_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+	var err error
+	args := map[string]interface{}{}
+	var arg0 string
+	if tmp, ok := rawArgs["id"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("id"))
+		arg0, err = ec.unmarshalNID2string(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["id"] = arg0
+	return args, nil
+}
+
+func (ec *executionContext) field_Query_nodes_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+	var err error
+	args := map[string]interface{}{}
+	var arg0 []string
+	if tmp, ok := rawArgs["ids"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("ids"))
+		arg0, err = ec.unmarshalNID2ᚕstringᚄ(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["ids"] = arg0
+	return args, nil
+}
+
+func (ec *executionContext) field_Query_season_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+	var err error
+	args := map[string]interface{}{}
+	var arg0 string
+	if tmp, ok := rawArgs["seriesId"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("seriesId"))
+		arg0, err = ec.unmarshalNID2string(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["seriesId"] = arg0
+	var arg1 int
+	if tmp, ok := rawArgs["number"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("number"))
+		arg1, err = ec.unmarshalNInt2int(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["number"] = arg1
+	return args, nil
+}
+
+func (ec *executionContext) field_Query_seasons_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+	var err error
+	args := map[string]interface{}{}
+	var arg0 *model.SeriesFilter
+	if tmp, ok := rawArgs["series"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("series"))
+		arg0, err = ec.unmarshalOSeriesFilter2ᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐSeriesFilter(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["series"] = arg0
+	return args, nil
+}
+
+func (ec *executionContext) field_Query_seriesById_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+	var err error
+	args := map[string]interface{}{}
+	var arg0 string
+	if tmp, ok := rawArgs["id"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("id"))
+		arg0, err = ec.unmarshalNID2string(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["id"] = arg0
+	return args, nil
+}
+
+func (ec *executionContext) field_Query_series_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+	var err error
+	args := map[string]interface{}{}
+	var arg0 *model.Paginate
+	if tmp, ok := rawArgs["paginate"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("paginate"))
+		arg0, err = ec.unmarshalOPaginate2ᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐPaginate(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["paginate"] = arg0
+	return args, nil
+}
+
+func (ec *executionContext) field_Query_video_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+	var err error
+	args := map[string]interface{}{}
+	var arg0 string
+	if tmp, ok := rawArgs["id"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("id"))
+		arg0, err = ec.unmarshalNID2string(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["id"] = arg0
+	return args, nil
+}
+
+func (ec *executionContext) field_Query_videos_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+	var err error
+	args := map[string]interface{}{}
+	var arg0 *model.Paginate
+	if tmp, ok := rawArgs["paginate"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("paginate"))
+		arg0, err = ec.unmarshalOPaginate2ᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐPaginate(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["paginate"] = arg0
+	var arg1 *string
+	if tmp, ok := rawArgs["title"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("title"))
+		arg1, err = ec.unmarshalOString2ᚖstring(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["title"] = arg1
+	var arg2 *model.ContributorFilter
+	if tmp, ok := rawArgs["contributor"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("contributor"))
+		arg2, err = ec.unmarshalOContributorFilter2ᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐContributorFilter(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["contributor"] = arg2
+	return args, nil
+}
+
+func (ec *executionContext) field_Renditions_rendition_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+	var err error
+	args := map[string]interface{}{}
+	var arg0 *model.QualityFilter
+	if tmp, ok := rawArgs["quality"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("quality"))
+		arg0, err = ec.unmarshalOQualityFilter2ᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐQualityFilter(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["quality"] = arg0
+	return args, nil
+}
+
+func (ec *executionContext) field___Type_enumValues_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+	var err error
+	args := map[string]interface{}{}
+	var arg0 bool
+	if tmp, ok := rawArgs["includeDeprecated"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("includeDeprecated"))
+		arg0, err = ec.unmarshalOBoolean2bool(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["includeDeprecated"] = arg0
+	return args, nil
+}
+
+func (ec *executionContext) field___Type_fields_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+	var err error
+	args := map[string]interface{}{}
+	var arg0 bool
+	if tmp, ok := rawArgs["includeDeprecated"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("includeDeprecated"))
+		arg0, err = ec.unmarshalOBoolean2bool(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["includeDeprecated"] = arg0
+	return args, nil
+}
+
+// endregion ***************************** args.gotpl *****************************
+
+// region    ************************** directives.gotpl **************************
+
+// endregion ************************** directives.gotpl **************************
+
+// region    **************************** field.gotpl *****************************
+
+func (ec *executionContext) _Artwork_url(ctx context.Context, field graphql.CollectedField, obj *model.Artwork) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "Artwork",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   true,
+		IsResolver: true,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args, err := ec.field_Artwork_url_args(ctx, rawArgs)
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	fc.Args = args
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.Artwork().URL(rctx, obj, args["geometry"].(*model.GeometryFilter))
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	fc.Result = res
+	return ec.marshalNString2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _Artwork_base64(ctx context.Context, field graphql.CollectedField, obj *model.Artwork) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "Artwork",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   true,
+		IsResolver: true,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args, err := ec.field_Artwork_base64_args(ctx, rawArgs)
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	fc.Args = args
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.Artwork().Base64(rctx, obj, args["geometry"].(*model.GeometryFilter))
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	fc.Result = res
+	return ec.marshalNString2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _Collection_id(ctx context.Context, field graphql.CollectedField, obj *model.Collection) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "Collection",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   false,
+		IsResolver: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.ID, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	fc.Result = res
+	return ec.marshalNID2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _Collection_name(ctx context.Context, field graphql.CollectedField, obj *model.Collection) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "Collection",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   false,
+		IsResolver: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Name, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	fc.Result = res
+	return ec.marshalNString2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _Collection_smart(ctx context.Context, field graphql.CollectedField, obj *model.Collection) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "Collection",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   true,
+		IsResolver: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Smart(), nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(bool)
+	fc.Result = res
+	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _Collection_shared(ctx context.Context, field graphql.CollectedField, obj *model.Collection) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "Collection",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   true,
+		IsResolver: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Shared(), nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(bool)
+	fc.Result = res
+	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _Collection_owner(ctx context.Context, field graphql.CollectedField, obj *model.Collection) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "Collection",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   true,
+		IsResolver: true,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.Collection().Owner(rctx, obj)
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*model.Profile)
+	fc.Result = res
+	return ec.marshalOProfile2ᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐProfile(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _Collection_members(ctx context.Context, field graphql.CollectedField, obj *model.Collection) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "Collection",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   true,
+		IsResolver: true,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.Collection().Members(rctx, obj)
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.([]model.CollectionMember)
+	fc.Result = res
+	return ec.marshalNCollectionMember2ᚕgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐCollectionMemberᚄ(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _Collection_memberCount(ctx context.Context, field graphql.CollectedField, obj *model.Collection) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "Collection",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   true,
+		IsResolver: true,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.Collection().MemberCount(rctx, obj)
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(int)
+	fc.Result = res
+	return ec.marshalNInt2int(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _Collection_artwork(ctx context.Context, field graphql.CollectedField, obj *model.Collection) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "Collection",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   true,
+		IsResolver: true,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.Collection().Artwork(rctx, obj)
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*model.Artwork)
+	fc.Result = res
+	return ec.marshalOArtwork2ᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐArtwork(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _Contributor_name(ctx context.Context, field graphql.CollectedField, obj *model.Contributor) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "Contributor",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   false,
+		IsResolver: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Name, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	fc.Result = res
+	return ec.marshalNString2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _Episode_id(ctx context.Context, field graphql.CollectedField, obj *model.Episode) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "Episode",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   false,
+		IsResolver: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.ID, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	fc.Result = res
+	return ec.marshalNID2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _Episode_season(ctx context.Context, field graphql.CollectedField, obj *model.Episode) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "Episode",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   false,
+		IsResolver: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Season, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(*model.Season)
+	fc.Result = res
+	return ec.marshalNSeason2ᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐSeason(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _Episode_episode(ctx context.Context, field graphql.CollectedField, obj *model.Episode) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "Episode",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   false,
+		IsResolver: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Episode, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(int)
+	fc.Result = res
+	return ec.marshalNInt2int(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _Episode_episodeID(ctx context.Context, field graphql.CollectedField, obj *model.Episode) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "Episode",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   false,
+		IsResolver: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.EpisodeID, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*string)
+	fc.Result = res
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _Episode_video(ctx context.Context, field graphql.CollectedField, obj *model.Episode) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "Episode",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   false,
+		IsResolver: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Video, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(*model.Video)
+	fc.Result = res
+	return ec.marshalNVideo2ᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐVideo(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _Episode_next(ctx context.Context, field graphql.CollectedField, obj *model.Episode) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "Episode",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   true,
+		IsResolver: true,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.Episode().Next(rctx, obj)
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*model.Episode)
+	fc.Result = res
+	return ec.marshalOEpisode2ᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐEpisode(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _Episode_previous(ctx context.Context, field graphql.CollectedField, obj *model.Episode) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "Episode",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   true,
+		IsResolver: true,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.Episode().Previous(rctx, obj)
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*model.Episode)
+	fc.Result = res
+	return ec.marshalOEpisode2ᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐEpisode(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _Franchise_id(ctx context.Context, field graphql.CollectedField, obj *model.Franchise) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "Franchise",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   false,
+		IsResolver: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.ID, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	fc.Result = res
+	return ec.marshalNID2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _Franchise_name(ctx context.Context, field graphql.CollectedField, obj *model.Franchise) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "Franchise",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   false,
+		IsResolver: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Name, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	fc.Result = res
+	return ec.marshalNString2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _Franchise_explicit(ctx context.Context, field graphql.CollectedField, obj *model.Franchise) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "Franchise",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   false,
+		IsResolver: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Explicit, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(bool)
+	fc.Result = res
+	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _Franchise_videos(ctx context.Context, field graphql.CollectedField, obj *model.Franchise) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "Franchise",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   true,
+		IsResolver: true,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args, err := ec.field_Franchise_videos_args(ctx, rawArgs)
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	fc.Args = args
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.Franchise().Videos(rctx, obj, args["order"].(*model.FranchiseOrder))
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.([]*model.Video)
+	fc.Result = res
+	return ec.marshalNVideo2ᚕᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐVideoᚄ(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _Franchise_videoCount(ctx context.Context, field graphql.CollectedField, obj *model.Franchise) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "Franchise",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   true,
+		IsResolver: true,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.Franchise().VideoCount(rctx, obj)
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(int)
+	fc.Result = res
+	return ec.marshalNInt2int(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _Mutation_createProfile(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "Mutation",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   true,
+		IsResolver: true,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args, err := ec.field_Mutation_createProfile_args(ctx, rawArgs)
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	fc.Args = args
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Mutation().CreateProfile(rctx, args["name"].(string))
+		}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			if ec.directives.Admin == nil {
+				return nil, errors.New("directive admin is not implemented")
+			}
+			return ec.directives.Admin(ctx, nil, directive0)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, graphql.ErrorOnPath(ctx, err)
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.(*model.Profile); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be *github.com/idiomatic/tvql/graph/model.Profile`, tmp)
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(*model.Profile)
+	fc.Result = res
+	return ec.marshalNProfile2ᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐProfile(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _Mutation_restrictProfile(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "Mutation",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   true,
+		IsResolver: true,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args, err := ec.field_Mutation_restrictProfile_args(ctx, rawArgs)
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	fc.Args = args
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Mutation().RestrictProfile(rctx, args["id"].(string), args["maxContentRating"].(*string), args["roots"].([]string))
+		}
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			if ec.directives.Admin == nil {
+				return nil, errors.New("directive admin is not implemented")
+			}
+			return ec.directives.Admin(ctx, nil, directive0)
+		}
+
+		tmp, err := directive1(rctx)
+		if err != nil {
+			return nil, graphql.ErrorOnPath(ctx, err)
+		}
+		if tmp == nil {
+			return nil, nil
+		}
+		if data, ok := tmp.(*model.Profile); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be *github.com/idiomatic/tvql/graph/model.Profile`, tmp)
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(*model.Profile)
+	fc.Result = res
+	return ec.marshalNProfile2ᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐProfile(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _Mutation_reportProgress(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "Mutation",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   true,
+		IsResolver: true,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args, err := ec.field_Mutation_reportProgress_args(ctx, rawArgs)
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	fc.Args = args
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.Mutation().ReportProgress(rctx, args["videoId"].(string), args["seconds"].(int))
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(*model.Video)
+	fc.Result = res
+	return ec.marshalNVideo2ᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐVideo(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _Mutation_markWatched(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "Mutation",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   true,
+		IsResolver: true,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args, err := ec.field_Mutation_markWatched_args(ctx, rawArgs)
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	fc.Args = args
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.Mutation().MarkWatched(rctx, args["videoId"].(string), args["watched"].(*bool))
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(*model.Video)
+	fc.Result = res
+	return ec.marshalNVideo2ᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐVideo(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _Mutation_createCollection(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "Mutation",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   true,
+		IsResolver: true,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args, err := ec.field_Mutation_createCollection_args(ctx, rawArgs)
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	fc.Args = args
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.Mutation().CreateCollection(rctx, args["name"].(string), args["memberIds"].([]string), args["filter"].(*model.VideoFilter), args["shared"].(*bool))
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(*model.Collection)
+	fc.Result = res
+	return ec.marshalNCollection2ᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐCollection(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _Mutation_renameCollection(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "Mutation",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   true,
+		IsResolver: true,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args, err := ec.field_Mutation_renameCollection_args(ctx, rawArgs)
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	fc.Args = args
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.Mutation().RenameCollection(rctx, args["id"].(string), args["name"].(string))
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(*model.Collection)
+	fc.Result = res
+	return ec.marshalNCollection2ᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐCollection(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _Mutation_filterCollection(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "Mutation",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   true,
+		IsResolver: true,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args, err := ec.field_Mutation_filterCollection_args(ctx, rawArgs)
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	fc.Args = args
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.Mutation().FilterCollection(rctx, args["id"].(string), args["filter"].(model.VideoFilter))
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(*model.Collection)
+	fc.Result = res
+	return ec.marshalNCollection2ᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐCollection(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _Mutation_deleteCollection(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "Mutation",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   true,
+		IsResolver: true,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args, err := ec.field_Mutation_deleteCollection_args(ctx, rawArgs)
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	fc.Args = args
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.Mutation().DeleteCollection(rctx, args["id"].(string))
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	fc.Result = res
+	return ec.marshalNID2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _Mutation_addToCollection(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "Mutation",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   true,
+		IsResolver: true,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args, err := ec.field_Mutation_addToCollection_args(ctx, rawArgs)
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	fc.Args = args
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.Mutation().AddToCollection(rctx, args["id"].(string), args["memberIds"].([]string), args["position"].(*int))
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(*model.Collection)
+	fc.Result = res
+	return ec.marshalNCollection2ᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐCollection(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _Mutation_removeFromCollection(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "Mutation",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   true,
+		IsResolver: true,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args, err := ec.field_Mutation_removeFromCollection_args(ctx, rawArgs)
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	fc.Args = args
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.Mutation().RemoveFromCollection(rctx, args["id"].(string), args["memberIds"].([]string))
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(*model.Collection)
+	fc.Result = res
+	return ec.marshalNCollection2ᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐCollection(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _Mutation_moveInCollection(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "Mutation",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   true,
+		IsResolver: true,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args, err := ec.field_Mutation_moveInCollection_args(ctx, rawArgs)
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	fc.Args = args
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.Mutation().MoveInCollection(rctx, args["id"].(string), args["memberId"].(string), args["position"].(int))
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(*model.Collection)
+	fc.Result = res
+	return ec.marshalNCollection2ᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐCollection(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _Profile_id(ctx context.Context, field graphql.CollectedField, obj *model.Profile) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "Profile",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   false,
+		IsResolver: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.ID, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	fc.Result = res
+	return ec.marshalNID2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _Profile_name(ctx context.Context, field graphql.CollectedField, obj *model.Profile) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "Profile",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   false,
+		IsResolver: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Name, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	fc.Result = res
+	return ec.marshalNString2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _Profile_restricted(ctx context.Context, field graphql.CollectedField, obj *model.Profile) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "Profile",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   true,
+		IsResolver: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Restricted(), nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(bool)
+	fc.Result = res
+	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _Profile_maxContentRating(ctx context.Context, field graphql.CollectedField, obj *model.Profile) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "Profile",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   false,
+		IsResolver: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.MaxContentRating, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*string)
+	fc.Result = res
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _Progress_seconds(ctx context.Context, field graphql.CollectedField, obj *model.Progress) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "Progress",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   false,
+		IsResolver: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Seconds, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(int)
+	fc.Result = res
+	return ec.marshalNInt2int(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _Progress_watched(ctx context.Context, field graphql.CollectedField, obj *model.Progress) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "Progress",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   false,
+		IsResolver: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Watched, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(bool)
+	fc.Result = res
+	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _Progress_lastWatched(ctx context.Context, field graphql.CollectedField, obj *model.Progress) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "Progress",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   false,
+		IsResolver: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.LastWatched, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(time.Time)
+	fc.Result = res
+	return ec.marshalNTime2timeᚐTime(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _Quality_videoCodec(ctx context.Context, field graphql.CollectedField, obj *model.Quality) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "Quality",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   false,
+		IsResolver: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.VideoCodec, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(model.VideoCodec)
+	fc.Result = res
+	return ec.marshalNVideoCodec2githubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐVideoCodec(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _Quality_resolution(ctx context.Context, field graphql.CollectedField, obj *model.Quality) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "Quality",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   false,
+		IsResolver: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Resolution, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	fc.Result = res
+	return ec.marshalNResolution2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _Quality_transcodeBudget(ctx context.Context, field graphql.CollectedField, obj *model.Quality) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "Quality",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   false,
+		IsResolver: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.TranscodeBudget, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*model.TranscodeBudget)
+	fc.Result = res
+	return ec.marshalOTranscodeBudget2ᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐTranscodeBudget(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _Query_node(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "Query",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   true,
+		IsResolver: true,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args, err := ec.field_Query_node_args(ctx, rawArgs)
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	fc.Args = args
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.Query().Node(rctx, args["id"].(string))
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(model.Node)
+	fc.Result = res
+	return ec.marshalONode2githubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐNode(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _Query_nodes(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "Query",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   true,
+		IsResolver: true,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args, err := ec.field_Query_nodes_args(ctx, rawArgs)
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	fc.Args = args
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.Query().Nodes(rctx, args["ids"].([]string))
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.([]model.Node)
+	fc.Result = res
+	return ec.marshalNNode2ᚕgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐNode(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _Query_video(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "Query",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   true,
+		IsResolver: true,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args, err := ec.field_Query_video_args(ctx, rawArgs)
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	fc.Args = args
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.Query().Video(rctx, args["id"].(string))
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(*model.Video)
+	fc.Result = res
+	return ec.marshalNVideo2ᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐVideo(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _Query_videos(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "Query",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   true,
+		IsResolver: true,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args, err := ec.field_Query_videos_args(ctx, rawArgs)
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	fc.Args = args
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.Query().Videos(rctx, args["paginate"].(*model.Paginate), args["title"].(*string), args["contributor"].(*model.ContributorFilter))
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.([]*model.Video)
+	fc.Result = res
+	return ec.marshalNVideo2ᚕᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐVideoᚄ(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _Query_series(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "Query",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   true,
+		IsResolver: true,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args, err := ec.field_Query_series_args(ctx, rawArgs)
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	fc.Args = args
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.Query().Series(rctx, args["paginate"].(*model.Paginate))
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.([]*model.Series)
+	fc.Result = res
+	return ec.marshalNSeries2ᚕᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐSeriesᚄ(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _Query_seriesById(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "Query",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   true,
+		IsResolver: true,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args, err := ec.field_Query_seriesById_args(ctx, rawArgs)
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	fc.Args = args
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.Query().SeriesByID(rctx, args["id"].(string))
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*model.Series)
+	fc.Result = res
+	return ec.marshalOSeries2ᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐSeries(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _Query_season(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "Query",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   true,
+		IsResolver: true,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args, err := ec.field_Query_season_args(ctx, rawArgs)
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	fc.Args = args
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.Query().Season(rctx, args["seriesId"].(string), args["number"].(int))
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*model.Season)
+	fc.Result = res
+	return ec.marshalOSeason2ᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐSeason(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _Query_episode(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "Query",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   true,
+		IsResolver: true,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args, err := ec.field_Query_episode_args(ctx, rawArgs)
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	fc.Args = args
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.Query().Episode(rctx, args["seriesId"].(string), args["season"].(int), args["episode"].(int))
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*model.Episode)
+	fc.Result = res
+	return ec.marshalOEpisode2ᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐEpisode(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _Query_seasons(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "Query",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   true,
+		IsResolver: true,
 	}
-	args["name"] = arg0
-	return args, nil
-}
 
-func (ec *executionContext) field_Query_episodeCount_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
-	var err error
-	args := map[string]interface{}{}
-	var arg0 *model.SeriesFilter
-	if tmp, ok := rawArgs["series"]; ok {
-		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("series"))
-		arg0, err = ec.unmarshalOSeriesFilter2ᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐSeriesFilter(ctx, tmp)
-		if err != nil {
-			return nil, err
-		}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args, err := ec.field_Query_seasons_args(ctx, rawArgs)
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
 	}
-	args["series"] = arg0
-	var arg1 *model.SeasonFilter
-	if tmp, ok := rawArgs["season"]; ok {
-		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("season"))
-		arg1, err = ec.unmarshalOSeasonFilter2ᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐSeasonFilter(ctx, tmp)
-		if err != nil {
-			return nil, err
+	fc.Args = args
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.Query().Seasons(rctx, args["series"].(*model.SeriesFilter))
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
 		}
+		return graphql.Null
 	}
-	args["season"] = arg1
-	return args, nil
+	res := resTmp.([]*model.Season)
+	fc.Result = res
+	return ec.marshalNSeason2ᚕᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐSeasonᚄ(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) field_Query_episodes_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
-	var err error
-	args := map[string]interface{}{}
-	var arg0 *model.SeriesFilter
-	if tmp, ok := rawArgs["series"]; ok {
-		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("series"))
-		arg0, err = ec.unmarshalOSeriesFilter2ᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐSeriesFilter(ctx, tmp)
-		if err != nil {
-			return nil, err
+func (ec *executionContext) _Query_episodes(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
 		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "Query",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   true,
+		IsResolver: true,
 	}
-	args["series"] = arg0
-	var arg1 *model.SeasonFilter
-	if tmp, ok := rawArgs["season"]; ok {
-		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("season"))
-		arg1, err = ec.unmarshalOSeasonFilter2ᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐSeasonFilter(ctx, tmp)
-		if err != nil {
-			return nil, err
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args, err := ec.field_Query_episodes_args(ctx, rawArgs)
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	fc.Args = args
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.Query().Episodes(rctx, args["series"].(*model.SeriesFilter), args["season"].(*model.SeasonFilter))
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
 		}
+		return graphql.Null
 	}
-	args["season"] = arg1
-	return args, nil
+	res := resTmp.([]*model.Episode)
+	fc.Result = res
+	return ec.marshalNEpisode2ᚕᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐEpisodeᚄ(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) field_Query_seasons_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
-	var err error
-	args := map[string]interface{}{}
-	var arg0 *model.SeriesFilter
-	if tmp, ok := rawArgs["series"]; ok {
-		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("series"))
-		arg0, err = ec.unmarshalOSeriesFilter2ᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐSeriesFilter(ctx, tmp)
-		if err != nil {
-			return nil, err
+func (ec *executionContext) _Query_episodeCount(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
 		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "Query",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   true,
+		IsResolver: true,
 	}
-	args["series"] = arg0
-	return args, nil
-}
 
-func (ec *executionContext) field_Query_series_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
-	var err error
-	args := map[string]interface{}{}
-	var arg0 *model.Paginate
-	if tmp, ok := rawArgs["paginate"]; ok {
-		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("paginate"))
-		arg0, err = ec.unmarshalOPaginate2ᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐPaginate(ctx, tmp)
-		if err != nil {
-			return nil, err
+	ctx = graphql.WithFieldContext(ctx, fc)
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args, err := ec.field_Query_episodeCount_args(ctx, rawArgs)
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	fc.Args = args
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.Query().EpisodeCount(rctx, args["series"].(*model.SeriesFilter), args["season"].(*model.SeasonFilter))
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
 		}
+		return graphql.Null
 	}
-	args["paginate"] = arg0
-	return args, nil
+	res := resTmp.(int)
+	fc.Result = res
+	return ec.marshalNInt2int(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) field_Query_video_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
-	var err error
-	args := map[string]interface{}{}
-	var arg0 string
-	if tmp, ok := rawArgs["id"]; ok {
-		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("id"))
-		arg0, err = ec.unmarshalNID2string(ctx, tmp)
-		if err != nil {
-			return nil, err
+func (ec *executionContext) _Query_profiles(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
 		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "Query",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   true,
+		IsResolver: true,
 	}
-	args["id"] = arg0
-	return args, nil
-}
 
-func (ec *executionContext) field_Query_videos_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
-	var err error
-	args := map[string]interface{}{}
-	var arg0 *model.Paginate
-	if tmp, ok := rawArgs["paginate"]; ok {
-		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("paginate"))
-		arg0, err = ec.unmarshalOPaginate2ᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐPaginate(ctx, tmp)
-		if err != nil {
-			return nil, err
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		directive0 := func(rctx context.Context) (interface{}, error) {
+			ctx = rctx // use context from middleware stack in children
+			return ec.resolvers.Query().Profiles(rctx)
 		}
-	}
-	args["paginate"] = arg0
-	var arg1 *string
-	if tmp, ok := rawArgs["title"]; ok {
-		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("title"))
-		arg1, err = ec.unmarshalOString2ᚖstring(ctx, tmp)
+		directive1 := func(ctx context.Context) (interface{}, error) {
+			if ec.directives.Admin == nil {
+				return nil, errors.New("directive admin is not implemented")
+			}
+			return ec.directives.Admin(ctx, nil, directive0)
+		}
+
+		tmp, err := directive1(rctx)
 		if err != nil {
-			return nil, err
+			return nil, graphql.ErrorOnPath(ctx, err)
+		}
+		if tmp == nil {
+			return nil, nil
 		}
+		if data, ok := tmp.([]*model.Profile); ok {
+			return data, nil
+		}
+		return nil, fmt.Errorf(`unexpected type %T from directive, should be []*github.com/idiomatic/tvql/graph/model.Profile`, tmp)
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
 	}
-	args["title"] = arg1
-	var arg2 *model.ContributorFilter
-	if tmp, ok := rawArgs["contributor"]; ok {
-		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("contributor"))
-		arg2, err = ec.unmarshalOContributorFilter2ᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐContributorFilter(ctx, tmp)
-		if err != nil {
-			return nil, err
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
 		}
+		return graphql.Null
 	}
-	args["contributor"] = arg2
-	return args, nil
+	res := resTmp.([]*model.Profile)
+	fc.Result = res
+	return ec.marshalNProfile2ᚕᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐProfileᚄ(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) field_Renditions_rendition_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
-	var err error
-	args := map[string]interface{}{}
-	var arg0 *model.QualityFilter
-	if tmp, ok := rawArgs["quality"]; ok {
-		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("quality"))
-		arg0, err = ec.unmarshalOQualityFilter2ᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐQualityFilter(ctx, tmp)
-		if err != nil {
-			return nil, err
+func (ec *executionContext) _Query_continueWatching(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "Query",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   true,
+		IsResolver: true,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args, err := ec.field_Query_continueWatching_args(ctx, rawArgs)
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	fc.Args = args
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.Query().ContinueWatching(rctx, args["first"].(*int))
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
 		}
+		return graphql.Null
 	}
-	args["quality"] = arg0
-	return args, nil
+	res := resTmp.([]*model.Video)
+	fc.Result = res
+	return ec.marshalNVideo2ᚕᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐVideoᚄ(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) field___Type_enumValues_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
-	var err error
-	args := map[string]interface{}{}
-	var arg0 bool
-	if tmp, ok := rawArgs["includeDeprecated"]; ok {
-		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("includeDeprecated"))
-		arg0, err = ec.unmarshalOBoolean2bool(ctx, tmp)
-		if err != nil {
-			return nil, err
+func (ec *executionContext) _Query_collections(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
 		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "Query",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   true,
+		IsResolver: true,
 	}
-	args["includeDeprecated"] = arg0
-	return args, nil
-}
 
-func (ec *executionContext) field___Type_fields_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
-	var err error
-	args := map[string]interface{}{}
-	var arg0 bool
-	if tmp, ok := rawArgs["includeDeprecated"]; ok {
-		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("includeDeprecated"))
-		arg0, err = ec.unmarshalOBoolean2bool(ctx, tmp)
-		if err != nil {
-			return nil, err
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.Query().Collections(rctx)
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
 		}
+		return graphql.Null
 	}
-	args["includeDeprecated"] = arg0
-	return args, nil
+	res := resTmp.([]*model.Collection)
+	fc.Result = res
+	return ec.marshalNCollection2ᚕᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐCollectionᚄ(ctx, field.Selections, res)
 }
 
-// endregion ***************************** args.gotpl *****************************
-
-// region    ************************** directives.gotpl **************************
-
-// endregion ************************** directives.gotpl **************************
-
-// region    **************************** field.gotpl *****************************
-
-func (ec *executionContext) _Artwork_url(ctx context.Context, field graphql.CollectedField, obj *model.Artwork) (ret graphql.Marshaler) {
+func (ec *executionContext) _Query_collection(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -1191,7 +4974,7 @@ func (ec *executionContext) _Artwork_url(ctx context.Context, field graphql.Coll
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "Artwork",
+		Object:     "Query",
 		Field:      field,
 		Args:       nil,
 		IsMethod:   true,
@@ -1200,7 +4983,7 @@ func (ec *executionContext) _Artwork_url(ctx context.Context, field graphql.Coll
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Artwork_url_args(ctx, rawArgs)
+	args, err := ec.field_Query_collection_args(ctx, rawArgs)
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
@@ -1208,7 +4991,39 @@ func (ec *executionContext) _Artwork_url(ctx context.Context, field graphql.Coll
 	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Artwork().URL(rctx, obj, args["geometry"].(*model.GeometryFilter))
+		return ec.resolvers.Query().Collection(rctx, args["id"].(string))
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*model.Collection)
+	fc.Result = res
+	return ec.marshalOCollection2ᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐCollection(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _Query_franchises(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "Query",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   true,
+		IsResolver: true,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.Query().Franchises(rctx)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -1220,12 +5035,12 @@ func (ec *executionContext) _Artwork_url(ctx context.Context, field graphql.Coll
 		}
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.([]*model.Franchise)
 	fc.Result = res
-	return ec.marshalNString2string(ctx, field.Selections, res)
+	return ec.marshalNFranchise2ᚕᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐFranchiseᚄ(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Artwork_base64(ctx context.Context, field graphql.CollectedField, obj *model.Artwork) (ret graphql.Marshaler) {
+func (ec *executionContext) _Query___type(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -1233,16 +5048,16 @@ func (ec *executionContext) _Artwork_base64(ctx context.Context, field graphql.C
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "Artwork",
+		Object:     "Query",
 		Field:      field,
 		Args:       nil,
 		IsMethod:   true,
-		IsResolver: true,
+		IsResolver: false,
 	}
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Artwork_base64_args(ctx, rawArgs)
+	args, err := ec.field_Query___type_args(ctx, rawArgs)
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
@@ -1250,7 +5065,71 @@ func (ec *executionContext) _Artwork_base64(ctx context.Context, field graphql.C
 	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Artwork().Base64(rctx, obj, args["geometry"].(*model.GeometryFilter))
+		return ec.introspectType(args["name"].(string))
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*introspection.Type)
+	fc.Result = res
+	return ec.marshalO__Type2ᚖgithubᚗcomᚋ99designsᚋgqlgenᚋgraphqlᚋintrospectionᚐType(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _Query___schema(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "Query",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   true,
+		IsResolver: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.introspectSchema()
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*introspection.Schema)
+	fc.Result = res
+	return ec.marshalO__Schema2ᚖgithubᚗcomᚋ99designsᚋgqlgenᚋgraphqlᚋintrospectionᚐSchema(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _Rendition_id(ctx context.Context, field graphql.CollectedField, obj *model.Rendition) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "Rendition",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   false,
+		IsResolver: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.ID, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -1264,10 +5143,10 @@ func (ec *executionContext) _Artwork_base64(ctx context.Context, field graphql.C
 	}
 	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalNString2string(ctx, field.Selections, res)
+	return ec.marshalNID2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Contributor_name(ctx context.Context, field graphql.CollectedField, obj *model.Contributor) (ret graphql.Marshaler) {
+func (ec *executionContext) _Rendition_url(ctx context.Context, field graphql.CollectedField, obj *model.Rendition) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -1275,17 +5154,17 @@ func (ec *executionContext) _Contributor_name(ctx context.Context, field graphql
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "Contributor",
+		Object:     "Rendition",
 		Field:      field,
 		Args:       nil,
-		IsMethod:   false,
-		IsResolver: false,
+		IsMethod:   true,
+		IsResolver: true,
 	}
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Name, nil
+		return ec.resolvers.Rendition().URL(rctx, obj)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -1302,7 +5181,7 @@ func (ec *executionContext) _Contributor_name(ctx context.Context, field graphql
 	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Episode_season(ctx context.Context, field graphql.CollectedField, obj *model.Episode) (ret graphql.Marshaler) {
+func (ec *executionContext) _Rendition_cut(ctx context.Context, field graphql.CollectedField, obj *model.Rendition) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -1310,7 +5189,7 @@ func (ec *executionContext) _Episode_season(ctx context.Context, field graphql.C
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "Episode",
+		Object:     "Rendition",
 		Field:      field,
 		Args:       nil,
 		IsMethod:   false,
@@ -1320,24 +5199,21 @@ func (ec *executionContext) _Episode_season(ctx context.Context, field graphql.C
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Season, nil
+		return obj.Cut, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.(*model.Season)
+	res := resTmp.(*string)
 	fc.Result = res
-	return ec.marshalNSeason2ᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐSeason(ctx, field.Selections, res)
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Episode_episode(ctx context.Context, field graphql.CollectedField, obj *model.Episode) (ret graphql.Marshaler) {
+func (ec *executionContext) _Rendition_quality(ctx context.Context, field graphql.CollectedField, obj *model.Rendition) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -1345,7 +5221,7 @@ func (ec *executionContext) _Episode_episode(ctx context.Context, field graphql.
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "Episode",
+		Object:     "Rendition",
 		Field:      field,
 		Args:       nil,
 		IsMethod:   false,
@@ -1355,7 +5231,7 @@ func (ec *executionContext) _Episode_episode(ctx context.Context, field graphql.
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Episode, nil
+		return obj.Quality, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -1367,12 +5243,12 @@ func (ec *executionContext) _Episode_episode(ctx context.Context, field graphql.
 		}
 		return graphql.Null
 	}
-	res := resTmp.(int)
+	res := resTmp.(*model.Quality)
 	fc.Result = res
-	return ec.marshalNInt2int(ctx, field.Selections, res)
+	return ec.marshalNQuality2ᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐQuality(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Episode_episodeID(ctx context.Context, field graphql.CollectedField, obj *model.Episode) (ret graphql.Marshaler) {
+func (ec *executionContext) _Rendition_duration(ctx context.Context, field graphql.CollectedField, obj *model.Rendition) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -1380,7 +5256,7 @@ func (ec *executionContext) _Episode_episodeID(ctx context.Context, field graphq
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "Episode",
+		Object:     "Rendition",
 		Field:      field,
 		Args:       nil,
 		IsMethod:   false,
@@ -1390,7 +5266,7 @@ func (ec *executionContext) _Episode_episodeID(ctx context.Context, field graphq
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.EpisodeID, nil
+		return obj.Duration, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -1399,12 +5275,44 @@ func (ec *executionContext) _Episode_episodeID(ctx context.Context, field graphq
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(*string)
+	res := resTmp.(*int)
 	fc.Result = res
-	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+	return ec.marshalOInt2ᚖint(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _Rendition_isHD(ctx context.Context, field graphql.CollectedField, obj *model.Rendition) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "Rendition",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   false,
+		IsResolver: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.IsHd, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*bool)
+	fc.Result = res
+	return ec.marshalOBoolean2ᚖbool(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Episode_video(ctx context.Context, field graphql.CollectedField, obj *model.Episode) (ret graphql.Marshaler) {
+func (ec *executionContext) _Rendition_isStreamable(ctx context.Context, field graphql.CollectedField, obj *model.Rendition) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -1412,7 +5320,7 @@ func (ec *executionContext) _Episode_video(ctx context.Context, field graphql.Co
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "Episode",
+		Object:     "Rendition",
 		Field:      field,
 		Args:       nil,
 		IsMethod:   false,
@@ -1422,7 +5330,7 @@ func (ec *executionContext) _Episode_video(ctx context.Context, field graphql.Co
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Video, nil
+		return obj.IsStreamable, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -1434,12 +5342,12 @@ func (ec *executionContext) _Episode_video(ctx context.Context, field graphql.Co
 		}
 		return graphql.Null
 	}
-	res := resTmp.(*model.Video)
+	res := resTmp.(bool)
 	fc.Result = res
-	return ec.marshalNVideo2ᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐVideo(ctx, field.Selections, res)
+	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Quality_videoCodec(ctx context.Context, field graphql.CollectedField, obj *model.Quality) (ret graphql.Marshaler) {
+func (ec *executionContext) _Rendition_size(ctx context.Context, field graphql.CollectedField, obj *model.Rendition) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -1447,7 +5355,7 @@ func (ec *executionContext) _Quality_videoCodec(ctx context.Context, field graph
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "Quality",
+		Object:     "Rendition",
 		Field:      field,
 		Args:       nil,
 		IsMethod:   false,
@@ -1457,7 +5365,7 @@ func (ec *executionContext) _Quality_videoCodec(ctx context.Context, field graph
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.VideoCodec, nil
+		return obj.Size, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -1469,12 +5377,12 @@ func (ec *executionContext) _Quality_videoCodec(ctx context.Context, field graph
 		}
 		return graphql.Null
 	}
-	res := resTmp.(model.VideoCodec)
+	res := resTmp.(int)
 	fc.Result = res
-	return ec.marshalNVideoCodec2githubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐVideoCodec(ctx, field.Selections, res)
+	return ec.marshalNInt2int(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Quality_resolution(ctx context.Context, field graphql.CollectedField, obj *model.Quality) (ret graphql.Marshaler) {
+func (ec *executionContext) _Renditions_all(ctx context.Context, field graphql.CollectedField, obj *model.Renditions) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -1482,7 +5390,7 @@ func (ec *executionContext) _Quality_resolution(ctx context.Context, field graph
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "Quality",
+		Object:     "Renditions",
 		Field:      field,
 		Args:       nil,
 		IsMethod:   false,
@@ -1492,24 +5400,21 @@ func (ec *executionContext) _Quality_resolution(ctx context.Context, field graph
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Resolution, nil
+		return obj.All, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.([]*model.Rendition)
 	fc.Result = res
-	return ec.marshalNResolution2string(ctx, field.Selections, res)
+	return ec.marshalORendition2ᚕᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐRenditionᚄ(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Quality_transcodeBudget(ctx context.Context, field graphql.CollectedField, obj *model.Quality) (ret graphql.Marshaler) {
+func (ec *executionContext) _Renditions_rendition(ctx context.Context, field graphql.CollectedField, obj *model.Renditions) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -1517,17 +5422,24 @@ func (ec *executionContext) _Quality_transcodeBudget(ctx context.Context, field
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "Quality",
+		Object:     "Renditions",
 		Field:      field,
 		Args:       nil,
-		IsMethod:   false,
-		IsResolver: false,
+		IsMethod:   true,
+		IsResolver: true,
 	}
 
 	ctx = graphql.WithFieldContext(ctx, fc)
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args, err := ec.field_Renditions_rendition_args(ctx, rawArgs)
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.TranscodeBudget, nil
+		return ec.resolvers.Renditions().Rendition(rctx, obj, args["quality"].(*model.QualityFilter))
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -1536,12 +5448,12 @@ func (ec *executionContext) _Quality_transcodeBudget(ctx context.Context, field
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(*model.TranscodeBudget)
+	res := resTmp.(*model.Rendition)
 	fc.Result = res
-	return ec.marshalOTranscodeBudget2ᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐTranscodeBudget(ctx, field.Selections, res)
+	return ec.marshalORendition2ᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐRendition(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Query_video(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _ScanProgress_root(ctx context.Context, field graphql.CollectedField, obj *model.ScanProgress) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -1549,24 +5461,17 @@ func (ec *executionContext) _Query_video(ctx context.Context, field graphql.Coll
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "Query",
+		Object:     "ScanProgress",
 		Field:      field,
 		Args:       nil,
-		IsMethod:   true,
-		IsResolver: true,
+		IsMethod:   false,
+		IsResolver: false,
 	}
 
 	ctx = graphql.WithFieldContext(ctx, fc)
-	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Query_video_args(ctx, rawArgs)
-	if err != nil {
-		ec.Error(ctx, err)
-		return graphql.Null
-	}
-	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().Video(rctx, args["id"].(string))
+		return obj.Root, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -1578,12 +5483,12 @@ func (ec *executionContext) _Query_video(ctx context.Context, field graphql.Coll
 		}
 		return graphql.Null
 	}
-	res := resTmp.(*model.Video)
+	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalNVideo2ᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐVideo(ctx, field.Selections, res)
+	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Query_videos(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _ScanProgress_filesScanned(ctx context.Context, field graphql.CollectedField, obj *model.ScanProgress) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -1591,24 +5496,17 @@ func (ec *executionContext) _Query_videos(ctx context.Context, field graphql.Col
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "Query",
+		Object:     "ScanProgress",
 		Field:      field,
 		Args:       nil,
-		IsMethod:   true,
-		IsResolver: true,
+		IsMethod:   false,
+		IsResolver: false,
 	}
 
 	ctx = graphql.WithFieldContext(ctx, fc)
-	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Query_videos_args(ctx, rawArgs)
-	if err != nil {
-		ec.Error(ctx, err)
-		return graphql.Null
-	}
-	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().Videos(rctx, args["paginate"].(*model.Paginate), args["title"].(*string), args["contributor"].(*model.ContributorFilter))
+		return obj.FilesScanned, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -1620,12 +5518,12 @@ func (ec *executionContext) _Query_videos(ctx context.Context, field graphql.Col
 		}
 		return graphql.Null
 	}
-	res := resTmp.([]*model.Video)
+	res := resTmp.(int)
 	fc.Result = res
-	return ec.marshalNVideo2ᚕᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐVideoᚄ(ctx, field.Selections, res)
+	return ec.marshalNInt2int(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Query_series(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _ScanProgress_videos(ctx context.Context, field graphql.CollectedField, obj *model.ScanProgress) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -1633,24 +5531,17 @@ func (ec *executionContext) _Query_series(ctx context.Context, field graphql.Col
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "Query",
+		Object:     "ScanProgress",
 		Field:      field,
 		Args:       nil,
-		IsMethod:   true,
-		IsResolver: true,
+		IsMethod:   false,
+		IsResolver: false,
 	}
 
 	ctx = graphql.WithFieldContext(ctx, fc)
-	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Query_series_args(ctx, rawArgs)
-	if err != nil {
-		ec.Error(ctx, err)
-		return graphql.Null
-	}
-	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().Series(rctx, args["paginate"].(*model.Paginate))
+		return obj.Videos, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -1662,12 +5553,12 @@ func (ec *executionContext) _Query_series(ctx context.Context, field graphql.Col
 		}
 		return graphql.Null
 	}
-	res := resTmp.([]*model.Series)
+	res := resTmp.(int)
 	fc.Result = res
-	return ec.marshalNSeries2ᚕᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐSeriesᚄ(ctx, field.Selections, res)
+	return ec.marshalNInt2int(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Query_seasons(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _ScanProgress_done(ctx context.Context, field graphql.CollectedField, obj *model.ScanProgress) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -1675,24 +5566,17 @@ func (ec *executionContext) _Query_seasons(ctx context.Context, field graphql.Co
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "Query",
+		Object:     "ScanProgress",
 		Field:      field,
 		Args:       nil,
-		IsMethod:   true,
-		IsResolver: true,
+		IsMethod:   false,
+		IsResolver: false,
 	}
 
 	ctx = graphql.WithFieldContext(ctx, fc)
-	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Query_seasons_args(ctx, rawArgs)
-	if err != nil {
-		ec.Error(ctx, err)
-		return graphql.Null
-	}
-	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().Seasons(rctx, args["series"].(*model.SeriesFilter))
+		return obj.Done, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -1704,12 +5588,12 @@ func (ec *executionContext) _Query_seasons(ctx context.Context, field graphql.Co
 		}
 		return graphql.Null
 	}
-	res := resTmp.([]*model.Season)
+	res := resTmp.(bool)
 	fc.Result = res
-	return ec.marshalNSeason2ᚕᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐSeasonᚄ(ctx, field.Selections, res)
+	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Query_episodes(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Season_id(ctx context.Context, field graphql.CollectedField, obj *model.Season) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -1717,24 +5601,17 @@ func (ec *executionContext) _Query_episodes(ctx context.Context, field graphql.C
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "Query",
+		Object:     "Season",
 		Field:      field,
 		Args:       nil,
-		IsMethod:   true,
-		IsResolver: true,
+		IsMethod:   false,
+		IsResolver: false,
 	}
 
 	ctx = graphql.WithFieldContext(ctx, fc)
-	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Query_episodes_args(ctx, rawArgs)
-	if err != nil {
-		ec.Error(ctx, err)
-		return graphql.Null
-	}
-	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().Episodes(rctx, args["series"].(*model.SeriesFilter), args["season"].(*model.SeasonFilter))
+		return obj.ID, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -1746,12 +5623,12 @@ func (ec *executionContext) _Query_episodes(ctx context.Context, field graphql.C
 		}
 		return graphql.Null
 	}
-	res := resTmp.([]*model.Episode)
+	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalNEpisode2ᚕᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐEpisodeᚄ(ctx, field.Selections, res)
+	return ec.marshalNID2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Query_episodeCount(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Season_series(ctx context.Context, field graphql.CollectedField, obj *model.Season) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -1759,24 +5636,17 @@ func (ec *executionContext) _Query_episodeCount(ctx context.Context, field graph
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "Query",
+		Object:     "Season",
 		Field:      field,
 		Args:       nil,
-		IsMethod:   true,
-		IsResolver: true,
+		IsMethod:   false,
+		IsResolver: false,
 	}
 
 	ctx = graphql.WithFieldContext(ctx, fc)
-	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Query_episodeCount_args(ctx, rawArgs)
-	if err != nil {
-		ec.Error(ctx, err)
-		return graphql.Null
-	}
-	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().EpisodeCount(rctx, args["series"].(*model.SeriesFilter), args["season"].(*model.SeasonFilter))
+		return obj.Series, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -1788,12 +5658,12 @@ func (ec *executionContext) _Query_episodeCount(ctx context.Context, field graph
 		}
 		return graphql.Null
 	}
-	res := resTmp.(int)
+	res := resTmp.(*model.Series)
 	fc.Result = res
-	return ec.marshalNInt2int(ctx, field.Selections, res)
+	return ec.marshalNSeries2ᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐSeries(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Query___type(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Season_season(ctx context.Context, field graphql.CollectedField, obj *model.Season) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -1801,38 +5671,34 @@ func (ec *executionContext) _Query___type(ctx context.Context, field graphql.Col
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "Query",
+		Object:     "Season",
 		Field:      field,
 		Args:       nil,
-		IsMethod:   true,
+		IsMethod:   false,
 		IsResolver: false,
 	}
 
 	ctx = graphql.WithFieldContext(ctx, fc)
-	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Query___type_args(ctx, rawArgs)
-	if err != nil {
-		ec.Error(ctx, err)
-		return graphql.Null
-	}
-	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.introspectType(args["name"].(string))
+		return obj.Season, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(*introspection.Type)
+	res := resTmp.(int)
 	fc.Result = res
-	return ec.marshalO__Type2ᚖgithubᚗcomᚋ99designsᚋgqlgenᚋgraphqlᚋintrospectionᚐType(ctx, field.Selections, res)
+	return ec.marshalNInt2int(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Query___schema(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Season_episodes(ctx context.Context, field graphql.CollectedField, obj *model.Season) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -1840,31 +5706,34 @@ func (ec *executionContext) _Query___schema(ctx context.Context, field graphql.C
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "Query",
+		Object:     "Season",
 		Field:      field,
 		Args:       nil,
 		IsMethod:   true,
-		IsResolver: false,
+		IsResolver: true,
 	}
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.introspectSchema()
+		return ec.resolvers.Season().Episodes(rctx, obj)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(*introspection.Schema)
+	res := resTmp.([]*model.Episode)
 	fc.Result = res
-	return ec.marshalO__Schema2ᚖgithubᚗcomᚋ99designsᚋgqlgenᚋgraphqlᚋintrospectionᚐSchema(ctx, field.Selections, res)
+	return ec.marshalNEpisode2ᚕᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐEpisodeᚄ(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Rendition_id(ctx context.Context, field graphql.CollectedField, obj *model.Rendition) (ret graphql.Marshaler) {
+func (ec *executionContext) _Season_episodeCount(ctx context.Context, field graphql.CollectedField, obj *model.Season) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -1872,17 +5741,17 @@ func (ec *executionContext) _Rendition_id(ctx context.Context, field graphql.Col
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "Rendition",
+		Object:     "Season",
 		Field:      field,
 		Args:       nil,
-		IsMethod:   false,
-		IsResolver: false,
+		IsMethod:   true,
+		IsResolver: true,
 	}
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.ID, nil
+		return ec.resolvers.Season().EpisodeCount(rctx, obj)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -1894,12 +5763,12 @@ func (ec *executionContext) _Rendition_id(ctx context.Context, field graphql.Col
 		}
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.(int)
 	fc.Result = res
-	return ec.marshalNID2string(ctx, field.Selections, res)
+	return ec.marshalNInt2int(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Rendition_url(ctx context.Context, field graphql.CollectedField, obj *model.Rendition) (ret graphql.Marshaler) {
+func (ec *executionContext) _Season_unwatchedCount(ctx context.Context, field graphql.CollectedField, obj *model.Season) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -1907,7 +5776,7 @@ func (ec *executionContext) _Rendition_url(ctx context.Context, field graphql.Co
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "Rendition",
+		Object:     "Season",
 		Field:      field,
 		Args:       nil,
 		IsMethod:   true,
@@ -1917,7 +5786,7 @@ func (ec *executionContext) _Rendition_url(ctx context.Context, field graphql.Co
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Rendition().URL(rctx, obj)
+		return ec.resolvers.Season().UnwatchedCount(rctx, obj)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -1929,12 +5798,12 @@ func (ec *executionContext) _Rendition_url(ctx context.Context, field graphql.Co
 		}
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.(int)
 	fc.Result = res
-	return ec.marshalNString2string(ctx, field.Selections, res)
+	return ec.marshalNInt2int(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Rendition_cut(ctx context.Context, field graphql.CollectedField, obj *model.Rendition) (ret graphql.Marshaler) {
+func (ec *executionContext) _Series_id(ctx context.Context, field graphql.CollectedField, obj *model.Series) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -1942,7 +5811,7 @@ func (ec *executionContext) _Rendition_cut(ctx context.Context, field graphql.Co
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "Rendition",
+		Object:     "Series",
 		Field:      field,
 		Args:       nil,
 		IsMethod:   false,
@@ -1952,21 +5821,24 @@ func (ec *executionContext) _Rendition_cut(ctx context.Context, field graphql.Co
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Cut, nil
+		return obj.ID, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(*string)
+	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+	return ec.marshalNID2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Rendition_quality(ctx context.Context, field graphql.CollectedField, obj *model.Rendition) (ret graphql.Marshaler) {
+func (ec *executionContext) _Series_name(ctx context.Context, field graphql.CollectedField, obj *model.Series) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -1974,7 +5846,7 @@ func (ec *executionContext) _Rendition_quality(ctx context.Context, field graphq
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "Rendition",
+		Object:     "Series",
 		Field:      field,
 		Args:       nil,
 		IsMethod:   false,
@@ -1984,7 +5856,7 @@ func (ec *executionContext) _Rendition_quality(ctx context.Context, field graphq
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Quality, nil
+		return obj.Name, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -1996,12 +5868,12 @@ func (ec *executionContext) _Rendition_quality(ctx context.Context, field graphq
 		}
 		return graphql.Null
 	}
-	res := resTmp.(*model.Quality)
+	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalNQuality2ᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐQuality(ctx, field.Selections, res)
+	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Rendition_duration(ctx context.Context, field graphql.CollectedField, obj *model.Rendition) (ret graphql.Marshaler) {
+func (ec *executionContext) _Series_sortName(ctx context.Context, field graphql.CollectedField, obj *model.Series) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -2009,7 +5881,7 @@ func (ec *executionContext) _Rendition_duration(ctx context.Context, field graph
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "Rendition",
+		Object:     "Series",
 		Field:      field,
 		Args:       nil,
 		IsMethod:   false,
@@ -2019,21 +5891,24 @@ func (ec *executionContext) _Rendition_duration(ctx context.Context, field graph
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Duration, nil
+		return obj.SortName, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(*int)
+	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalOInt2ᚖint(ctx, field.Selections, res)
+	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Rendition_isHD(ctx context.Context, field graphql.CollectedField, obj *model.Rendition) (ret graphql.Marshaler) {
+func (ec *executionContext) _Series_artwork(ctx context.Context, field graphql.CollectedField, obj *model.Series) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -2041,7 +5916,7 @@ func (ec *executionContext) _Rendition_isHD(ctx context.Context, field graphql.C
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "Rendition",
+		Object:     "Series",
 		Field:      field,
 		Args:       nil,
 		IsMethod:   false,
@@ -2051,7 +5926,7 @@ func (ec *executionContext) _Rendition_isHD(ctx context.Context, field graphql.C
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.IsHd, nil
+		return obj.Artwork, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -2060,12 +5935,12 @@ func (ec *executionContext) _Rendition_isHD(ctx context.Context, field graphql.C
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(*bool)
+	res := resTmp.(*model.Artwork)
 	fc.Result = res
-	return ec.marshalOBoolean2ᚖbool(ctx, field.Selections, res)
+	return ec.marshalOArtwork2ᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐArtwork(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Rendition_size(ctx context.Context, field graphql.CollectedField, obj *model.Rendition) (ret graphql.Marshaler) {
+func (ec *executionContext) _Series_seasons(ctx context.Context, field graphql.CollectedField, obj *model.Series) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -2073,17 +5948,17 @@ func (ec *executionContext) _Rendition_size(ctx context.Context, field graphql.C
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "Rendition",
+		Object:     "Series",
 		Field:      field,
 		Args:       nil,
-		IsMethod:   false,
-		IsResolver: false,
+		IsMethod:   true,
+		IsResolver: true,
 	}
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Size, nil
+		return ec.resolvers.Series().Seasons(rctx, obj)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -2095,12 +5970,12 @@ func (ec *executionContext) _Rendition_size(ctx context.Context, field graphql.C
 		}
 		return graphql.Null
 	}
-	res := resTmp.(int)
+	res := resTmp.([]*model.Season)
 	fc.Result = res
-	return ec.marshalNInt2int(ctx, field.Selections, res)
+	return ec.marshalNSeason2ᚕᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐSeasonᚄ(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Renditions_all(ctx context.Context, field graphql.CollectedField, obj *model.Renditions) (ret graphql.Marshaler) {
+func (ec *executionContext) _Series_episodes(ctx context.Context, field graphql.CollectedField, obj *model.Series) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -2108,31 +5983,34 @@ func (ec *executionContext) _Renditions_all(ctx context.Context, field graphql.C
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "Renditions",
+		Object:     "Series",
 		Field:      field,
 		Args:       nil,
-		IsMethod:   false,
-		IsResolver: false,
+		IsMethod:   true,
+		IsResolver: true,
 	}
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.All, nil
+		return ec.resolvers.Series().Episodes(rctx, obj)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.([]*model.Rendition)
+	res := resTmp.([]*model.Episode)
 	fc.Result = res
-	return ec.marshalORendition2ᚕᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐRenditionᚄ(ctx, field.Selections, res)
+	return ec.marshalNEpisode2ᚕᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐEpisodeᚄ(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Renditions_rendition(ctx context.Context, field graphql.CollectedField, obj *model.Renditions) (ret graphql.Marshaler) {
+func (ec *executionContext) _Series_episodeCount(ctx context.Context, field graphql.CollectedField, obj *model.Series) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -2140,7 +6018,7 @@ func (ec *executionContext) _Renditions_rendition(ctx context.Context, field gra
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "Renditions",
+		Object:     "Series",
 		Field:      field,
 		Args:       nil,
 		IsMethod:   true,
@@ -2148,30 +6026,26 @@ func (ec *executionContext) _Renditions_rendition(ctx context.Context, field gra
 	}
 
 	ctx = graphql.WithFieldContext(ctx, fc)
-	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Renditions_rendition_args(ctx, rawArgs)
-	if err != nil {
-		ec.Error(ctx, err)
-		return graphql.Null
-	}
-	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Renditions().Rendition(rctx, obj, args["quality"].(*model.QualityFilter))
+		return ec.resolvers.Series().EpisodeCount(rctx, obj)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(*model.Rendition)
+	res := resTmp.(int)
 	fc.Result = res
-	return ec.marshalORendition2ᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐRendition(ctx, field.Selections, res)
+	return ec.marshalNInt2int(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Season_series(ctx context.Context, field graphql.CollectedField, obj *model.Season) (ret graphql.Marshaler) {
+func (ec *executionContext) _Series_nextUp(ctx context.Context, field graphql.CollectedField, obj *model.Series) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -2179,77 +6053,84 @@ func (ec *executionContext) _Season_series(ctx context.Context, field graphql.Co
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "Season",
+		Object:     "Series",
 		Field:      field,
 		Args:       nil,
-		IsMethod:   false,
-		IsResolver: false,
+		IsMethod:   true,
+		IsResolver: true,
 	}
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Series, nil
+		return ec.resolvers.Series().NextUp(rctx, obj)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.(*model.Series)
+	res := resTmp.(*model.Episode)
 	fc.Result = res
-	return ec.marshalNSeries2ᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐSeries(ctx, field.Selections, res)
+	return ec.marshalOEpisode2ᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐEpisode(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Season_season(ctx context.Context, field graphql.CollectedField, obj *model.Season) (ret graphql.Marshaler) {
+func (ec *executionContext) _Subscription_videoAdded(ctx context.Context, field graphql.CollectedField) (ret func() graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
-			ret = graphql.Null
+			ret = nil
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "Season",
+		Object:     "Subscription",
 		Field:      field,
 		Args:       nil,
-		IsMethod:   false,
-		IsResolver: false,
+		IsMethod:   true,
+		IsResolver: true,
 	}
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Season, nil
+		return ec.resolvers.Subscription().VideoAdded(rctx)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
-		return graphql.Null
+		return nil
 	}
 	if resTmp == nil {
 		if !graphql.HasFieldError(ctx, fc) {
 			ec.Errorf(ctx, "must not be null")
 		}
-		return graphql.Null
+		return nil
+	}
+	return func() graphql.Marshaler {
+		res, ok := <-resTmp.(<-chan *model.Video)
+		if !ok {
+			return nil
+		}
+		return graphql.WriterFunc(func(w io.Writer) {
+			w.Write([]byte{'{'})
+			graphql.MarshalString(field.Alias).MarshalGQL(w)
+			w.Write([]byte{':'})
+			ec.marshalNVideo2ᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐVideo(ctx, field.Selections, res).MarshalGQL(w)
+			w.Write([]byte{'}'})
+		})
 	}
-	res := resTmp.(int)
-	fc.Result = res
-	return ec.marshalNInt2int(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Season_episodes(ctx context.Context, field graphql.CollectedField, obj *model.Season) (ret graphql.Marshaler) {
+func (ec *executionContext) _Subscription_videoUpdated(ctx context.Context, field graphql.CollectedField) (ret func() graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
-			ret = graphql.Null
+			ret = nil
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "Season",
+		Object:     "Subscription",
 		Field:      field,
 		Args:       nil,
 		IsMethod:   true,
@@ -2259,32 +6140,42 @@ func (ec *executionContext) _Season_episodes(ctx context.Context, field graphql.
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Season().Episodes(rctx, obj)
+		return ec.resolvers.Subscription().VideoUpdated(rctx)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
-		return graphql.Null
+		return nil
 	}
 	if resTmp == nil {
 		if !graphql.HasFieldError(ctx, fc) {
 			ec.Errorf(ctx, "must not be null")
 		}
-		return graphql.Null
+		return nil
+	}
+	return func() graphql.Marshaler {
+		res, ok := <-resTmp.(<-chan *model.Video)
+		if !ok {
+			return nil
+		}
+		return graphql.WriterFunc(func(w io.Writer) {
+			w.Write([]byte{'{'})
+			graphql.MarshalString(field.Alias).MarshalGQL(w)
+			w.Write([]byte{':'})
+			ec.marshalNVideo2ᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐVideo(ctx, field.Selections, res).MarshalGQL(w)
+			w.Write([]byte{'}'})
+		})
 	}
-	res := resTmp.([]*model.Episode)
-	fc.Result = res
-	return ec.marshalNEpisode2ᚕᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐEpisodeᚄ(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Season_episodeCount(ctx context.Context, field graphql.CollectedField, obj *model.Season) (ret graphql.Marshaler) {
+func (ec *executionContext) _Subscription_videoRemoved(ctx context.Context, field graphql.CollectedField) (ret func() graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
-			ret = graphql.Null
+			ret = nil
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "Season",
+		Object:     "Subscription",
 		Field:      field,
 		Args:       nil,
 		IsMethod:   true,
@@ -2294,59 +6185,79 @@ func (ec *executionContext) _Season_episodeCount(ctx context.Context, field grap
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Season().EpisodeCount(rctx, obj)
+		return ec.resolvers.Subscription().VideoRemoved(rctx)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
-		return graphql.Null
+		return nil
 	}
 	if resTmp == nil {
 		if !graphql.HasFieldError(ctx, fc) {
 			ec.Errorf(ctx, "must not be null")
 		}
-		return graphql.Null
+		return nil
+	}
+	return func() graphql.Marshaler {
+		res, ok := <-resTmp.(<-chan string)
+		if !ok {
+			return nil
+		}
+		return graphql.WriterFunc(func(w io.Writer) {
+			w.Write([]byte{'{'})
+			graphql.MarshalString(field.Alias).MarshalGQL(w)
+			w.Write([]byte{':'})
+			ec.marshalNID2string(ctx, field.Selections, res).MarshalGQL(w)
+			w.Write([]byte{'}'})
+		})
 	}
-	res := resTmp.(int)
-	fc.Result = res
-	return ec.marshalNInt2int(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Series_name(ctx context.Context, field graphql.CollectedField, obj *model.Series) (ret graphql.Marshaler) {
+func (ec *executionContext) _Subscription_scanProgress(ctx context.Context, field graphql.CollectedField) (ret func() graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
-			ret = graphql.Null
+			ret = nil
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "Series",
+		Object:     "Subscription",
 		Field:      field,
 		Args:       nil,
-		IsMethod:   false,
-		IsResolver: false,
+		IsMethod:   true,
+		IsResolver: true,
 	}
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Name, nil
+		return ec.resolvers.Subscription().ScanProgress(rctx)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
-		return graphql.Null
+		return nil
 	}
 	if resTmp == nil {
 		if !graphql.HasFieldError(ctx, fc) {
 			ec.Errorf(ctx, "must not be null")
 		}
-		return graphql.Null
+		return nil
+	}
+	return func() graphql.Marshaler {
+		res, ok := <-resTmp.(<-chan *model.ScanProgress)
+		if !ok {
+			return nil
+		}
+		return graphql.WriterFunc(func(w io.Writer) {
+			w.Write([]byte{'{'})
+			graphql.MarshalString(field.Alias).MarshalGQL(w)
+			w.Write([]byte{':'})
+			ec.marshalNScanProgress2ᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐScanProgress(ctx, field.Selections, res).MarshalGQL(w)
+			w.Write([]byte{'}'})
+		})
 	}
-	res := resTmp.(string)
-	fc.Result = res
-	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Series_sortName(ctx context.Context, field graphql.CollectedField, obj *model.Series) (ret graphql.Marshaler) {
+func (ec *executionContext) _Video_id(ctx context.Context, field graphql.CollectedField, obj *model.Video) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -2354,7 +6265,7 @@ func (ec *executionContext) _Series_sortName(ctx context.Context, field graphql.
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "Series",
+		Object:     "Video",
 		Field:      field,
 		Args:       nil,
 		IsMethod:   false,
@@ -2364,7 +6275,7 @@ func (ec *executionContext) _Series_sortName(ctx context.Context, field graphql.
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.SortName, nil
+		return obj.ID, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -2378,10 +6289,10 @@ func (ec *executionContext) _Series_sortName(ctx context.Context, field graphql.
 	}
 	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalNString2string(ctx, field.Selections, res)
+	return ec.marshalNID2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Series_artwork(ctx context.Context, field graphql.CollectedField, obj *model.Series) (ret graphql.Marshaler) {
+func (ec *executionContext) _Video_title(ctx context.Context, field graphql.CollectedField, obj *model.Video) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -2389,7 +6300,7 @@ func (ec *executionContext) _Series_artwork(ctx context.Context, field graphql.C
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "Series",
+		Object:     "Video",
 		Field:      field,
 		Args:       nil,
 		IsMethod:   false,
@@ -2399,21 +6310,24 @@ func (ec *executionContext) _Series_artwork(ctx context.Context, field graphql.C
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Artwork, nil
+		return obj.Title, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(*model.Artwork)
+	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalOArtwork2ᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐArtwork(ctx, field.Selections, res)
+	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Series_seasons(ctx context.Context, field graphql.CollectedField, obj *model.Series) (ret graphql.Marshaler) {
+func (ec *executionContext) _Video_sortTitle(ctx context.Context, field graphql.CollectedField, obj *model.Video) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -2421,17 +6335,17 @@ func (ec *executionContext) _Series_seasons(ctx context.Context, field graphql.C
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "Series",
+		Object:     "Video",
 		Field:      field,
 		Args:       nil,
-		IsMethod:   true,
-		IsResolver: true,
+		IsMethod:   false,
+		IsResolver: false,
 	}
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Series().Seasons(rctx, obj)
+		return obj.SortTitle, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -2443,12 +6357,12 @@ func (ec *executionContext) _Series_seasons(ctx context.Context, field graphql.C
 		}
 		return graphql.Null
 	}
-	res := resTmp.([]*model.Season)
+	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalNSeason2ᚕᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐSeasonᚄ(ctx, field.Selections, res)
+	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Series_episodes(ctx context.Context, field graphql.CollectedField, obj *model.Series) (ret graphql.Marshaler) {
+func (ec *executionContext) _Video_releaseYear(ctx context.Context, field graphql.CollectedField, obj *model.Video) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -2456,17 +6370,17 @@ func (ec *executionContext) _Series_episodes(ctx context.Context, field graphql.
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "Series",
+		Object:     "Video",
 		Field:      field,
 		Args:       nil,
-		IsMethod:   true,
-		IsResolver: true,
+		IsMethod:   false,
+		IsResolver: false,
 	}
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Series().Episodes(rctx, obj)
+		return obj.ReleaseYear, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -2478,12 +6392,12 @@ func (ec *executionContext) _Series_episodes(ctx context.Context, field graphql.
 		}
 		return graphql.Null
 	}
-	res := resTmp.([]*model.Episode)
+	res := resTmp.(int)
 	fc.Result = res
-	return ec.marshalNEpisode2ᚕᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐEpisodeᚄ(ctx, field.Selections, res)
+	return ec.marshalNInt2int(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Series_episodeCount(ctx context.Context, field graphql.CollectedField, obj *model.Series) (ret graphql.Marshaler) {
+func (ec *executionContext) _Video_renditions(ctx context.Context, field graphql.CollectedField, obj *model.Video) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -2491,34 +6405,31 @@ func (ec *executionContext) _Series_episodeCount(ctx context.Context, field grap
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "Series",
+		Object:     "Video",
 		Field:      field,
 		Args:       nil,
-		IsMethod:   true,
-		IsResolver: true,
+		IsMethod:   false,
+		IsResolver: false,
 	}
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Series().EpisodeCount(rctx, obj)
+		return obj.Renditions, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.(int)
+	res := resTmp.(*model.Renditions)
 	fc.Result = res
-	return ec.marshalNInt2int(ctx, field.Selections, res)
+	return ec.marshalORenditions2ᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐRenditions(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Video_id(ctx context.Context, field graphql.CollectedField, obj *model.Video) (ret graphql.Marshaler) {
+func (ec *executionContext) _Video_hlsUrl(ctx context.Context, field graphql.CollectedField, obj *model.Video) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -2529,31 +6440,28 @@ func (ec *executionContext) _Video_id(ctx context.Context, field graphql.Collect
 		Object:     "Video",
 		Field:      field,
 		Args:       nil,
-		IsMethod:   false,
-		IsResolver: false,
+		IsMethod:   true,
+		IsResolver: true,
 	}
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.ID, nil
+		return ec.resolvers.Video().HlsURL(rctx, obj)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.(*string)
 	fc.Result = res
-	return ec.marshalNID2string(ctx, field.Selections, res)
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Video_title(ctx context.Context, field graphql.CollectedField, obj *model.Video) (ret graphql.Marshaler) {
+func (ec *executionContext) _Video_dashUrl(ctx context.Context, field graphql.CollectedField, obj *model.Video) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -2564,31 +6472,28 @@ func (ec *executionContext) _Video_title(ctx context.Context, field graphql.Coll
 		Object:     "Video",
 		Field:      field,
 		Args:       nil,
-		IsMethod:   false,
-		IsResolver: false,
+		IsMethod:   true,
+		IsResolver: true,
 	}
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Title, nil
+		return ec.resolvers.Video().DashURL(rctx, obj)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.(*string)
 	fc.Result = res
-	return ec.marshalNString2string(ctx, field.Selections, res)
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Video_sortTitle(ctx context.Context, field graphql.CollectedField, obj *model.Video) (ret graphql.Marshaler) {
+func (ec *executionContext) _Video_artwork(ctx context.Context, field graphql.CollectedField, obj *model.Video) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -2599,31 +6504,28 @@ func (ec *executionContext) _Video_sortTitle(ctx context.Context, field graphql.
 		Object:     "Video",
 		Field:      field,
 		Args:       nil,
-		IsMethod:   false,
-		IsResolver: false,
+		IsMethod:   true,
+		IsResolver: true,
 	}
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.SortTitle, nil
+		return ec.resolvers.Video().Artwork(rctx, obj)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.(*model.Artwork)
 	fc.Result = res
-	return ec.marshalNString2string(ctx, field.Selections, res)
+	return ec.marshalOArtwork2ᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐArtwork(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Video_releaseYear(ctx context.Context, field graphql.CollectedField, obj *model.Video) (ret graphql.Marshaler) {
+func (ec *executionContext) _Video_description(ctx context.Context, field graphql.CollectedField, obj *model.Video) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -2641,24 +6543,21 @@ func (ec *executionContext) _Video_releaseYear(ctx context.Context, field graphq
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.ReleaseYear, nil
+		return obj.Description, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.(int)
+	res := resTmp.(*string)
 	fc.Result = res
-	return ec.marshalNInt2int(ctx, field.Selections, res)
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Video_renditions(ctx context.Context, field graphql.CollectedField, obj *model.Video) (ret graphql.Marshaler) {
+func (ec *executionContext) _Video_directors(ctx context.Context, field graphql.CollectedField, obj *model.Video) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -2676,7 +6575,7 @@ func (ec *executionContext) _Video_renditions(ctx context.Context, field graphql
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Renditions, nil
+		return obj.Directors, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -2685,12 +6584,12 @@ func (ec *executionContext) _Video_renditions(ctx context.Context, field graphql
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(*model.Renditions)
+	res := resTmp.([]*model.Contributor)
 	fc.Result = res
-	return ec.marshalORenditions2ᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐRenditions(ctx, field.Selections, res)
+	return ec.marshalOContributor2ᚕᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐContributorᚄ(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Video_artwork(ctx context.Context, field graphql.CollectedField, obj *model.Video) (ret graphql.Marshaler) {
+func (ec *executionContext) _Video_writers(ctx context.Context, field graphql.CollectedField, obj *model.Video) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -2701,14 +6600,14 @@ func (ec *executionContext) _Video_artwork(ctx context.Context, field graphql.Co
 		Object:     "Video",
 		Field:      field,
 		Args:       nil,
-		IsMethod:   true,
-		IsResolver: true,
+		IsMethod:   false,
+		IsResolver: false,
 	}
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Video().Artwork(rctx, obj)
+		return obj.Writers, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -2717,12 +6616,12 @@ func (ec *executionContext) _Video_artwork(ctx context.Context, field graphql.Co
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(*model.Artwork)
+	res := resTmp.([]*model.Contributor)
 	fc.Result = res
-	return ec.marshalOArtwork2ᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐArtwork(ctx, field.Selections, res)
+	return ec.marshalOContributor2ᚕᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐContributorᚄ(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Video_description(ctx context.Context, field graphql.CollectedField, obj *model.Video) (ret graphql.Marshaler) {
+func (ec *executionContext) _Video_cast(ctx context.Context, field graphql.CollectedField, obj *model.Video) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -2740,7 +6639,7 @@ func (ec *executionContext) _Video_description(ctx context.Context, field graphq
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Description, nil
+		return obj.Cast, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -2749,12 +6648,12 @@ func (ec *executionContext) _Video_description(ctx context.Context, field graphq
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(*string)
+	res := resTmp.([]*model.Contributor)
 	fc.Result = res
-	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+	return ec.marshalOContributor2ᚕᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐContributorᚄ(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Video_directors(ctx context.Context, field graphql.CollectedField, obj *model.Video) (ret graphql.Marshaler) {
+func (ec *executionContext) _Video_genre(ctx context.Context, field graphql.CollectedField, obj *model.Video) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -2772,7 +6671,7 @@ func (ec *executionContext) _Video_directors(ctx context.Context, field graphql.
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Directors, nil
+		return obj.Genre, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -2781,12 +6680,12 @@ func (ec *executionContext) _Video_directors(ctx context.Context, field graphql.
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.([]*model.Contributor)
+	res := resTmp.(*string)
 	fc.Result = res
-	return ec.marshalOContributor2ᚕᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐContributorᚄ(ctx, field.Selections, res)
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Video_writers(ctx context.Context, field graphql.CollectedField, obj *model.Video) (ret graphql.Marshaler) {
+func (ec *executionContext) _Video_contentRating(ctx context.Context, field graphql.CollectedField, obj *model.Video) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -2804,7 +6703,7 @@ func (ec *executionContext) _Video_writers(ctx context.Context, field graphql.Co
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Writers, nil
+		return obj.ContentRating, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -2813,12 +6712,12 @@ func (ec *executionContext) _Video_writers(ctx context.Context, field graphql.Co
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.([]*model.Contributor)
+	res := resTmp.(*string)
 	fc.Result = res
-	return ec.marshalOContributor2ᚕᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐContributorᚄ(ctx, field.Selections, res)
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Video_cast(ctx context.Context, field graphql.CollectedField, obj *model.Video) (ret graphql.Marshaler) {
+func (ec *executionContext) _Video_tomatometer(ctx context.Context, field graphql.CollectedField, obj *model.Video) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -2836,7 +6735,7 @@ func (ec *executionContext) _Video_cast(ctx context.Context, field graphql.Colle
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Cast, nil
+		return obj.Tomatometer, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -2845,12 +6744,12 @@ func (ec *executionContext) _Video_cast(ctx context.Context, field graphql.Colle
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.([]*model.Contributor)
+	res := resTmp.(*int)
 	fc.Result = res
-	return ec.marshalOContributor2ᚕᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐContributorᚄ(ctx, field.Selections, res)
+	return ec.marshalOInt2ᚖint(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Video_genre(ctx context.Context, field graphql.CollectedField, obj *model.Video) (ret graphql.Marshaler) {
+func (ec *executionContext) _Video_episode(ctx context.Context, field graphql.CollectedField, obj *model.Video) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -2868,7 +6767,7 @@ func (ec *executionContext) _Video_genre(ctx context.Context, field graphql.Coll
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Genre, nil
+		return obj.Episode, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -2877,12 +6776,12 @@ func (ec *executionContext) _Video_genre(ctx context.Context, field graphql.Coll
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(*string)
+	res := resTmp.(*model.Episode)
 	fc.Result = res
-	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+	return ec.marshalOEpisode2ᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐEpisode(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Video_contentRating(ctx context.Context, field graphql.CollectedField, obj *model.Video) (ret graphql.Marshaler) {
+func (ec *executionContext) _Video_franchise(ctx context.Context, field graphql.CollectedField, obj *model.Video) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -2893,14 +6792,14 @@ func (ec *executionContext) _Video_contentRating(ctx context.Context, field grap
 		Object:     "Video",
 		Field:      field,
 		Args:       nil,
-		IsMethod:   false,
-		IsResolver: false,
+		IsMethod:   true,
+		IsResolver: true,
 	}
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.ContentRating, nil
+		return ec.resolvers.Video().Franchise(rctx, obj)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -2909,12 +6808,12 @@ func (ec *executionContext) _Video_contentRating(ctx context.Context, field grap
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(*string)
+	res := resTmp.(*model.Franchise)
 	fc.Result = res
-	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+	return ec.marshalOFranchise2ᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐFranchise(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Video_tomatometer(ctx context.Context, field graphql.CollectedField, obj *model.Video) (ret graphql.Marshaler) {
+func (ec *executionContext) _Video_progress(ctx context.Context, field graphql.CollectedField, obj *model.Video) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -2925,14 +6824,14 @@ func (ec *executionContext) _Video_tomatometer(ctx context.Context, field graphq
 		Object:     "Video",
 		Field:      field,
 		Args:       nil,
-		IsMethod:   false,
-		IsResolver: false,
+		IsMethod:   true,
+		IsResolver: true,
 	}
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Tomatometer, nil
+		return ec.resolvers.Video().Progress(rctx, obj)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -2941,12 +6840,12 @@ func (ec *executionContext) _Video_tomatometer(ctx context.Context, field graphq
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(*int)
+	res := resTmp.(*model.Progress)
 	fc.Result = res
-	return ec.marshalOInt2ᚖint(ctx, field.Selections, res)
+	return ec.marshalOProgress2ᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐProgress(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Video_episode(ctx context.Context, field graphql.CollectedField, obj *model.Video) (ret graphql.Marshaler) {
+func (ec *executionContext) _Video_watched(ctx context.Context, field graphql.CollectedField, obj *model.Video) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -2957,25 +6856,28 @@ func (ec *executionContext) _Video_episode(ctx context.Context, field graphql.Co
 		Object:     "Video",
 		Field:      field,
 		Args:       nil,
-		IsMethod:   false,
-		IsResolver: false,
+		IsMethod:   true,
+		IsResolver: true,
 	}
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Episode, nil
+		return ec.resolvers.Video().Watched(rctx, obj)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(*model.Episode)
+	res := resTmp.(bool)
 	fc.Result = res
-	return ec.marshalOEpisode2ᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐEpisode(ctx, field.Selections, res)
+	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
 }
 
 func (ec *executionContext) ___Directive_name(ctx context.Context, field graphql.CollectedField, obj *introspection.Directive) (ret graphql.Marshaler) {
@@ -4171,6 +8073,54 @@ func (ec *executionContext) unmarshalInputGeometryFilter(ctx context.Context, ob
 			if err != nil {
 				return it, err
 			}
+		case "fit":
+			var err error
+
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("fit"))
+			it.Fit, err = ec.unmarshalOArtworkFit2ᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐArtworkFit(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		case "anchor":
+			var err error
+
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("anchor"))
+			it.Anchor, err = ec.unmarshalOArtworkAnchor2ᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐArtworkAnchor(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		case "background":
+			var err error
+
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("background"))
+			it.Background, err = ec.unmarshalOString2ᚖstring(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		case "dpr":
+			var err error
+
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("dpr"))
+			it.Dpr, err = ec.unmarshalOFloat2ᚖfloat64(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		case "format":
+			var err error
+
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("format"))
+			it.Format, err = ec.unmarshalOArtworkFormat2ᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐArtworkFormat(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		case "quality":
+			var err error
+
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("quality"))
+			it.Quality, err = ec.unmarshalOInt2ᚖint(ctx, v)
+			if err != nil {
+				return it, err
+			}
 		}
 	}
 
@@ -4189,16 +8139,180 @@ func (ec *executionContext) unmarshalInputPaginate(ctx context.Context, obj inte
 		case "first":
 			var err error
 
-			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("first"))
-			it.First, err = ec.unmarshalOInt2ᚖint(ctx, v)
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("first"))
+			it.First, err = ec.unmarshalOInt2ᚖint(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		case "after":
+			var err error
+
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("after"))
+			it.After, err = ec.unmarshalOID2ᚖstring(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		}
+	}
+
+	return it, nil
+}
+
+func (ec *executionContext) unmarshalInputQualityFilter(ctx context.Context, obj interface{}) (model.QualityFilter, error) {
+	var it model.QualityFilter
+	asMap := map[string]interface{}{}
+	for k, v := range obj.(map[string]interface{}) {
+		asMap[k] = v
+	}
+
+	for k, v := range asMap {
+		switch k {
+		case "videoCodec":
+			var err error
+
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("videoCodec"))
+			it.VideoCodec, err = ec.unmarshalOVideoCodec2ᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐVideoCodec(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		case "resolution":
+			var err error
+
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("resolution"))
+			it.Resolution, err = ec.unmarshalOResolution2ᚖstring(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		}
+	}
+
+	return it, nil
+}
+
+func (ec *executionContext) unmarshalInputSeasonFilter(ctx context.Context, obj interface{}) (model.SeasonFilter, error) {
+	var it model.SeasonFilter
+	asMap := map[string]interface{}{}
+	for k, v := range obj.(map[string]interface{}) {
+		asMap[k] = v
+	}
+
+	for k, v := range asMap {
+		switch k {
+		case "season":
+			var err error
+
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("season"))
+			it.Season, err = ec.unmarshalOInt2ᚖint(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		}
+	}
+
+	return it, nil
+}
+
+func (ec *executionContext) unmarshalInputSeriesFilter(ctx context.Context, obj interface{}) (model.SeriesFilter, error) {
+	var it model.SeriesFilter
+	asMap := map[string]interface{}{}
+	for k, v := range obj.(map[string]interface{}) {
+		asMap[k] = v
+	}
+
+	for k, v := range asMap {
+		switch k {
+		case "id":
+			var err error
+
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("id"))
+			it.ID, err = ec.unmarshalOID2ᚖstring(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		case "name":
+			var err error
+
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("name"))
+			it.Name, err = ec.unmarshalOString2ᚖstring(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		}
+	}
+
+	return it, nil
+}
+
+func (ec *executionContext) unmarshalInputVideoFilter(ctx context.Context, obj interface{}) (model.VideoFilter, error) {
+	var it model.VideoFilter
+	asMap := map[string]interface{}{}
+	for k, v := range obj.(map[string]interface{}) {
+		asMap[k] = v
+	}
+
+	for k, v := range asMap {
+		switch k {
+		case "title":
+			var err error
+
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("title"))
+			it.Title, err = ec.unmarshalOString2ᚖstring(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		case "titleContains":
+			var err error
+
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("titleContains"))
+			it.TitleContains, err = ec.unmarshalOString2ᚖstring(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		case "genre":
+			var err error
+
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("genre"))
+			it.Genre, err = ec.unmarshalOString2ᚖstring(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		case "contentRating":
+			var err error
+
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("contentRating"))
+			it.ContentRating, err = ec.unmarshalOString2ᚖstring(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		case "releasedFrom":
+			var err error
+
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("releasedFrom"))
+			it.ReleasedFrom, err = ec.unmarshalOInt2ᚖint(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		case "releasedUntil":
+			var err error
+
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("releasedUntil"))
+			it.ReleasedUntil, err = ec.unmarshalOInt2ᚖint(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		case "episodic":
+			var err error
+
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("episodic"))
+			it.Episodic, err = ec.unmarshalOBoolean2ᚖbool(ctx, v)
 			if err != nil {
 				return it, err
 			}
-		case "after":
+		case "series":
 			var err error
 
-			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("after"))
-			it.After, err = ec.unmarshalOID2ᚖstring(ctx, v)
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("series"))
+			it.Series, err = ec.unmarshalOSeriesFilter2ᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐSeriesFilter(ctx, v)
 			if err != nil {
 				return it, err
 			}
@@ -4208,103 +8322,364 @@ func (ec *executionContext) unmarshalInputPaginate(ctx context.Context, obj inte
 	return it, nil
 }
 
-func (ec *executionContext) unmarshalInputQualityFilter(ctx context.Context, obj interface{}) (model.QualityFilter, error) {
-	var it model.QualityFilter
-	asMap := map[string]interface{}{}
-	for k, v := range obj.(map[string]interface{}) {
-		asMap[k] = v
-	}
-
-	for k, v := range asMap {
-		switch k {
-		case "videoCodec":
-			var err error
+// endregion **************************** input.gotpl *****************************
 
-			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("videoCodec"))
-			it.VideoCodec, err = ec.unmarshalOVideoCodec2ᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐVideoCodec(ctx, v)
-			if err != nil {
-				return it, err
-			}
-		case "resolution":
-			var err error
+// region    ************************** interface.gotpl ***************************
 
-			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("resolution"))
-			it.Resolution, err = ec.unmarshalOResolution2ᚖstring(ctx, v)
-			if err != nil {
-				return it, err
-			}
+func (ec *executionContext) _CollectionMember(ctx context.Context, sel ast.SelectionSet, obj model.CollectionMember) graphql.Marshaler {
+	switch obj := (obj).(type) {
+	case nil:
+		return graphql.Null
+	case model.Video:
+		return ec._Video(ctx, sel, &obj)
+	case *model.Video:
+		if obj == nil {
+			return graphql.Null
+		}
+		return ec._Video(ctx, sel, obj)
+	case model.Series:
+		return ec._Series(ctx, sel, &obj)
+	case *model.Series:
+		if obj == nil {
+			return graphql.Null
 		}
+		return ec._Series(ctx, sel, obj)
+	case model.Episode:
+		return ec._Episode(ctx, sel, &obj)
+	case *model.Episode:
+		if obj == nil {
+			return graphql.Null
+		}
+		return ec._Episode(ctx, sel, obj)
+	default:
+		panic(fmt.Errorf("unexpected type %T", obj))
 	}
+}
 
-	return it, nil
+func (ec *executionContext) _Node(ctx context.Context, sel ast.SelectionSet, obj model.Node) graphql.Marshaler {
+	switch obj := (obj).(type) {
+	case nil:
+		return graphql.Null
+	case model.Video:
+		return ec._Video(ctx, sel, &obj)
+	case *model.Video:
+		if obj == nil {
+			return graphql.Null
+		}
+		return ec._Video(ctx, sel, obj)
+	case model.Collection:
+		return ec._Collection(ctx, sel, &obj)
+	case *model.Collection:
+		if obj == nil {
+			return graphql.Null
+		}
+		return ec._Collection(ctx, sel, obj)
+	case model.Franchise:
+		return ec._Franchise(ctx, sel, &obj)
+	case *model.Franchise:
+		if obj == nil {
+			return graphql.Null
+		}
+		return ec._Franchise(ctx, sel, obj)
+	case model.Series:
+		return ec._Series(ctx, sel, &obj)
+	case *model.Series:
+		if obj == nil {
+			return graphql.Null
+		}
+		return ec._Series(ctx, sel, obj)
+	case model.Season:
+		return ec._Season(ctx, sel, &obj)
+	case *model.Season:
+		if obj == nil {
+			return graphql.Null
+		}
+		return ec._Season(ctx, sel, obj)
+	case model.Episode:
+		return ec._Episode(ctx, sel, &obj)
+	case *model.Episode:
+		if obj == nil {
+			return graphql.Null
+		}
+		return ec._Episode(ctx, sel, obj)
+	case model.Rendition:
+		return ec._Rendition(ctx, sel, &obj)
+	case *model.Rendition:
+		if obj == nil {
+			return graphql.Null
+		}
+		return ec._Rendition(ctx, sel, obj)
+	default:
+		panic(fmt.Errorf("unexpected type %T", obj))
+	}
 }
 
-func (ec *executionContext) unmarshalInputSeasonFilter(ctx context.Context, obj interface{}) (model.SeasonFilter, error) {
-	var it model.SeasonFilter
-	asMap := map[string]interface{}{}
-	for k, v := range obj.(map[string]interface{}) {
-		asMap[k] = v
+// endregion ************************** interface.gotpl ***************************
+
+// region    **************************** object.gotpl ****************************
+
+var artworkImplementors = []string{"Artwork"}
+
+func (ec *executionContext) _Artwork(ctx context.Context, sel ast.SelectionSet, obj *model.Artwork) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, artworkImplementors)
+
+	out := graphql.NewFieldSet(fields)
+	var invalids uint32
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("Artwork")
+		case "url":
+			field := field
+			out.Concurrently(i, func() (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._Artwork_url(ctx, field, obj)
+				if res == graphql.Null {
+					atomic.AddUint32(&invalids, 1)
+				}
+				return res
+			})
+		case "base64":
+			field := field
+			out.Concurrently(i, func() (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._Artwork_base64(ctx, field, obj)
+				if res == graphql.Null {
+					atomic.AddUint32(&invalids, 1)
+				}
+				return res
+			})
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch()
+	if invalids > 0 {
+		return graphql.Null
 	}
+	return out
+}
 
-	for k, v := range asMap {
-		switch k {
-		case "season":
-			var err error
+var collectionImplementors = []string{"Collection", "Node"}
 
-			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("season"))
-			it.Season, err = ec.unmarshalOInt2ᚖint(ctx, v)
-			if err != nil {
-				return it, err
+func (ec *executionContext) _Collection(ctx context.Context, sel ast.SelectionSet, obj *model.Collection) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, collectionImplementors)
+
+	out := graphql.NewFieldSet(fields)
+	var invalids uint32
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("Collection")
+		case "id":
+			out.Values[i] = ec._Collection_id(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				atomic.AddUint32(&invalids, 1)
+			}
+		case "name":
+			out.Values[i] = ec._Collection_name(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				atomic.AddUint32(&invalids, 1)
 			}
+		case "smart":
+			out.Values[i] = ec._Collection_smart(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				atomic.AddUint32(&invalids, 1)
+			}
+		case "shared":
+			out.Values[i] = ec._Collection_shared(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				atomic.AddUint32(&invalids, 1)
+			}
+		case "owner":
+			field := field
+			out.Concurrently(i, func() (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._Collection_owner(ctx, field, obj)
+				return res
+			})
+		case "members":
+			field := field
+			out.Concurrently(i, func() (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._Collection_members(ctx, field, obj)
+				if res == graphql.Null {
+					atomic.AddUint32(&invalids, 1)
+				}
+				return res
+			})
+		case "memberCount":
+			field := field
+			out.Concurrently(i, func() (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._Collection_memberCount(ctx, field, obj)
+				if res == graphql.Null {
+					atomic.AddUint32(&invalids, 1)
+				}
+				return res
+			})
+		case "artwork":
+			field := field
+			out.Concurrently(i, func() (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._Collection_artwork(ctx, field, obj)
+				return res
+			})
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
 		}
 	}
-
-	return it, nil
+	out.Dispatch()
+	if invalids > 0 {
+		return graphql.Null
+	}
+	return out
 }
 
-func (ec *executionContext) unmarshalInputSeriesFilter(ctx context.Context, obj interface{}) (model.SeriesFilter, error) {
-	var it model.SeriesFilter
-	asMap := map[string]interface{}{}
-	for k, v := range obj.(map[string]interface{}) {
-		asMap[k] = v
-	}
+var contributorImplementors = []string{"Contributor"}
 
-	for k, v := range asMap {
-		switch k {
+func (ec *executionContext) _Contributor(ctx context.Context, sel ast.SelectionSet, obj *model.Contributor) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, contributorImplementors)
+
+	out := graphql.NewFieldSet(fields)
+	var invalids uint32
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("Contributor")
 		case "name":
-			var err error
+			out.Values[i] = ec._Contributor_name(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch()
+	if invalids > 0 {
+		return graphql.Null
+	}
+	return out
+}
 
-			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("name"))
-			it.Name, err = ec.unmarshalOString2ᚖstring(ctx, v)
-			if err != nil {
-				return it, err
+var episodeImplementors = []string{"Episode", "CollectionMember", "Node"}
+
+func (ec *executionContext) _Episode(ctx context.Context, sel ast.SelectionSet, obj *model.Episode) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, episodeImplementors)
+
+	out := graphql.NewFieldSet(fields)
+	var invalids uint32
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("Episode")
+		case "id":
+			out.Values[i] = ec._Episode_id(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				atomic.AddUint32(&invalids, 1)
+			}
+		case "season":
+			out.Values[i] = ec._Episode_season(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				atomic.AddUint32(&invalids, 1)
+			}
+		case "episode":
+			out.Values[i] = ec._Episode_episode(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				atomic.AddUint32(&invalids, 1)
+			}
+		case "episodeID":
+			out.Values[i] = ec._Episode_episodeID(ctx, field, obj)
+		case "video":
+			out.Values[i] = ec._Episode_video(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				atomic.AddUint32(&invalids, 1)
 			}
+		case "next":
+			field := field
+			out.Concurrently(i, func() (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._Episode_next(ctx, field, obj)
+				return res
+			})
+		case "previous":
+			field := field
+			out.Concurrently(i, func() (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._Episode_previous(ctx, field, obj)
+				return res
+			})
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
 		}
 	}
-
-	return it, nil
+	out.Dispatch()
+	if invalids > 0 {
+		return graphql.Null
+	}
+	return out
 }
 
-// endregion **************************** input.gotpl *****************************
-
-// region    ************************** interface.gotpl ***************************
-
-// endregion ************************** interface.gotpl ***************************
-
-// region    **************************** object.gotpl ****************************
-
-var artworkImplementors = []string{"Artwork"}
+var franchiseImplementors = []string{"Franchise", "Node"}
 
-func (ec *executionContext) _Artwork(ctx context.Context, sel ast.SelectionSet, obj *model.Artwork) graphql.Marshaler {
-	fields := graphql.CollectFields(ec.OperationContext, sel, artworkImplementors)
+func (ec *executionContext) _Franchise(ctx context.Context, sel ast.SelectionSet, obj *model.Franchise) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, franchiseImplementors)
 
 	out := graphql.NewFieldSet(fields)
 	var invalids uint32
 	for i, field := range fields {
 		switch field.Name {
 		case "__typename":
-			out.Values[i] = graphql.MarshalString("Artwork")
-		case "url":
+			out.Values[i] = graphql.MarshalString("Franchise")
+		case "id":
+			out.Values[i] = ec._Franchise_id(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				atomic.AddUint32(&invalids, 1)
+			}
+		case "name":
+			out.Values[i] = ec._Franchise_name(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				atomic.AddUint32(&invalids, 1)
+			}
+		case "explicit":
+			out.Values[i] = ec._Franchise_explicit(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				atomic.AddUint32(&invalids, 1)
+			}
+		case "videos":
 			field := field
 			out.Concurrently(i, func() (res graphql.Marshaler) {
 				defer func() {
@@ -4312,13 +8687,13 @@ func (ec *executionContext) _Artwork(ctx context.Context, sel ast.SelectionSet,
 						ec.Error(ctx, ec.Recover(ctx, r))
 					}
 				}()
-				res = ec._Artwork_url(ctx, field, obj)
+				res = ec._Franchise_videos(ctx, field, obj)
 				if res == graphql.Null {
 					atomic.AddUint32(&invalids, 1)
 				}
 				return res
 			})
-		case "base64":
+		case "videoCount":
 			field := field
 			out.Concurrently(i, func() (res graphql.Marshaler) {
 				defer func() {
@@ -4326,7 +8701,7 @@ func (ec *executionContext) _Artwork(ctx context.Context, sel ast.SelectionSet,
 						ec.Error(ctx, ec.Recover(ctx, r))
 					}
 				}()
-				res = ec._Artwork_base64(ctx, field, obj)
+				res = ec._Franchise_videoCount(ctx, field, obj)
 				if res == graphql.Null {
 					atomic.AddUint32(&invalids, 1)
 				}
@@ -4343,22 +8718,115 @@ func (ec *executionContext) _Artwork(ctx context.Context, sel ast.SelectionSet,
 	return out
 }
 
-var contributorImplementors = []string{"Contributor"}
+var mutationImplementors = []string{"Mutation"}
 
-func (ec *executionContext) _Contributor(ctx context.Context, sel ast.SelectionSet, obj *model.Contributor) graphql.Marshaler {
-	fields := graphql.CollectFields(ec.OperationContext, sel, contributorImplementors)
+func (ec *executionContext) _Mutation(ctx context.Context, sel ast.SelectionSet) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, mutationImplementors)
+
+	ctx = graphql.WithFieldContext(ctx, &graphql.FieldContext{
+		Object: "Mutation",
+	})
 
 	out := graphql.NewFieldSet(fields)
 	var invalids uint32
 	for i, field := range fields {
 		switch field.Name {
 		case "__typename":
-			out.Values[i] = graphql.MarshalString("Contributor")
+			out.Values[i] = graphql.MarshalString("Mutation")
+		case "createProfile":
+			out.Values[i] = ec._Mutation_createProfile(ctx, field)
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		case "restrictProfile":
+			out.Values[i] = ec._Mutation_restrictProfile(ctx, field)
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		case "reportProgress":
+			out.Values[i] = ec._Mutation_reportProgress(ctx, field)
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		case "markWatched":
+			out.Values[i] = ec._Mutation_markWatched(ctx, field)
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		case "createCollection":
+			out.Values[i] = ec._Mutation_createCollection(ctx, field)
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		case "renameCollection":
+			out.Values[i] = ec._Mutation_renameCollection(ctx, field)
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		case "filterCollection":
+			out.Values[i] = ec._Mutation_filterCollection(ctx, field)
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		case "deleteCollection":
+			out.Values[i] = ec._Mutation_deleteCollection(ctx, field)
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		case "addToCollection":
+			out.Values[i] = ec._Mutation_addToCollection(ctx, field)
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		case "removeFromCollection":
+			out.Values[i] = ec._Mutation_removeFromCollection(ctx, field)
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		case "moveInCollection":
+			out.Values[i] = ec._Mutation_moveInCollection(ctx, field)
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch()
+	if invalids > 0 {
+		return graphql.Null
+	}
+	return out
+}
+
+var profileImplementors = []string{"Profile"}
+
+func (ec *executionContext) _Profile(ctx context.Context, sel ast.SelectionSet, obj *model.Profile) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, profileImplementors)
+
+	out := graphql.NewFieldSet(fields)
+	var invalids uint32
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("Profile")
+		case "id":
+			out.Values[i] = ec._Profile_id(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
 		case "name":
-			out.Values[i] = ec._Contributor_name(ctx, field, obj)
+			out.Values[i] = ec._Profile_name(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		case "restricted":
+			out.Values[i] = ec._Profile_restricted(ctx, field, obj)
 			if out.Values[i] == graphql.Null {
 				invalids++
 			}
+		case "maxContentRating":
+			out.Values[i] = ec._Profile_maxContentRating(ctx, field, obj)
 		default:
 			panic("unknown field " + strconv.Quote(field.Name))
 		}
@@ -4370,31 +8838,29 @@ func (ec *executionContext) _Contributor(ctx context.Context, sel ast.SelectionS
 	return out
 }
 
-var episodeImplementors = []string{"Episode"}
+var progressImplementors = []string{"Progress"}
 
-func (ec *executionContext) _Episode(ctx context.Context, sel ast.SelectionSet, obj *model.Episode) graphql.Marshaler {
-	fields := graphql.CollectFields(ec.OperationContext, sel, episodeImplementors)
+func (ec *executionContext) _Progress(ctx context.Context, sel ast.SelectionSet, obj *model.Progress) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, progressImplementors)
 
 	out := graphql.NewFieldSet(fields)
 	var invalids uint32
 	for i, field := range fields {
 		switch field.Name {
 		case "__typename":
-			out.Values[i] = graphql.MarshalString("Episode")
-		case "season":
-			out.Values[i] = ec._Episode_season(ctx, field, obj)
+			out.Values[i] = graphql.MarshalString("Progress")
+		case "seconds":
+			out.Values[i] = ec._Progress_seconds(ctx, field, obj)
 			if out.Values[i] == graphql.Null {
 				invalids++
 			}
-		case "episode":
-			out.Values[i] = ec._Episode_episode(ctx, field, obj)
+		case "watched":
+			out.Values[i] = ec._Progress_watched(ctx, field, obj)
 			if out.Values[i] == graphql.Null {
 				invalids++
 			}
-		case "episodeID":
-			out.Values[i] = ec._Episode_episodeID(ctx, field, obj)
-		case "video":
-			out.Values[i] = ec._Episode_video(ctx, field, obj)
+		case "lastWatched":
+			out.Values[i] = ec._Progress_lastWatched(ctx, field, obj)
 			if out.Values[i] == graphql.Null {
 				invalids++
 			}
@@ -4458,6 +8924,31 @@ func (ec *executionContext) _Query(ctx context.Context, sel ast.SelectionSet) gr
 		switch field.Name {
 		case "__typename":
 			out.Values[i] = graphql.MarshalString("Query")
+		case "node":
+			field := field
+			out.Concurrently(i, func() (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._Query_node(ctx, field)
+				return res
+			})
+		case "nodes":
+			field := field
+			out.Concurrently(i, func() (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._Query_nodes(ctx, field)
+				if res == graphql.Null {
+					atomic.AddUint32(&invalids, 1)
+				}
+				return res
+			})
 		case "video":
 			field := field
 			out.Concurrently(i, func() (res graphql.Marshaler) {
@@ -4466,13 +8957,116 @@ func (ec *executionContext) _Query(ctx context.Context, sel ast.SelectionSet) gr
 						ec.Error(ctx, ec.Recover(ctx, r))
 					}
 				}()
-				res = ec._Query_video(ctx, field)
+				res = ec._Query_video(ctx, field)
+				if res == graphql.Null {
+					atomic.AddUint32(&invalids, 1)
+				}
+				return res
+			})
+		case "videos":
+			field := field
+			out.Concurrently(i, func() (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._Query_videos(ctx, field)
+				if res == graphql.Null {
+					atomic.AddUint32(&invalids, 1)
+				}
+				return res
+			})
+		case "series":
+			field := field
+			out.Concurrently(i, func() (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._Query_series(ctx, field)
+				if res == graphql.Null {
+					atomic.AddUint32(&invalids, 1)
+				}
+				return res
+			})
+		case "seriesById":
+			field := field
+			out.Concurrently(i, func() (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._Query_seriesById(ctx, field)
+				return res
+			})
+		case "season":
+			field := field
+			out.Concurrently(i, func() (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._Query_season(ctx, field)
+				return res
+			})
+		case "episode":
+			field := field
+			out.Concurrently(i, func() (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._Query_episode(ctx, field)
+				return res
+			})
+		case "seasons":
+			field := field
+			out.Concurrently(i, func() (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._Query_seasons(ctx, field)
+				if res == graphql.Null {
+					atomic.AddUint32(&invalids, 1)
+				}
+				return res
+			})
+		case "episodes":
+			field := field
+			out.Concurrently(i, func() (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._Query_episodes(ctx, field)
+				if res == graphql.Null {
+					atomic.AddUint32(&invalids, 1)
+				}
+				return res
+			})
+		case "episodeCount":
+			field := field
+			out.Concurrently(i, func() (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._Query_episodeCount(ctx, field)
 				if res == graphql.Null {
 					atomic.AddUint32(&invalids, 1)
 				}
 				return res
 			})
-		case "videos":
+		case "profiles":
 			field := field
 			out.Concurrently(i, func() (res graphql.Marshaler) {
 				defer func() {
@@ -4480,13 +9074,13 @@ func (ec *executionContext) _Query(ctx context.Context, sel ast.SelectionSet) gr
 						ec.Error(ctx, ec.Recover(ctx, r))
 					}
 				}()
-				res = ec._Query_videos(ctx, field)
+				res = ec._Query_profiles(ctx, field)
 				if res == graphql.Null {
 					atomic.AddUint32(&invalids, 1)
 				}
 				return res
 			})
-		case "series":
+		case "continueWatching":
 			field := field
 			out.Concurrently(i, func() (res graphql.Marshaler) {
 				defer func() {
@@ -4494,13 +9088,13 @@ func (ec *executionContext) _Query(ctx context.Context, sel ast.SelectionSet) gr
 						ec.Error(ctx, ec.Recover(ctx, r))
 					}
 				}()
-				res = ec._Query_series(ctx, field)
+				res = ec._Query_continueWatching(ctx, field)
 				if res == graphql.Null {
 					atomic.AddUint32(&invalids, 1)
 				}
 				return res
 			})
-		case "seasons":
+		case "collections":
 			field := field
 			out.Concurrently(i, func() (res graphql.Marshaler) {
 				defer func() {
@@ -4508,13 +9102,13 @@ func (ec *executionContext) _Query(ctx context.Context, sel ast.SelectionSet) gr
 						ec.Error(ctx, ec.Recover(ctx, r))
 					}
 				}()
-				res = ec._Query_seasons(ctx, field)
+				res = ec._Query_collections(ctx, field)
 				if res == graphql.Null {
 					atomic.AddUint32(&invalids, 1)
 				}
 				return res
 			})
-		case "episodes":
+		case "collection":
 			field := field
 			out.Concurrently(i, func() (res graphql.Marshaler) {
 				defer func() {
@@ -4522,13 +9116,10 @@ func (ec *executionContext) _Query(ctx context.Context, sel ast.SelectionSet) gr
 						ec.Error(ctx, ec.Recover(ctx, r))
 					}
 				}()
-				res = ec._Query_episodes(ctx, field)
-				if res == graphql.Null {
-					atomic.AddUint32(&invalids, 1)
-				}
+				res = ec._Query_collection(ctx, field)
 				return res
 			})
-		case "episodeCount":
+		case "franchises":
 			field := field
 			out.Concurrently(i, func() (res graphql.Marshaler) {
 				defer func() {
@@ -4536,7 +9127,7 @@ func (ec *executionContext) _Query(ctx context.Context, sel ast.SelectionSet) gr
 						ec.Error(ctx, ec.Recover(ctx, r))
 					}
 				}()
-				res = ec._Query_episodeCount(ctx, field)
+				res = ec._Query_franchises(ctx, field)
 				if res == graphql.Null {
 					atomic.AddUint32(&invalids, 1)
 				}
@@ -4557,7 +9148,7 @@ func (ec *executionContext) _Query(ctx context.Context, sel ast.SelectionSet) gr
 	return out
 }
 
-var renditionImplementors = []string{"Rendition"}
+var renditionImplementors = []string{"Rendition", "Node"}
 
 func (ec *executionContext) _Rendition(ctx context.Context, sel ast.SelectionSet, obj *model.Rendition) graphql.Marshaler {
 	fields := graphql.CollectFields(ec.OperationContext, sel, renditionImplementors)
@@ -4598,6 +9189,11 @@ func (ec *executionContext) _Rendition(ctx context.Context, sel ast.SelectionSet
 			out.Values[i] = ec._Rendition_duration(ctx, field, obj)
 		case "isHD":
 			out.Values[i] = ec._Rendition_isHD(ctx, field, obj)
+		case "isStreamable":
+			out.Values[i] = ec._Rendition_isStreamable(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				atomic.AddUint32(&invalids, 1)
+			}
 		case "size":
 			out.Values[i] = ec._Rendition_size(ctx, field, obj)
 			if out.Values[i] == graphql.Null {
@@ -4649,7 +9245,49 @@ func (ec *executionContext) _Renditions(ctx context.Context, sel ast.SelectionSe
 	return out
 }
 
-var seasonImplementors = []string{"Season"}
+var scanProgressImplementors = []string{"ScanProgress"}
+
+func (ec *executionContext) _ScanProgress(ctx context.Context, sel ast.SelectionSet, obj *model.ScanProgress) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, scanProgressImplementors)
+
+	out := graphql.NewFieldSet(fields)
+	var invalids uint32
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("ScanProgress")
+		case "root":
+			out.Values[i] = ec._ScanProgress_root(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		case "filesScanned":
+			out.Values[i] = ec._ScanProgress_filesScanned(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		case "videos":
+			out.Values[i] = ec._ScanProgress_videos(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		case "done":
+			out.Values[i] = ec._ScanProgress_done(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch()
+	if invalids > 0 {
+		return graphql.Null
+	}
+	return out
+}
+
+var seasonImplementors = []string{"Season", "Node"}
 
 func (ec *executionContext) _Season(ctx context.Context, sel ast.SelectionSet, obj *model.Season) graphql.Marshaler {
 	fields := graphql.CollectFields(ec.OperationContext, sel, seasonImplementors)
@@ -4660,6 +9298,11 @@ func (ec *executionContext) _Season(ctx context.Context, sel ast.SelectionSet, o
 		switch field.Name {
 		case "__typename":
 			out.Values[i] = graphql.MarshalString("Season")
+		case "id":
+			out.Values[i] = ec._Season_id(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				atomic.AddUint32(&invalids, 1)
+			}
 		case "series":
 			out.Values[i] = ec._Season_series(ctx, field, obj)
 			if out.Values[i] == graphql.Null {
@@ -4698,6 +9341,20 @@ func (ec *executionContext) _Season(ctx context.Context, sel ast.SelectionSet, o
 				}
 				return res
 			})
+		case "unwatchedCount":
+			field := field
+			out.Concurrently(i, func() (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._Season_unwatchedCount(ctx, field, obj)
+				if res == graphql.Null {
+					atomic.AddUint32(&invalids, 1)
+				}
+				return res
+			})
 		default:
 			panic("unknown field " + strconv.Quote(field.Name))
 		}
@@ -4709,7 +9366,7 @@ func (ec *executionContext) _Season(ctx context.Context, sel ast.SelectionSet, o
 	return out
 }
 
-var seriesImplementors = []string{"Series"}
+var seriesImplementors = []string{"Series", "CollectionMember", "Node"}
 
 func (ec *executionContext) _Series(ctx context.Context, sel ast.SelectionSet, obj *model.Series) graphql.Marshaler {
 	fields := graphql.CollectFields(ec.OperationContext, sel, seriesImplementors)
@@ -4720,6 +9377,11 @@ func (ec *executionContext) _Series(ctx context.Context, sel ast.SelectionSet, o
 		switch field.Name {
 		case "__typename":
 			out.Values[i] = graphql.MarshalString("Series")
+		case "id":
+			out.Values[i] = ec._Series_id(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				atomic.AddUint32(&invalids, 1)
+			}
 		case "name":
 			out.Values[i] = ec._Series_name(ctx, field, obj)
 			if out.Values[i] == graphql.Null {
@@ -4774,6 +9436,17 @@ func (ec *executionContext) _Series(ctx context.Context, sel ast.SelectionSet, o
 				}
 				return res
 			})
+		case "nextUp":
+			field := field
+			out.Concurrently(i, func() (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._Series_nextUp(ctx, field, obj)
+				return res
+			})
 		default:
 			panic("unknown field " + strconv.Quote(field.Name))
 		}
@@ -4785,7 +9458,33 @@ func (ec *executionContext) _Series(ctx context.Context, sel ast.SelectionSet, o
 	return out
 }
 
-var videoImplementors = []string{"Video"}
+var subscriptionImplementors = []string{"Subscription"}
+
+func (ec *executionContext) _Subscription(ctx context.Context, sel ast.SelectionSet) func() graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, subscriptionImplementors)
+	ctx = graphql.WithFieldContext(ctx, &graphql.FieldContext{
+		Object: "Subscription",
+	})
+	if len(fields) != 1 {
+		ec.Errorf(ctx, "must subscribe to exactly one stream")
+		return nil
+	}
+
+	switch fields[0].Name {
+	case "videoAdded":
+		return ec._Subscription_videoAdded(ctx, fields[0])
+	case "videoUpdated":
+		return ec._Subscription_videoUpdated(ctx, fields[0])
+	case "videoRemoved":
+		return ec._Subscription_videoRemoved(ctx, fields[0])
+	case "scanProgress":
+		return ec._Subscription_scanProgress(ctx, fields[0])
+	default:
+		panic("unknown field " + strconv.Quote(fields[0].Name))
+	}
+}
+
+var videoImplementors = []string{"Video", "Node", "CollectionMember"}
 
 func (ec *executionContext) _Video(ctx context.Context, sel ast.SelectionSet, obj *model.Video) graphql.Marshaler {
 	fields := graphql.CollectFields(ec.OperationContext, sel, videoImplementors)
@@ -4818,6 +9517,28 @@ func (ec *executionContext) _Video(ctx context.Context, sel ast.SelectionSet, ob
 			}
 		case "renditions":
 			out.Values[i] = ec._Video_renditions(ctx, field, obj)
+		case "hlsUrl":
+			field := field
+			out.Concurrently(i, func() (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._Video_hlsUrl(ctx, field, obj)
+				return res
+			})
+		case "dashUrl":
+			field := field
+			out.Concurrently(i, func() (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._Video_dashUrl(ctx, field, obj)
+				return res
+			})
 		case "artwork":
 			field := field
 			out.Concurrently(i, func() (res graphql.Marshaler) {
@@ -4845,6 +9566,168 @@ func (ec *executionContext) _Video(ctx context.Context, sel ast.SelectionSet, ob
 			out.Values[i] = ec._Video_tomatometer(ctx, field, obj)
 		case "episode":
 			out.Values[i] = ec._Video_episode(ctx, field, obj)
+		case "franchise":
+			field := field
+			out.Concurrently(i, func() (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._Video_franchise(ctx, field, obj)
+				return res
+			})
+		case "progress":
+			field := field
+			out.Concurrently(i, func() (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._Video_progress(ctx, field, obj)
+				return res
+			})
+		case "watched":
+			field := field
+			out.Concurrently(i, func() (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._Video_watched(ctx, field, obj)
+				if res == graphql.Null {
+					atomic.AddUint32(&invalids, 1)
+				}
+				return res
+			})
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch()
+	if invalids > 0 {
+		return graphql.Null
+	}
+	return out
+}
+
+var __DirectiveImplementors = []string{"__Directive"}
+
+func (ec *executionContext) ___Directive(ctx context.Context, sel ast.SelectionSet, obj *introspection.Directive) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, __DirectiveImplementors)
+
+	out := graphql.NewFieldSet(fields)
+	var invalids uint32
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("__Directive")
+		case "name":
+			out.Values[i] = ec.___Directive_name(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		case "description":
+			out.Values[i] = ec.___Directive_description(ctx, field, obj)
+		case "locations":
+			out.Values[i] = ec.___Directive_locations(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		case "args":
+			out.Values[i] = ec.___Directive_args(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		case "isRepeatable":
+			out.Values[i] = ec.___Directive_isRepeatable(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch()
+	if invalids > 0 {
+		return graphql.Null
+	}
+	return out
+}
+
+var __EnumValueImplementors = []string{"__EnumValue"}
+
+func (ec *executionContext) ___EnumValue(ctx context.Context, sel ast.SelectionSet, obj *introspection.EnumValue) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, __EnumValueImplementors)
+
+	out := graphql.NewFieldSet(fields)
+	var invalids uint32
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("__EnumValue")
+		case "name":
+			out.Values[i] = ec.___EnumValue_name(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		case "description":
+			out.Values[i] = ec.___EnumValue_description(ctx, field, obj)
+		case "isDeprecated":
+			out.Values[i] = ec.___EnumValue_isDeprecated(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		case "deprecationReason":
+			out.Values[i] = ec.___EnumValue_deprecationReason(ctx, field, obj)
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch()
+	if invalids > 0 {
+		return graphql.Null
+	}
+	return out
+}
+
+var __FieldImplementors = []string{"__Field"}
+
+func (ec *executionContext) ___Field(ctx context.Context, sel ast.SelectionSet, obj *introspection.Field) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, __FieldImplementors)
+
+	out := graphql.NewFieldSet(fields)
+	var invalids uint32
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("__Field")
+		case "name":
+			out.Values[i] = ec.___Field_name(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		case "description":
+			out.Values[i] = ec.___Field_description(ctx, field, obj)
+		case "args":
+			out.Values[i] = ec.___Field_args(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		case "type":
+			out.Values[i] = ec.___Field_type(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		case "isDeprecated":
+			out.Values[i] = ec.___Field_isDeprecated(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		case "deprecationReason":
+			out.Values[i] = ec.___Field_deprecationReason(ctx, field, obj)
 		default:
 			panic("unknown field " + strconv.Quote(field.Name))
 		}
@@ -4856,39 +9739,31 @@ func (ec *executionContext) _Video(ctx context.Context, sel ast.SelectionSet, ob
 	return out
 }
 
-var __DirectiveImplementors = []string{"__Directive"}
+var __InputValueImplementors = []string{"__InputValue"}
 
-func (ec *executionContext) ___Directive(ctx context.Context, sel ast.SelectionSet, obj *introspection.Directive) graphql.Marshaler {
-	fields := graphql.CollectFields(ec.OperationContext, sel, __DirectiveImplementors)
+func (ec *executionContext) ___InputValue(ctx context.Context, sel ast.SelectionSet, obj *introspection.InputValue) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, __InputValueImplementors)
 
 	out := graphql.NewFieldSet(fields)
 	var invalids uint32
 	for i, field := range fields {
 		switch field.Name {
 		case "__typename":
-			out.Values[i] = graphql.MarshalString("__Directive")
+			out.Values[i] = graphql.MarshalString("__InputValue")
 		case "name":
-			out.Values[i] = ec.___Directive_name(ctx, field, obj)
+			out.Values[i] = ec.___InputValue_name(ctx, field, obj)
 			if out.Values[i] == graphql.Null {
 				invalids++
 			}
 		case "description":
-			out.Values[i] = ec.___Directive_description(ctx, field, obj)
-		case "locations":
-			out.Values[i] = ec.___Directive_locations(ctx, field, obj)
-			if out.Values[i] == graphql.Null {
-				invalids++
-			}
-		case "args":
-			out.Values[i] = ec.___Directive_args(ctx, field, obj)
-			if out.Values[i] == graphql.Null {
-				invalids++
-			}
-		case "isRepeatable":
-			out.Values[i] = ec.___Directive_isRepeatable(ctx, field, obj)
+			out.Values[i] = ec.___InputValue_description(ctx, field, obj)
+		case "type":
+			out.Values[i] = ec.___InputValue_type(ctx, field, obj)
 			if out.Values[i] == graphql.Null {
 				invalids++
 			}
+		case "defaultValue":
+			out.Values[i] = ec.___InputValue_defaultValue(ctx, field, obj)
 		default:
 			panic("unknown field " + strconv.Quote(field.Name))
 		}
@@ -4900,31 +9775,36 @@ func (ec *executionContext) ___Directive(ctx context.Context, sel ast.SelectionS
 	return out
 }
 
-var __EnumValueImplementors = []string{"__EnumValue"}
+var __SchemaImplementors = []string{"__Schema"}
 
-func (ec *executionContext) ___EnumValue(ctx context.Context, sel ast.SelectionSet, obj *introspection.EnumValue) graphql.Marshaler {
-	fields := graphql.CollectFields(ec.OperationContext, sel, __EnumValueImplementors)
+func (ec *executionContext) ___Schema(ctx context.Context, sel ast.SelectionSet, obj *introspection.Schema) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, __SchemaImplementors)
 
 	out := graphql.NewFieldSet(fields)
 	var invalids uint32
 	for i, field := range fields {
 		switch field.Name {
 		case "__typename":
-			out.Values[i] = graphql.MarshalString("__EnumValue")
-		case "name":
-			out.Values[i] = ec.___EnumValue_name(ctx, field, obj)
+			out.Values[i] = graphql.MarshalString("__Schema")
+		case "types":
+			out.Values[i] = ec.___Schema_types(ctx, field, obj)
 			if out.Values[i] == graphql.Null {
 				invalids++
 			}
-		case "description":
-			out.Values[i] = ec.___EnumValue_description(ctx, field, obj)
-		case "isDeprecated":
-			out.Values[i] = ec.___EnumValue_isDeprecated(ctx, field, obj)
+		case "queryType":
+			out.Values[i] = ec.___Schema_queryType(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		case "mutationType":
+			out.Values[i] = ec.___Schema_mutationType(ctx, field, obj)
+		case "subscriptionType":
+			out.Values[i] = ec.___Schema_subscriptionType(ctx, field, obj)
+		case "directives":
+			out.Values[i] = ec.___Schema_directives(ctx, field, obj)
 			if out.Values[i] == graphql.Null {
 				invalids++
 			}
-		case "deprecationReason":
-			out.Values[i] = ec.___EnumValue_deprecationReason(ctx, field, obj)
 		default:
 			panic("unknown field " + strconv.Quote(field.Name))
 		}
@@ -4936,202 +9816,407 @@ func (ec *executionContext) ___EnumValue(ctx context.Context, sel ast.SelectionS
 	return out
 }
 
-var __FieldImplementors = []string{"__Field"}
+var __TypeImplementors = []string{"__Type"}
 
-func (ec *executionContext) ___Field(ctx context.Context, sel ast.SelectionSet, obj *introspection.Field) graphql.Marshaler {
-	fields := graphql.CollectFields(ec.OperationContext, sel, __FieldImplementors)
+func (ec *executionContext) ___Type(ctx context.Context, sel ast.SelectionSet, obj *introspection.Type) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, __TypeImplementors)
 
 	out := graphql.NewFieldSet(fields)
 	var invalids uint32
 	for i, field := range fields {
 		switch field.Name {
 		case "__typename":
-			out.Values[i] = graphql.MarshalString("__Field")
-		case "name":
-			out.Values[i] = ec.___Field_name(ctx, field, obj)
+			out.Values[i] = graphql.MarshalString("__Type")
+		case "kind":
+			out.Values[i] = ec.___Type_kind(ctx, field, obj)
 			if out.Values[i] == graphql.Null {
 				invalids++
 			}
+		case "name":
+			out.Values[i] = ec.___Type_name(ctx, field, obj)
 		case "description":
-			out.Values[i] = ec.___Field_description(ctx, field, obj)
-		case "args":
-			out.Values[i] = ec.___Field_args(ctx, field, obj)
-			if out.Values[i] == graphql.Null {
-				invalids++
+			out.Values[i] = ec.___Type_description(ctx, field, obj)
+		case "fields":
+			out.Values[i] = ec.___Type_fields(ctx, field, obj)
+		case "interfaces":
+			out.Values[i] = ec.___Type_interfaces(ctx, field, obj)
+		case "possibleTypes":
+			out.Values[i] = ec.___Type_possibleTypes(ctx, field, obj)
+		case "enumValues":
+			out.Values[i] = ec.___Type_enumValues(ctx, field, obj)
+		case "inputFields":
+			out.Values[i] = ec.___Type_inputFields(ctx, field, obj)
+		case "ofType":
+			out.Values[i] = ec.___Type_ofType(ctx, field, obj)
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch()
+	if invalids > 0 {
+		return graphql.Null
+	}
+	return out
+}
+
+// endregion **************************** object.gotpl ****************************
+
+// region    ***************************** type.gotpl *****************************
+
+func (ec *executionContext) unmarshalNBoolean2bool(ctx context.Context, v interface{}) (bool, error) {
+	res, err := graphql.UnmarshalBoolean(v)
+	return res, graphql.ErrorOnPath(ctx, err)
+}
+
+func (ec *executionContext) marshalNBoolean2bool(ctx context.Context, sel ast.SelectionSet, v bool) graphql.Marshaler {
+	res := graphql.MarshalBoolean(v)
+	if res == graphql.Null {
+		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
+			ec.Errorf(ctx, "must not be null")
+		}
+	}
+	return res
+}
+
+func (ec *executionContext) marshalNCollection2githubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐCollection(ctx context.Context, sel ast.SelectionSet, v model.Collection) graphql.Marshaler {
+	return ec._Collection(ctx, sel, &v)
+}
+
+func (ec *executionContext) marshalNCollection2ᚕᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐCollectionᚄ(ctx context.Context, sel ast.SelectionSet, v []*model.Collection) graphql.Marshaler {
+	ret := make(graphql.Array, len(v))
+	var wg sync.WaitGroup
+	isLen1 := len(v) == 1
+	if !isLen1 {
+		wg.Add(len(v))
+	}
+	for i := range v {
+		i := i
+		fc := &graphql.FieldContext{
+			Index:  &i,
+			Result: &v[i],
+		}
+		ctx := graphql.WithFieldContext(ctx, fc)
+		f := func(i int) {
+			defer func() {
+				if r := recover(); r != nil {
+					ec.Error(ctx, ec.Recover(ctx, r))
+					ret = nil
+				}
+			}()
+			if !isLen1 {
+				defer wg.Done()
 			}
-		case "type":
-			out.Values[i] = ec.___Field_type(ctx, field, obj)
-			if out.Values[i] == graphql.Null {
-				invalids++
+			ret[i] = ec.marshalNCollection2ᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐCollection(ctx, sel, v[i])
+		}
+		if isLen1 {
+			f(i)
+		} else {
+			go f(i)
+		}
+
+	}
+	wg.Wait()
+
+	for _, e := range ret {
+		if e == graphql.Null {
+			return graphql.Null
+		}
+	}
+
+	return ret
+}
+
+func (ec *executionContext) marshalNCollection2ᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐCollection(ctx context.Context, sel ast.SelectionSet, v *model.Collection) graphql.Marshaler {
+	if v == nil {
+		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	return ec._Collection(ctx, sel, v)
+}
+
+func (ec *executionContext) marshalNCollectionMember2githubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐCollectionMember(ctx context.Context, sel ast.SelectionSet, v model.CollectionMember) graphql.Marshaler {
+	if v == nil {
+		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	return ec._CollectionMember(ctx, sel, v)
+}
+
+func (ec *executionContext) marshalNCollectionMember2ᚕgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐCollectionMemberᚄ(ctx context.Context, sel ast.SelectionSet, v []model.CollectionMember) graphql.Marshaler {
+	ret := make(graphql.Array, len(v))
+	var wg sync.WaitGroup
+	isLen1 := len(v) == 1
+	if !isLen1 {
+		wg.Add(len(v))
+	}
+	for i := range v {
+		i := i
+		fc := &graphql.FieldContext{
+			Index:  &i,
+			Result: &v[i],
+		}
+		ctx := graphql.WithFieldContext(ctx, fc)
+		f := func(i int) {
+			defer func() {
+				if r := recover(); r != nil {
+					ec.Error(ctx, ec.Recover(ctx, r))
+					ret = nil
+				}
+			}()
+			if !isLen1 {
+				defer wg.Done()
 			}
-		case "isDeprecated":
-			out.Values[i] = ec.___Field_isDeprecated(ctx, field, obj)
-			if out.Values[i] == graphql.Null {
-				invalids++
+			ret[i] = ec.marshalNCollectionMember2githubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐCollectionMember(ctx, sel, v[i])
+		}
+		if isLen1 {
+			f(i)
+		} else {
+			go f(i)
+		}
+
+	}
+	wg.Wait()
+
+	for _, e := range ret {
+		if e == graphql.Null {
+			return graphql.Null
+		}
+	}
+
+	return ret
+}
+
+func (ec *executionContext) marshalNContributor2ᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐContributor(ctx context.Context, sel ast.SelectionSet, v *model.Contributor) graphql.Marshaler {
+	if v == nil {
+		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	return ec._Contributor(ctx, sel, v)
+}
+
+func (ec *executionContext) marshalNEpisode2ᚕᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐEpisodeᚄ(ctx context.Context, sel ast.SelectionSet, v []*model.Episode) graphql.Marshaler {
+	ret := make(graphql.Array, len(v))
+	var wg sync.WaitGroup
+	isLen1 := len(v) == 1
+	if !isLen1 {
+		wg.Add(len(v))
+	}
+	for i := range v {
+		i := i
+		fc := &graphql.FieldContext{
+			Index:  &i,
+			Result: &v[i],
+		}
+		ctx := graphql.WithFieldContext(ctx, fc)
+		f := func(i int) {
+			defer func() {
+				if r := recover(); r != nil {
+					ec.Error(ctx, ec.Recover(ctx, r))
+					ret = nil
+				}
+			}()
+			if !isLen1 {
+				defer wg.Done()
 			}
-		case "deprecationReason":
-			out.Values[i] = ec.___Field_deprecationReason(ctx, field, obj)
-		default:
-			panic("unknown field " + strconv.Quote(field.Name))
+			ret[i] = ec.marshalNEpisode2ᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐEpisode(ctx, sel, v[i])
 		}
+		if isLen1 {
+			f(i)
+		} else {
+			go f(i)
+		}
+
 	}
-	out.Dispatch()
-	if invalids > 0 {
-		return graphql.Null
-	}
-	return out
-}
+	wg.Wait()
 
-var __InputValueImplementors = []string{"__InputValue"}
+	for _, e := range ret {
+		if e == graphql.Null {
+			return graphql.Null
+		}
+	}
 
-func (ec *executionContext) ___InputValue(ctx context.Context, sel ast.SelectionSet, obj *introspection.InputValue) graphql.Marshaler {
-	fields := graphql.CollectFields(ec.OperationContext, sel, __InputValueImplementors)
+	return ret
+}
 
-	out := graphql.NewFieldSet(fields)
-	var invalids uint32
-	for i, field := range fields {
-		switch field.Name {
-		case "__typename":
-			out.Values[i] = graphql.MarshalString("__InputValue")
-		case "name":
-			out.Values[i] = ec.___InputValue_name(ctx, field, obj)
-			if out.Values[i] == graphql.Null {
-				invalids++
-			}
-		case "description":
-			out.Values[i] = ec.___InputValue_description(ctx, field, obj)
-		case "type":
-			out.Values[i] = ec.___InputValue_type(ctx, field, obj)
-			if out.Values[i] == graphql.Null {
-				invalids++
-			}
-		case "defaultValue":
-			out.Values[i] = ec.___InputValue_defaultValue(ctx, field, obj)
-		default:
-			panic("unknown field " + strconv.Quote(field.Name))
+func (ec *executionContext) marshalNEpisode2ᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐEpisode(ctx context.Context, sel ast.SelectionSet, v *model.Episode) graphql.Marshaler {
+	if v == nil {
+		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
+			ec.Errorf(ctx, "must not be null")
 		}
-	}
-	out.Dispatch()
-	if invalids > 0 {
 		return graphql.Null
 	}
-	return out
+	return ec._Episode(ctx, sel, v)
 }
 
-var __SchemaImplementors = []string{"__Schema"}
+func (ec *executionContext) marshalNFranchise2ᚕᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐFranchiseᚄ(ctx context.Context, sel ast.SelectionSet, v []*model.Franchise) graphql.Marshaler {
+	ret := make(graphql.Array, len(v))
+	var wg sync.WaitGroup
+	isLen1 := len(v) == 1
+	if !isLen1 {
+		wg.Add(len(v))
+	}
+	for i := range v {
+		i := i
+		fc := &graphql.FieldContext{
+			Index:  &i,
+			Result: &v[i],
+		}
+		ctx := graphql.WithFieldContext(ctx, fc)
+		f := func(i int) {
+			defer func() {
+				if r := recover(); r != nil {
+					ec.Error(ctx, ec.Recover(ctx, r))
+					ret = nil
+				}
+			}()
+			if !isLen1 {
+				defer wg.Done()
+			}
+			ret[i] = ec.marshalNFranchise2ᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐFranchise(ctx, sel, v[i])
+		}
+		if isLen1 {
+			f(i)
+		} else {
+			go f(i)
+		}
 
-func (ec *executionContext) ___Schema(ctx context.Context, sel ast.SelectionSet, obj *introspection.Schema) graphql.Marshaler {
-	fields := graphql.CollectFields(ec.OperationContext, sel, __SchemaImplementors)
+	}
+	wg.Wait()
 
-	out := graphql.NewFieldSet(fields)
-	var invalids uint32
-	for i, field := range fields {
-		switch field.Name {
-		case "__typename":
-			out.Values[i] = graphql.MarshalString("__Schema")
-		case "types":
-			out.Values[i] = ec.___Schema_types(ctx, field, obj)
-			if out.Values[i] == graphql.Null {
-				invalids++
-			}
-		case "queryType":
-			out.Values[i] = ec.___Schema_queryType(ctx, field, obj)
-			if out.Values[i] == graphql.Null {
-				invalids++
-			}
-		case "mutationType":
-			out.Values[i] = ec.___Schema_mutationType(ctx, field, obj)
-		case "subscriptionType":
-			out.Values[i] = ec.___Schema_subscriptionType(ctx, field, obj)
-		case "directives":
-			out.Values[i] = ec.___Schema_directives(ctx, field, obj)
-			if out.Values[i] == graphql.Null {
-				invalids++
-			}
-		default:
-			panic("unknown field " + strconv.Quote(field.Name))
+	for _, e := range ret {
+		if e == graphql.Null {
+			return graphql.Null
 		}
 	}
-	out.Dispatch()
-	if invalids > 0 {
+
+	return ret
+}
+
+func (ec *executionContext) marshalNFranchise2ᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐFranchise(ctx context.Context, sel ast.SelectionSet, v *model.Franchise) graphql.Marshaler {
+	if v == nil {
+		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	return out
+	return ec._Franchise(ctx, sel, v)
 }
 
-var __TypeImplementors = []string{"__Type"}
+func (ec *executionContext) unmarshalNID2string(ctx context.Context, v interface{}) (string, error) {
+	res, err := graphql.UnmarshalID(v)
+	return res, graphql.ErrorOnPath(ctx, err)
+}
 
-func (ec *executionContext) ___Type(ctx context.Context, sel ast.SelectionSet, obj *introspection.Type) graphql.Marshaler {
-	fields := graphql.CollectFields(ec.OperationContext, sel, __TypeImplementors)
+func (ec *executionContext) marshalNID2string(ctx context.Context, sel ast.SelectionSet, v string) graphql.Marshaler {
+	res := graphql.MarshalID(v)
+	if res == graphql.Null {
+		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
+			ec.Errorf(ctx, "must not be null")
+		}
+	}
+	return res
+}
 
-	out := graphql.NewFieldSet(fields)
-	var invalids uint32
-	for i, field := range fields {
-		switch field.Name {
-		case "__typename":
-			out.Values[i] = graphql.MarshalString("__Type")
-		case "kind":
-			out.Values[i] = ec.___Type_kind(ctx, field, obj)
-			if out.Values[i] == graphql.Null {
-				invalids++
-			}
-		case "name":
-			out.Values[i] = ec.___Type_name(ctx, field, obj)
-		case "description":
-			out.Values[i] = ec.___Type_description(ctx, field, obj)
-		case "fields":
-			out.Values[i] = ec.___Type_fields(ctx, field, obj)
-		case "interfaces":
-			out.Values[i] = ec.___Type_interfaces(ctx, field, obj)
-		case "possibleTypes":
-			out.Values[i] = ec.___Type_possibleTypes(ctx, field, obj)
-		case "enumValues":
-			out.Values[i] = ec.___Type_enumValues(ctx, field, obj)
-		case "inputFields":
-			out.Values[i] = ec.___Type_inputFields(ctx, field, obj)
-		case "ofType":
-			out.Values[i] = ec.___Type_ofType(ctx, field, obj)
-		default:
-			panic("unknown field " + strconv.Quote(field.Name))
+func (ec *executionContext) unmarshalNID2ᚕstringᚄ(ctx context.Context, v interface{}) ([]string, error) {
+	var vSlice []interface{}
+	if v != nil {
+		if tmp1, ok := v.([]interface{}); ok {
+			vSlice = tmp1
+		} else {
+			vSlice = []interface{}{v}
 		}
 	}
-	out.Dispatch()
-	if invalids > 0 {
-		return graphql.Null
+	var err error
+	res := make([]string, len(vSlice))
+	for i := range vSlice {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithIndex(i))
+		res[i], err = ec.unmarshalNID2string(ctx, vSlice[i])
+		if err != nil {
+			return nil, err
+		}
 	}
-	return out
+	return res, nil
 }
 
-// endregion **************************** object.gotpl ****************************
+func (ec *executionContext) marshalNID2ᚕstringᚄ(ctx context.Context, sel ast.SelectionSet, v []string) graphql.Marshaler {
+	ret := make(graphql.Array, len(v))
+	for i := range v {
+		ret[i] = ec.marshalNID2string(ctx, sel, v[i])
+	}
 
-// region    ***************************** type.gotpl *****************************
+	for _, e := range ret {
+		if e == graphql.Null {
+			return graphql.Null
+		}
+	}
 
-func (ec *executionContext) unmarshalNBoolean2bool(ctx context.Context, v interface{}) (bool, error) {
-	res, err := graphql.UnmarshalBoolean(v)
+	return ret
+}
+
+func (ec *executionContext) unmarshalNInt2int(ctx context.Context, v interface{}) (int, error) {
+	res, err := graphql.UnmarshalInt(v)
 	return res, graphql.ErrorOnPath(ctx, err)
 }
 
-func (ec *executionContext) marshalNBoolean2bool(ctx context.Context, sel ast.SelectionSet, v bool) graphql.Marshaler {
-	res := graphql.MarshalBoolean(v)
+func (ec *executionContext) marshalNInt2int(ctx context.Context, sel ast.SelectionSet, v int) graphql.Marshaler {
+	res := graphql.MarshalInt(v)
 	if res == graphql.Null {
 		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
 			ec.Errorf(ctx, "must not be null")
 		}
 	}
-	return res
+	return res
+}
+
+func (ec *executionContext) marshalNNode2ᚕgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐNode(ctx context.Context, sel ast.SelectionSet, v []model.Node) graphql.Marshaler {
+	ret := make(graphql.Array, len(v))
+	var wg sync.WaitGroup
+	isLen1 := len(v) == 1
+	if !isLen1 {
+		wg.Add(len(v))
+	}
+	for i := range v {
+		i := i
+		fc := &graphql.FieldContext{
+			Index:  &i,
+			Result: &v[i],
+		}
+		ctx := graphql.WithFieldContext(ctx, fc)
+		f := func(i int) {
+			defer func() {
+				if r := recover(); r != nil {
+					ec.Error(ctx, ec.Recover(ctx, r))
+					ret = nil
+				}
+			}()
+			if !isLen1 {
+				defer wg.Done()
+			}
+			ret[i] = ec.marshalONode2githubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐNode(ctx, sel, v[i])
+		}
+		if isLen1 {
+			f(i)
+		} else {
+			go f(i)
+		}
+
+	}
+	wg.Wait()
+
+	return ret
 }
 
-func (ec *executionContext) marshalNContributor2ᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐContributor(ctx context.Context, sel ast.SelectionSet, v *model.Contributor) graphql.Marshaler {
-	if v == nil {
-		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
-			ec.Errorf(ctx, "must not be null")
-		}
-		return graphql.Null
-	}
-	return ec._Contributor(ctx, sel, v)
+func (ec *executionContext) marshalNProfile2githubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐProfile(ctx context.Context, sel ast.SelectionSet, v model.Profile) graphql.Marshaler {
+	return ec._Profile(ctx, sel, &v)
 }
 
-func (ec *executionContext) marshalNEpisode2ᚕᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐEpisodeᚄ(ctx context.Context, sel ast.SelectionSet, v []*model.Episode) graphql.Marshaler {
+func (ec *executionContext) marshalNProfile2ᚕᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐProfileᚄ(ctx context.Context, sel ast.SelectionSet, v []*model.Profile) graphql.Marshaler {
 	ret := make(graphql.Array, len(v))
 	var wg sync.WaitGroup
 	isLen1 := len(v) == 1
@@ -5155,7 +10240,7 @@ func (ec *executionContext) marshalNEpisode2ᚕᚖgithubᚗcomᚋidiomaticᚋtvq
 			if !isLen1 {
 				defer wg.Done()
 			}
-			ret[i] = ec.marshalNEpisode2ᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐEpisode(ctx, sel, v[i])
+			ret[i] = ec.marshalNProfile2ᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐProfile(ctx, sel, v[i])
 		}
 		if isLen1 {
 			f(i)
@@ -5175,44 +10260,14 @@ func (ec *executionContext) marshalNEpisode2ᚕᚖgithubᚗcomᚋidiomaticᚋtvq
 	return ret
 }
 
-func (ec *executionContext) marshalNEpisode2ᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐEpisode(ctx context.Context, sel ast.SelectionSet, v *model.Episode) graphql.Marshaler {
+func (ec *executionContext) marshalNProfile2ᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐProfile(ctx context.Context, sel ast.SelectionSet, v *model.Profile) graphql.Marshaler {
 	if v == nil {
 		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
 			ec.Errorf(ctx, "must not be null")
 		}
 		return graphql.Null
 	}
-	return ec._Episode(ctx, sel, v)
-}
-
-func (ec *executionContext) unmarshalNID2string(ctx context.Context, v interface{}) (string, error) {
-	res, err := graphql.UnmarshalID(v)
-	return res, graphql.ErrorOnPath(ctx, err)
-}
-
-func (ec *executionContext) marshalNID2string(ctx context.Context, sel ast.SelectionSet, v string) graphql.Marshaler {
-	res := graphql.MarshalID(v)
-	if res == graphql.Null {
-		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
-			ec.Errorf(ctx, "must not be null")
-		}
-	}
-	return res
-}
-
-func (ec *executionContext) unmarshalNInt2int(ctx context.Context, v interface{}) (int, error) {
-	res, err := graphql.UnmarshalInt(v)
-	return res, graphql.ErrorOnPath(ctx, err)
-}
-
-func (ec *executionContext) marshalNInt2int(ctx context.Context, sel ast.SelectionSet, v int) graphql.Marshaler {
-	res := graphql.MarshalInt(v)
-	if res == graphql.Null {
-		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
-			ec.Errorf(ctx, "must not be null")
-		}
-	}
-	return res
+	return ec._Profile(ctx, sel, v)
 }
 
 func (ec *executionContext) marshalNQuality2ᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐQuality(ctx context.Context, sel ast.SelectionSet, v *model.Quality) graphql.Marshaler {
@@ -5250,6 +10305,20 @@ func (ec *executionContext) marshalNResolution2string(ctx context.Context, sel a
 	return res
 }
 
+func (ec *executionContext) marshalNScanProgress2githubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐScanProgress(ctx context.Context, sel ast.SelectionSet, v model.ScanProgress) graphql.Marshaler {
+	return ec._ScanProgress(ctx, sel, &v)
+}
+
+func (ec *executionContext) marshalNScanProgress2ᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐScanProgress(ctx context.Context, sel ast.SelectionSet, v *model.ScanProgress) graphql.Marshaler {
+	if v == nil {
+		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	return ec._ScanProgress(ctx, sel, v)
+}
+
 func (ec *executionContext) marshalNSeason2ᚕᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐSeasonᚄ(ctx context.Context, sel ast.SelectionSet, v []*model.Season) graphql.Marshaler {
 	ret := make(graphql.Array, len(v))
 	var wg sync.WaitGroup
@@ -5373,6 +10442,21 @@ func (ec *executionContext) marshalNString2string(ctx context.Context, sel ast.S
 	return res
 }
 
+func (ec *executionContext) unmarshalNTime2timeᚐTime(ctx context.Context, v interface{}) (time.Time, error) {
+	res, err := graphql.UnmarshalTime(v)
+	return res, graphql.ErrorOnPath(ctx, err)
+}
+
+func (ec *executionContext) marshalNTime2timeᚐTime(ctx context.Context, sel ast.SelectionSet, v time.Time) graphql.Marshaler {
+	res := graphql.MarshalTime(v)
+	if res == graphql.Null {
+		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
+			ec.Errorf(ctx, "must not be null")
+		}
+	}
+	return res
+}
+
 func (ec *executionContext) marshalNVideo2githubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐVideo(ctx context.Context, sel ast.SelectionSet, v model.Video) graphql.Marshaler {
 	return ec._Video(ctx, sel, &v)
 }
@@ -5441,6 +10525,11 @@ func (ec *executionContext) marshalNVideoCodec2githubᚗcomᚋidiomaticᚋtvql
 	return v
 }
 
+func (ec *executionContext) unmarshalNVideoFilter2githubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐVideoFilter(ctx context.Context, v interface{}) (model.VideoFilter, error) {
+	res, err := ec.unmarshalInputVideoFilter(ctx, v)
+	return res, graphql.ErrorOnPath(ctx, err)
+}
+
 func (ec *executionContext) marshalN__Directive2githubᚗcomᚋ99designsᚋgqlgenᚋgraphqlᚋintrospectionᚐDirective(ctx context.Context, sel ast.SelectionSet, v introspection.Directive) graphql.Marshaler {
 	return ec.___Directive(ctx, sel, &v)
 }
@@ -5705,6 +10794,54 @@ func (ec *executionContext) marshalOArtwork2ᚖgithubᚗcomᚋidiomaticᚋtvql
 	return ec._Artwork(ctx, sel, v)
 }
 
+func (ec *executionContext) unmarshalOArtworkAnchor2ᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐArtworkAnchor(ctx context.Context, v interface{}) (*model.ArtworkAnchor, error) {
+	if v == nil {
+		return nil, nil
+	}
+	var res = new(model.ArtworkAnchor)
+	err := res.UnmarshalGQL(v)
+	return res, graphql.ErrorOnPath(ctx, err)
+}
+
+func (ec *executionContext) marshalOArtworkAnchor2ᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐArtworkAnchor(ctx context.Context, sel ast.SelectionSet, v *model.ArtworkAnchor) graphql.Marshaler {
+	if v == nil {
+		return graphql.Null
+	}
+	return v
+}
+
+func (ec *executionContext) unmarshalOArtworkFit2ᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐArtworkFit(ctx context.Context, v interface{}) (*model.ArtworkFit, error) {
+	if v == nil {
+		return nil, nil
+	}
+	var res = new(model.ArtworkFit)
+	err := res.UnmarshalGQL(v)
+	return res, graphql.ErrorOnPath(ctx, err)
+}
+
+func (ec *executionContext) marshalOArtworkFit2ᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐArtworkFit(ctx context.Context, sel ast.SelectionSet, v *model.ArtworkFit) graphql.Marshaler {
+	if v == nil {
+		return graphql.Null
+	}
+	return v
+}
+
+func (ec *executionContext) unmarshalOArtworkFormat2ᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐArtworkFormat(ctx context.Context, v interface{}) (*model.ArtworkFormat, error) {
+	if v == nil {
+		return nil, nil
+	}
+	var res = new(model.ArtworkFormat)
+	err := res.UnmarshalGQL(v)
+	return res, graphql.ErrorOnPath(ctx, err)
+}
+
+func (ec *executionContext) marshalOArtworkFormat2ᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐArtworkFormat(ctx context.Context, sel ast.SelectionSet, v *model.ArtworkFormat) graphql.Marshaler {
+	if v == nil {
+		return graphql.Null
+	}
+	return v
+}
+
 func (ec *executionContext) unmarshalOBoolean2bool(ctx context.Context, v interface{}) (bool, error) {
 	res, err := graphql.UnmarshalBoolean(v)
 	return res, graphql.ErrorOnPath(ctx, err)
@@ -5729,6 +10866,13 @@ func (ec *executionContext) marshalOBoolean2ᚖbool(ctx context.Context, sel ast
 	return graphql.MarshalBoolean(*v)
 }
 
+func (ec *executionContext) marshalOCollection2ᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐCollection(ctx context.Context, sel ast.SelectionSet, v *model.Collection) graphql.Marshaler {
+	if v == nil {
+		return graphql.Null
+	}
+	return ec._Collection(ctx, sel, v)
+}
+
 func (ec *executionContext) marshalOContributor2ᚕᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐContributorᚄ(ctx context.Context, sel ast.SelectionSet, v []*model.Contributor) graphql.Marshaler {
 	if v == nil {
 		return graphql.Null
@@ -5791,6 +10935,44 @@ func (ec *executionContext) marshalOEpisode2ᚖgithubᚗcomᚋidiomaticᚋtvql
 	return ec._Episode(ctx, sel, v)
 }
 
+func (ec *executionContext) unmarshalOFloat2ᚖfloat64(ctx context.Context, v interface{}) (*float64, error) {
+	if v == nil {
+		return nil, nil
+	}
+	res, err := graphql.UnmarshalFloat(v)
+	return &res, graphql.ErrorOnPath(ctx, err)
+}
+
+func (ec *executionContext) marshalOFloat2ᚖfloat64(ctx context.Context, sel ast.SelectionSet, v *float64) graphql.Marshaler {
+	if v == nil {
+		return graphql.Null
+	}
+	return graphql.MarshalFloat(*v)
+}
+
+func (ec *executionContext) marshalOFranchise2ᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐFranchise(ctx context.Context, sel ast.SelectionSet, v *model.Franchise) graphql.Marshaler {
+	if v == nil {
+		return graphql.Null
+	}
+	return ec._Franchise(ctx, sel, v)
+}
+
+func (ec *executionContext) unmarshalOFranchiseOrder2ᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐFranchiseOrder(ctx context.Context, v interface{}) (*model.FranchiseOrder, error) {
+	if v == nil {
+		return nil, nil
+	}
+	var res = new(model.FranchiseOrder)
+	err := res.UnmarshalGQL(v)
+	return res, graphql.ErrorOnPath(ctx, err)
+}
+
+func (ec *executionContext) marshalOFranchiseOrder2ᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐFranchiseOrder(ctx context.Context, sel ast.SelectionSet, v *model.FranchiseOrder) graphql.Marshaler {
+	if v == nil {
+		return graphql.Null
+	}
+	return v
+}
+
 func (ec *executionContext) unmarshalOGeometryFilter2ᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐGeometryFilter(ctx context.Context, v interface{}) (*model.GeometryFilter, error) {
 	if v == nil {
 		return nil, nil
@@ -5799,6 +10981,48 @@ func (ec *executionContext) unmarshalOGeometryFilter2ᚖgithubᚗcomᚋidiomatic
 	return &res, graphql.ErrorOnPath(ctx, err)
 }
 
+func (ec *executionContext) unmarshalOID2ᚕstringᚄ(ctx context.Context, v interface{}) ([]string, error) {
+	if v == nil {
+		return nil, nil
+	}
+	var vSlice []interface{}
+	if v != nil {
+		if tmp1, ok := v.([]interface{}); ok {
+			vSlice = tmp1
+		} else {
+			vSlice = []interface{}{v}
+		}
+	}
+	var err error
+	res := make([]string, len(vSlice))
+	for i := range vSlice {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithIndex(i))
+		res[i], err = ec.unmarshalNID2string(ctx, vSlice[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+func (ec *executionContext) marshalOID2ᚕstringᚄ(ctx context.Context, sel ast.SelectionSet, v []string) graphql.Marshaler {
+	if v == nil {
+		return graphql.Null
+	}
+	ret := make(graphql.Array, len(v))
+	for i := range v {
+		ret[i] = ec.marshalNID2string(ctx, sel, v[i])
+	}
+
+	for _, e := range ret {
+		if e == graphql.Null {
+			return graphql.Null
+		}
+	}
+
+	return ret
+}
+
 func (ec *executionContext) unmarshalOID2ᚖstring(ctx context.Context, v interface{}) (*string, error) {
 	if v == nil {
 		return nil, nil
@@ -5829,6 +11053,13 @@ func (ec *executionContext) marshalOInt2ᚖint(ctx context.Context, sel ast.Sele
 	return graphql.MarshalInt(*v)
 }
 
+func (ec *executionContext) marshalONode2githubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐNode(ctx context.Context, sel ast.SelectionSet, v model.Node) graphql.Marshaler {
+	if v == nil {
+		return graphql.Null
+	}
+	return ec._Node(ctx, sel, v)
+}
+
 func (ec *executionContext) unmarshalOPaginate2ᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐPaginate(ctx context.Context, v interface{}) (*model.Paginate, error) {
 	if v == nil {
 		return nil, nil
@@ -5837,6 +11068,20 @@ func (ec *executionContext) unmarshalOPaginate2ᚖgithubᚗcomᚋidiomaticᚋtvq
 	return &res, graphql.ErrorOnPath(ctx, err)
 }
 
+func (ec *executionContext) marshalOProfile2ᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐProfile(ctx context.Context, sel ast.SelectionSet, v *model.Profile) graphql.Marshaler {
+	if v == nil {
+		return graphql.Null
+	}
+	return ec._Profile(ctx, sel, v)
+}
+
+func (ec *executionContext) marshalOProgress2ᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐProgress(ctx context.Context, sel ast.SelectionSet, v *model.Progress) graphql.Marshaler {
+	if v == nil {
+		return graphql.Null
+	}
+	return ec._Progress(ctx, sel, v)
+}
+
 func (ec *executionContext) unmarshalOQualityFilter2ᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐQualityFilter(ctx context.Context, v interface{}) (*model.QualityFilter, error) {
 	if v == nil {
 		return nil, nil
@@ -5921,6 +11166,13 @@ func (ec *executionContext) marshalOResolution2ᚖstring(ctx context.Context, se
 	return graphql.MarshalString(*v)
 }
 
+func (ec *executionContext) marshalOSeason2ᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐSeason(ctx context.Context, sel ast.SelectionSet, v *model.Season) graphql.Marshaler {
+	if v == nil {
+		return graphql.Null
+	}
+	return ec._Season(ctx, sel, v)
+}
+
 func (ec *executionContext) unmarshalOSeasonFilter2ᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐSeasonFilter(ctx context.Context, v interface{}) (*model.SeasonFilter, error) {
 	if v == nil {
 		return nil, nil
@@ -5929,6 +11181,13 @@ func (ec *executionContext) unmarshalOSeasonFilter2ᚖgithubᚗcomᚋidiomatic
 	return &res, graphql.ErrorOnPath(ctx, err)
 }
 
+func (ec *executionContext) marshalOSeries2ᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐSeries(ctx context.Context, sel ast.SelectionSet, v *model.Series) graphql.Marshaler {
+	if v == nil {
+		return graphql.Null
+	}
+	return ec._Series(ctx, sel, v)
+}
+
 func (ec *executionContext) unmarshalOSeriesFilter2ᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐSeriesFilter(ctx context.Context, v interface{}) (*model.SeriesFilter, error) {
 	if v == nil {
 		return nil, nil
@@ -5946,6 +11205,48 @@ func (ec *executionContext) marshalOString2string(ctx context.Context, sel ast.S
 	return graphql.MarshalString(v)
 }
 
+func (ec *executionContext) unmarshalOString2ᚕstringᚄ(ctx context.Context, v interface{}) ([]string, error) {
+	if v == nil {
+		return nil, nil
+	}
+	var vSlice []interface{}
+	if v != nil {
+		if tmp1, ok := v.([]interface{}); ok {
+			vSlice = tmp1
+		} else {
+			vSlice = []interface{}{v}
+		}
+	}
+	var err error
+	res := make([]string, len(vSlice))
+	for i := range vSlice {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithIndex(i))
+		res[i], err = ec.unmarshalNString2string(ctx, vSlice[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+func (ec *executionContext) marshalOString2ᚕstringᚄ(ctx context.Context, sel ast.SelectionSet, v []string) graphql.Marshaler {
+	if v == nil {
+		return graphql.Null
+	}
+	ret := make(graphql.Array, len(v))
+	for i := range v {
+		ret[i] = ec.marshalNString2string(ctx, sel, v[i])
+	}
+
+	for _, e := range ret {
+		if e == graphql.Null {
+			return graphql.Null
+		}
+	}
+
+	return ret
+}
+
 func (ec *executionContext) unmarshalOString2ᚖstring(ctx context.Context, v interface{}) (*string, error) {
 	if v == nil {
 		return nil, nil
@@ -5993,6 +11294,14 @@ func (ec *executionContext) marshalOVideoCodec2ᚖgithubᚗcomᚋidiomaticᚋtvq
 	return v
 }
 
+func (ec *executionContext) unmarshalOVideoFilter2ᚖgithubᚗcomᚋidiomaticᚋtvqlᚋgraphᚋmodelᚐVideoFilter(ctx context.Context, v interface{}) (*model.VideoFilter, error) {
+	if v == nil {
+		return nil, nil
+	}
+	res, err := ec.unmarshalInputVideoFilter(ctx, v)
+	return &res, graphql.ErrorOnPath(ctx, err)
+}
+
 func (ec *executionContext) marshalO__EnumValue2ᚕgithubᚗcomᚋ99designsᚋgqlgenᚋgraphqlᚋintrospectionᚐEnumValueᚄ(ctx context.Context, sel ast.SelectionSet, v []introspection.EnumValue) graphql.Marshaler {
 	if v == nil {
 		return graphql.Null
@@ -0,0 +1,133 @@
+package graph_test
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/99designs/gqlgen/client"
+	"github.com/99designs/gqlgen/graphql/handler"
+	"github.com/99designs/gqlgen/graphql/handler/extension"
+	"github.com/99designs/gqlgen/graphql/handler/transport"
+	"github.com/idiomatic/tvql/artcache"
+	"github.com/idiomatic/tvql/auth"
+	"github.com/idiomatic/tvql/graph"
+	"github.com/idiomatic/tvql/graph/generated"
+	"github.com/idiomatic/tvql/graph/loaders"
+	"github.com/idiomatic/tvql/graph/model"
+	"github.com/idiomatic/tvql/logging"
+	"github.com/idiomatic/tvql/metadata/mp4/mp4test"
+	"github.com/idiomatic/tvql/signing"
+)
+
+// fixtures is a small library: rated movies, and a series.
+var fixtures = map[string]mp4test.Spec{
+	"Movies/HQ 1080p30/Heat.m4v":        {Title: "Heat", Day: "1995", Rating: "R"},
+	"Movies/Fast 480p30/Heat.m4v":       {Title: "Heat", Day: "1995", Rating: "R"},
+	"Movies/HQ 1080p30/Up.m4v":          {Title: "Up", Day: "2009", Rating: "PG", Cover: "jpeg"},
+	"Shows/HQ 1080p30/The Show 101.m4v": {Title: "Pilot", Day: "2001", Show: "The Show", Season: 1, Episode: 1, Rating: "TV-PG"},
+	"Shows/HQ 1080p30/The Show 102.m4v": {Title: "Second", Day: "2001", Show: "The Show", Season: 1, Episode: 2, Rating: "TV-MA"},
+	"Shows/HQ 1080p30/The Show 103.m4v": {Title: "Third", Day: "2001", Show: "The Show", Season: 1, Episode: 3, Rating: "TV-PG"},
+	"Shows/HQ 1080p30/Grownups 101.m4v": {Title: "Adults Only", Day: "2005", Show: "Grownups", Season: 1, Episode: 1, Rating: "TV-MA"},
+	"Shows/HQ 1080p30/Grownups 201.m4v": {Title: "Still Adults", Day: "2006", Show: "Grownups", Season: 2, Episode: 1, Rating: "TV-MA"},
+}
+
+func TestMain(m *testing.M) {
+	logging.SetDefault(logging.New(ioutil.Discard, logging.LevelError, logging.FormatLogfmt))
+	os.Exit(m.Run())
+}
+
+func writeFixtures(t *testing.T) string {
+	t.Helper()
+	root := t.TempDir()
+	for path, spec := range fixtures {
+		spec.Samples = 60
+		if err := mp4test.WriteFile(filepath.Join(root, path), spec); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return root
+}
+
+type testServer struct {
+	root        string
+	library     *model.Library
+	profiles    *model.Profiles
+	collections *model.Collections
+	signer      *signing.Signer
+	srv         *handler.Server
+}
+
+// newTestServer surveys a fixture library and serves it much as
+// server.go does.
+func newTestServer(t *testing.T) *testServer {
+	t.Helper()
+	s := &testServer{root: writeFixtures(t), library: model.NewLibrary()}
+	if err := s.library.Survey(context.Background(), s.root); err != nil {
+		t.Fatal(err)
+	}
+
+	var err error
+	dir := t.TempDir()
+	if s.profiles, err = model.OpenProfiles(filepath.Join(dir, "profiles.json")); err != nil {
+		t.Fatal(err)
+	}
+	if s.collections, err = model.OpenCollections(filepath.Join(dir, "collections.json")); err != nil {
+		t.Fatal(err)
+	}
+	if s.signer, err = signing.New([][]byte{[]byte("test")}, time.Hour, false); err != nil {
+		t.Fatal(err)
+	}
+	artworks, err := artcache.New(s.library, 16, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resolver := graph.NewResolver(s.library, s.profiles, s.collections,
+		&url.URL{Path: "video/"}, &url.URL{Path: "artwork/"}, &url.URL{Path: "stream/"}, s.signer, artworks)
+	config := generated.Config{Resolvers: resolver}
+	config.Directives.Admin = graph.Admin
+	graph.Complexity(&config.Complexity)
+
+	s.srv = handler.New(generated.NewExecutableSchema(config))
+	s.srv.AddTransport(transport.GET{})
+	s.srv.AddTransport(transport.POST{})
+	s.srv.AroundOperations(loaders.Around(s.library))
+	s.srv.Use(extension.FixedComplexityLimit(5000))
+	s.srv.Use(graph.DepthLimit{Depth: 12})
+	s.srv.SetErrorPresenter(graph.ErrorPresenter)
+	return s
+}
+
+// handler serves GraphQL to a principal.
+func (s *testServer) handler(principal *auth.Principal) http.Handler {
+	h := signing.WithClient(graph.WithProfileHeader(s.srv))
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h.ServeHTTP(w, r.WithContext(auth.WithPrincipal(r.Context(), principal)))
+	})
+}
+
+func (s *testServer) client(principal *auth.Principal) *client.Client {
+	return client.New(s.handler(principal))
+}
+
+var admin = &auth.Principal{Name: "admin", Admin: true}
+
+// restricted returns a principal bound to a new profile, restricted to
+// maxContentRating.
+func (s *testServer) restricted(t *testing.T, name string, maxContentRating string) *auth.Principal {
+	t.Helper()
+	profile, err := s.profiles.Create(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.profiles.Restrict(profile.ID, &maxContentRating, nil); err != nil {
+		t.Fatal(err)
+	}
+	return &auth.Principal{Name: name, Profile: profile.ID}
+}
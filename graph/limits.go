@@ -0,0 +1,187 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/99designs/gqlgen/graphql/errcode"
+	"github.com/idiomatic/tvql/graph/generated"
+	"github.com/idiomatic/tvql/graph/model"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+const (
+	errDepthLimit = "DEPTH_LIMIT_EXCEEDED"
+	errTimeout    = "TIMEOUT"
+)
+
+const (
+	// assumed fan-out of unpaginated lists
+	listCost = 20
+	// decode, resize, and re-encode
+	base64Cost = 250
+)
+
+// Complexity assigns per-field costs for use with
+// extension.ComplexityLimit.
+func Complexity(c *generated.ComplexityRoot) {
+	c.Artwork.Base64 = func(childComplexity int, geometry *model.GeometryFilter) int {
+		return base64Cost + childComplexity
+	}
+
+	c.Query.Videos = func(childComplexity int, paginate *model.Paginate, title *string, contributor *model.ContributorFilter) int {
+		return paginatedCost(paginate, childComplexity)
+	}
+	c.Query.Series = func(childComplexity int, paginate *model.Paginate) int {
+		return paginatedCost(paginate, childComplexity)
+	}
+	c.Query.Seasons = func(childComplexity int, series *model.SeriesFilter) int {
+		return listCost * (1 + childComplexity)
+	}
+	c.Query.Episodes = func(childComplexity int, series *model.SeriesFilter, season *model.SeasonFilter) int {
+		return listCost * (1 + childComplexity)
+	}
+	c.Query.ContinueWatching = func(childComplexity int, first *int) int {
+		return paginatedCost(&model.Paginate{First: first}, childComplexity)
+	}
+	c.Query.Nodes = func(childComplexity int, ids []string) int {
+		return len(ids) * (1 + childComplexity)
+	}
+
+	c.Series.Seasons = func(childComplexity int) int {
+		return listCost * (1 + childComplexity)
+	}
+	c.Series.Episodes = func(childComplexity int) int {
+		return listCost * (1 + childComplexity)
+	}
+	c.Season.Episodes = func(childComplexity int) int {
+		return listCost * (1 + childComplexity)
+	}
+	c.Collection.Members = func(childComplexity int) int {
+		return listCost * (1 + childComplexity)
+	}
+	c.Query.Franchises = func(childComplexity int) int {
+		return listCost * (1 + childComplexity)
+	}
+	c.Franchise.Videos = func(childComplexity int, order *model.FranchiseOrder) int {
+		return listCost * (1 + childComplexity)
+	}
+	c.Renditions.All = func(childComplexity int) int {
+		return 4 * (1 + childComplexity)
+	}
+}
+
+func paginatedCost(paginate *model.Paginate, childComplexity int) int {
+	count := listCost
+	if paginate != nil && paginate.First != nil && *paginate.First < count {
+		count = *paginate.First
+	}
+	return count * (1 + childComplexity)
+}
+
+// DepthLimit rejects operations nested deeper than Depth fields.
+// Introspection fields are not counted.
+type DepthLimit struct {
+	Depth int
+}
+
+var _ interface {
+	graphql.HandlerExtension
+	graphql.OperationContextMutator
+} = DepthLimit{}
+
+func (DepthLimit) ExtensionName() string {
+	return "DepthLimit"
+}
+
+func (DepthLimit) Validate(schema graphql.ExecutableSchema) error {
+	return nil
+}
+
+func (d DepthLimit) MutateOperationContext(ctx context.Context, rc *graphql.OperationContext) *gqlerror.Error {
+	if depth := selectionDepth(rc.Operation.SelectionSet); depth > d.Depth {
+		err := gqlerror.Errorf("operation has depth %d, which exceeds the limit of %d", depth, d.Depth)
+		errcode.Set(err, errDepthLimit)
+		return err
+	}
+	return nil
+}
+
+func selectionDepth(selections ast.SelectionSet) int {
+	max := 0
+	for _, selection := range selections {
+		var depth int
+		switch selection := selection.(type) {
+		case *ast.Field:
+			if strings.HasPrefix(selection.Name, "__") {
+				continue
+			}
+			depth = 1 + selectionDepth(selection.SelectionSet)
+		case *ast.InlineFragment:
+			depth = selectionDepth(selection.SelectionSet)
+		case *ast.FragmentSpread:
+			depth = selectionDepth(selection.Definition.SelectionSet)
+		}
+		if depth > max {
+			max = depth
+		}
+	}
+	return max
+}
+
+// Timeout gives each query or mutation a deadline, after which
+// remaining fields resolve to errors.  Subscriptions are exempt.
+type Timeout struct {
+	Duration time.Duration
+}
+
+var _ interface {
+	graphql.HandlerExtension
+	graphql.ResponseInterceptor
+	graphql.FieldInterceptor
+} = Timeout{}
+
+func (Timeout) ExtensionName() string {
+	return "Timeout"
+}
+
+func (Timeout) Validate(schema graphql.ExecutableSchema) error {
+	return nil
+}
+
+func (t Timeout) InterceptResponse(ctx context.Context, next graphql.ResponseHandler) *graphql.Response {
+	rc := graphql.GetOperationContext(ctx)
+	if rc.Operation != nil && rc.Operation.Operation == ast.Subscription {
+		return next(ctx)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, t.Duration)
+	defer cancel()
+
+	return next(ctx)
+}
+
+func (Timeout) InterceptField(ctx context.Context, next graphql.Resolver) (interface{}, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return next(ctx)
+}
+
+// ErrorPresenter tags deadline and permission errors for clients.
+func ErrorPresenter(ctx context.Context, err error) *gqlerror.Error {
+	gqlErr := graphql.DefaultErrorPresenter(ctx, err)
+	if errors.Is(err, context.DeadlineExceeded) {
+		gqlErr.Message = fmt.Sprintf("operation timed out: %s", gqlErr.Message)
+		errcode.Set(gqlErr, errTimeout)
+	}
+	if errors.Is(err, ErrForbidden) {
+		errcode.Set(gqlErr, errForbidden)
+	}
+	return gqlErr
+}
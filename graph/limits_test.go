@@ -0,0 +1,59 @@
+package graph_test
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestComplexityLimit(t *testing.T) {
+	s := newTestServer(t)
+	c := s.client(admin)
+
+	for _, test := range []struct {
+		query    string
+		rejected bool
+	}{
+		// 19 videos × (1 + artwork (1 + base64 250))
+		{`{ videos(paginate: {first: 19}) { artwork { base64 } } }`, false},
+		// 20, i.e., listCost, when unpaginated
+		{`{ videos { artwork { base64 } } }`, true},
+		{`{ videos(paginate: {first: 100}) { artwork { base64 } } }`, true},
+		{`{ nodes(ids: ["a", "b", "c"]) { id } }`, false},
+	} {
+		err := post(c, test.query)
+		switch {
+		case test.rejected && (err == nil || !strings.Contains(err.Error(), "COMPLEXITY_LIMIT_EXCEEDED")):
+			t.Errorf("%s: %v, want rejected", test.query, err)
+		case !test.rejected && err != nil:
+			t.Errorf("%s: %v", test.query, err)
+		}
+	}
+}
+
+func TestDepthLimit(t *testing.T) {
+	s := newTestServer(t)
+	c := s.client(admin)
+
+	for _, test := range []struct {
+		query    string
+		rejected bool
+	}{
+		// 12 deep
+		{`{ node(id: "none") { ... on Episode { season { series { nextUp { season { series { nextUp { season { series { nextUp { season { id } } } } } } } } } } } } }`, false},
+		// introspection uncounted
+		{`{ node(id: "none") { ... on Episode { season { series { nextUp { season { series { nextUp { season { series { nextUp { season { __typename } } } } } } } } } } } } }`, false},
+		// 13 deep
+		{`{ node(id: "none") { ... on Episode { season { series { nextUp { season { series { nextUp { season { series { nextUp { season { series { id } } } } } } } } } } } } } }`, true},
+		// 13 deep, by fragment
+		{`{ node(id: "none") { ... deep } }
+		  fragment deep on Episode { season { series { nextUp { season { series { nextUp { season { series { nextUp { season { series { id } } } } } } } } } } } }`, true},
+	} {
+		err := post(c, test.query)
+		switch {
+		case test.rejected && (err == nil || !strings.Contains(err.Error(), "DEPTH_LIMIT_EXCEEDED")):
+			t.Errorf("%s: %v, want rejected", test.query, err)
+		case !test.rejected && err != nil:
+			t.Errorf("%s: %v", test.query, err)
+		}
+	}
+}
@@ -0,0 +1,71 @@
+// Package loaders batches and caches library lookups for the duration
+// of one GraphQL operation.
+package loaders
+
+//go:generate go run github.com/vektah/dataloaden EpisodeSliceLoader string []*github.com/idiomatic/tvql/graph/model.Episode
+//go:generate go run github.com/vektah/dataloaden SeasonSliceLoader string []*github.com/idiomatic/tvql/graph/model.Season
+//go:generate go run github.com/vektah/dataloaden ArtworkLoader string *github.com/idiomatic/tvql/graph/model.Artwork
+
+import (
+	"context"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/idiomatic/tvql/graph/model"
+)
+
+type contextKey struct{}
+
+// wait is how long keys accumulate before a batch is fetched.
+const wait = time.Millisecond
+
+type Loaders struct {
+	// keyed by series or season id
+	Episodes *EpisodeSliceLoader
+	// keyed by series id
+	Seasons *SeasonSliceLoader
+	// keyed by video id
+	Artwork *ArtworkLoader
+}
+
+func New(library *model.Library) *Loaders {
+	return &Loaders{
+		Episodes: NewEpisodeSliceLoader(EpisodeSliceLoaderConfig{
+			Fetch: func(ids []string) ([][]*model.Episode, []error) {
+				return library.EpisodesByParent(ids), nil
+			},
+			Wait: wait,
+		}),
+		Seasons: NewSeasonSliceLoader(SeasonSliceLoaderConfig{
+			Fetch: func(ids []string) ([][]*model.Season, []error) {
+				return library.SeasonsBySeries(ids), nil
+			},
+			Wait: wait,
+		}),
+		Artwork: NewArtworkLoader(ArtworkLoaderConfig{
+			Fetch: func(ids []string) ([]*model.Artwork, []error) {
+				artworks := make([]*model.Artwork, len(ids))
+				for i, hasArtwork := range library.HasArtwork(ids) {
+					if hasArtwork {
+						artworks[i] = &model.Artwork{ID: ids[i]}
+					}
+				}
+				return artworks, nil
+			},
+			Wait: wait,
+		}),
+	}
+}
+
+// Around attaches fresh loaders to each GraphQL operation.
+func Around(library *model.Library) graphql.OperationMiddleware {
+	return func(ctx context.Context, next graphql.OperationHandler) graphql.ResponseHandler {
+		return next(context.WithValue(ctx, contextKey{}, New(library)))
+	}
+}
+
+// For returns the operation's loaders, if any.
+func For(ctx context.Context) (*Loaders, bool) {
+	loaders, ok := ctx.Value(contextKey{}).(*Loaders)
+	return loaders, ok
+}
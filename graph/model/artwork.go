@@ -5,23 +5,77 @@ import (
 	"fmt"
 	"image"
 	"image/jpeg"
+	"image/png"
+	"io"
+	"net/http"
 	"os"
 
-	"github.com/disintegration/imaging"
 	"github.com/idiomatic/tvql/metadata/mp4"
 	"github.com/sunfish-shogi/bufseekio"
 )
 
 type Artwork struct {
-	ID MetavideoID
+	// video id
+	ID string
+}
+
+// artworkEncoder encodes an image at quality (1 to 100, or 0 for the
+// default), where lossy.
+type artworkEncoder func(w io.Writer, img image.Image, quality int) error
+
+// artworkEncoders are the available formats.  Others (e.g., WEBP) are
+// registered per build.
+var artworkEncoders = map[ArtworkFormat]artworkEncoder{
+	ArtworkFormatJpeg: func(w io.Writer, img image.Image, quality int) error {
+		if quality == 0 {
+			return jpeg.Encode(w, img, nil)
+		}
+		return jpeg.Encode(w, img, &jpeg.Options{Quality: quality})
+	},
+	ArtworkFormatPng: func(w io.Writer, img image.Image, quality int) error {
+		return png.Encode(w, img)
+	},
+}
+
+var artworkContentTypes = map[ArtworkFormat]string{
+	ArtworkFormatJpeg: "image/jpeg",
+	ArtworkFormatPng:  "image/png",
+	ArtworkFormatWebp: "image/webp",
+}
+
+// Available reports whether artwork can be encoded in this format.
+func (e ArtworkFormat) Available() bool {
+	_, ok := artworkEncoders[e]
+	return ok
+}
+
+// ContentType is the MIME type of this format.
+func (e ArtworkFormat) ContentType() string {
+	return artworkContentTypes[e]
+}
+
+// artworkFormat identifies the format of encoded artwork, if known.
+func artworkFormat(contentType string) (ArtworkFormat, bool) {
+	for format, candidate := range artworkContentTypes {
+		if candidate == contentType {
+			return format, true
+		}
+	}
+	return "", false
+}
+
+// ArtworkPath is the file holding a video's artwork, if any.
+func (l *Library) ArtworkPath(id string) (string, bool) {
+	metavideo, ok := l.Snapshot().metavideo(id)
+	if !ok || !metavideo.HasArtwork {
+		return "", false
+	}
+	return metavideo.Path, true
 }
 
 // XXX video id or rendition id?
 func (l *Library) GetArtwork(id string) ([]byte, error) {
-	l.Mutex.Lock()
-	defer l.Mutex.Unlock()
-
-	metavideo, ok := l.Metavideos[Stringer{id}]
+	metavideo, ok := l.Snapshot().metavideo(id)
 	if !ok {
 		return nil, fmt.Errorf("video not found")
 	}
@@ -46,51 +100,56 @@ func (l *Library) GetArtwork(id string) ([]byte, error) {
 	return artwork, nil
 }
 
-func ResizeArtwork(artwork []byte, geometry *GeometryFilter) ([]byte, error) {
+// ResizeArtwork downsamples artwork per geometry and encodes it per
+// geometry format and quality, else as the original.  Yields the
+// content type.
+func ResizeArtwork(artwork []byte, geometry *GeometryFilter) ([]byte, string, error) {
 	if geometry == nil {
-		return artwork, nil
+		geometry = &GeometryFilter{}
 	}
-	if geometry.Width == nil && geometry.Height == nil {
-		return artwork, nil
+	if err := geometry.Validate(); err != nil {
+		return nil, "", err
 	}
 
-	rawImage, _, err := image.Decode(bytes.NewBuffer(artwork))
-	if err != nil {
-		return nil, err
+	contentType := http.DetectContentType(artwork)
+	format, ok := artworkFormat(contentType)
+	if geometry.Format != nil {
+		format = *geometry.Format
+	} else if !ok {
+		// e.g., BMP
+		format = ArtworkFormatJpeg
 	}
 
-	var height, width int
-	size := rawImage.Bounds().Size()
+	var quality int
+	if geometry.Quality != nil {
+		quality = *geometry.Quality
+	}
 
-	if geometry.Width == nil && geometry.Height != nil {
-		height = *geometry.Height
-		if height > size.Y {
-			height = size.Y
-		}
-		width = height * size.X / size.Y
-	} else if geometry.Height == nil && geometry.Width != nil {
-		width = *geometry.Width
-		if width > size.X {
-			width = size.X
-		}
-		height = width * size.Y / size.X
-	} else {
-		width = *geometry.Width
-		height = *geometry.Height
-		if width > size.X {
-			width = size.X
-		}
-		if height > size.Y {
-			height = size.Y
-		}
+	if geometry.Width == nil && geometry.Height == nil && geometry.Quality == nil &&
+		(geometry.Format == nil || format.ContentType() == contentType) {
+		// passthrough, e.g., of PNG (or even BMP) originals
+		return artwork, contentType, nil
+	}
+
+	encode, ok := artworkEncoders[format]
+	if !ok {
+		return nil, "", fmt.Errorf("artwork format %s unavailable", format)
 	}
 
-	resizedImage := imaging.Resize(rawImage, width, height, imaging.Lanczos)
+	rawImage, _, err := image.Decode(bytes.NewBuffer(artwork))
+	if err != nil {
+		return nil, "", err
+	}
+
+	resizedImage, err := geometry.fit(rawImage)
+	if err != nil {
+		return nil, "", err
+	}
 
 	resizedArtwork := bytes.NewBuffer(nil)
-	if err := jpeg.Encode(resizedArtwork, resizedImage, nil); err != nil {
-		return nil, err
+	if err := encode(resizedArtwork, resizedImage, quality); err != nil {
+		return nil, "", err
 	}
 
-	return resizedArtwork.Bytes(), nil
+	return resizedArtwork.Bytes(), format.ContentType(), nil
 }
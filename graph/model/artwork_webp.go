@@ -0,0 +1,21 @@
+//go:build cgo
+// +build cgo
+
+package model
+
+import (
+	"image"
+	"io"
+
+	"github.com/chai2010/webp"
+)
+
+// WEBP encoding requires libwebp, i.e., cgo.
+func init() {
+	artworkEncoders[ArtworkFormatWebp] = func(w io.Writer, img image.Image, quality int) error {
+		if quality == 0 {
+			return webp.Encode(w, img, nil)
+		}
+		return webp.Encode(w, img, &webp.Options{Quality: float32(quality)})
+	}
+}
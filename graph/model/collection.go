@@ -0,0 +1,410 @@
+package model
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Collection is a curated list of videos, series, and episodes, or a
+// smart collection whose members match a video filter.
+type Collection struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	// owning profile id, unless shared
+	OwnerID string `json:"owner,omitempty"`
+	// ordered member node ids, unless smart
+	MemberIDs []string     `json:"members,omitempty"`
+	Filter    *VideoFilter `json:"filter,omitempty"`
+}
+
+func (Collection) IsNode() {}
+
+// Shared reports whether the collection belongs to no profile.
+func (c Collection) Shared() bool {
+	return c.OwnerID == ""
+}
+
+// Smart reports whether membership is defined by a video filter.
+func (c Collection) Smart() bool {
+	return c.Filter != nil
+}
+
+// Collections persists collections to a JSON file.
+//
+// Collections are replaced, never modified, so callers may retain
+// them without locks.
+type Collections struct {
+	path  string
+	mutex sync.RWMutex
+	state collectionsState
+	// incremented whenever state changes
+	version uint64
+}
+
+type collectionsState struct {
+	Collections map[string]*Collection `json:"collections"`
+}
+
+// OpenCollections loads collections from path, if it exists.
+func OpenCollections(path string) (*Collections, error) {
+	c := &Collections{
+		path: path,
+		state: collectionsState{
+			Collections: make(map[string]*Collection),
+		},
+	}
+
+	if err := readState(path, &c.state); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// Version changes whenever collections change.
+func (c *Collections) Version() uint64 {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return c.version
+}
+
+func (c *Collections) Get(id string) (*Collection, bool) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	collection, ok := c.state.Collections[id]
+	return collection, ok
+}
+
+// All returns collections ordered by name.
+func (c *Collections) All() []*Collection {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	var collections []*Collection
+	for _, collection := range c.state.Collections {
+		collections = append(collections, collection)
+	}
+	sort.Slice(collections, func(i, j int) bool { return collections[i].Name < collections[j].Name })
+	return collections
+}
+
+// Create adds a curated collection of members, or a smart collection
+// if filter is non-nil, owned by a profile (else shared).
+func (c *Collections) Create(name string, ownerID string, memberIDs []string, filter *VideoFilter) (*Collection, error) {
+	if filter != nil && len(memberIDs) != 0 {
+		return nil, fmt.Errorf("smart collections have no curated members")
+	}
+
+	collection := &Collection{
+		// names may change, so identity is creation time
+		ID:        NewGlobalID(NodeKindCollection, strconv.FormatInt(time.Now().UnixNano(), 10)),
+		Name:      name,
+		OwnerID:   ownerID,
+		MemberIDs: dedupe(memberIDs),
+		Filter:    filter,
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if _, ok := c.state.Collections[collection.ID]; ok {
+		return nil, fmt.Errorf("collection exists")
+	}
+
+	return collection, c.replace(collection.ID, collection)
+}
+
+// Delete removes a collection.
+func (c *Collections) Delete(id string) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if _, ok := c.state.Collections[id]; !ok {
+		return fmt.Errorf("collection not found")
+	}
+
+	return c.replace(id, nil)
+}
+
+// Rename changes a collection's name.
+func (c *Collections) Rename(id string, name string) (*Collection, error) {
+	return c.update(id, func(collection *Collection) error {
+		collection.Name = name
+		return nil
+	})
+}
+
+// SetFilter changes a smart collection's video filter.
+func (c *Collections) SetFilter(id string, filter *VideoFilter) (*Collection, error) {
+	return c.update(id, func(collection *Collection) error {
+		if !collection.Smart() {
+			return fmt.Errorf("collection is not smart")
+		}
+		if filter == nil {
+			return fmt.Errorf("smart collections require a filter")
+		}
+		collection.Filter = filter
+		return nil
+	})
+}
+
+// Add inserts members at position (else at the end), ignoring those
+// already present.
+func (c *Collections) Add(id string, memberIDs []string, position *int) (*Collection, error) {
+	return c.update(id, func(collection *Collection) error {
+		if collection.Smart() {
+			return fmt.Errorf("smart collections have no curated members")
+		}
+
+		present := make(map[string]bool)
+		for _, memberID := range collection.MemberIDs {
+			present[memberID] = true
+		}
+		var added []string
+		for _, memberID := range dedupe(memberIDs) {
+			if !present[memberID] {
+				added = append(added, memberID)
+			}
+		}
+
+		i := len(collection.MemberIDs)
+		if position != nil {
+			i = clamp(*position, 0, len(collection.MemberIDs))
+		}
+
+		members := make([]string, 0, len(collection.MemberIDs)+len(added))
+		members = append(members, collection.MemberIDs[:i]...)
+		members = append(members, added...)
+		members = append(members, collection.MemberIDs[i:]...)
+		collection.MemberIDs = members
+		return nil
+	})
+}
+
+// Remove drops members.
+func (c *Collections) Remove(id string, memberIDs []string) (*Collection, error) {
+	return c.update(id, func(collection *Collection) error {
+		if collection.Smart() {
+			return fmt.Errorf("smart collections have no curated members")
+		}
+
+		removed := make(map[string]bool)
+		for _, memberID := range memberIDs {
+			removed[memberID] = true
+		}
+
+		var members []string
+		for _, memberID := range collection.MemberIDs {
+			if !removed[memberID] {
+				members = append(members, memberID)
+			}
+		}
+		collection.MemberIDs = members
+		return nil
+	})
+}
+
+// Move repositions a member.
+func (c *Collections) Move(id string, memberID string, position int) (*Collection, error) {
+	return c.update(id, func(collection *Collection) error {
+		var members []string
+		for _, m := range collection.MemberIDs {
+			if m != memberID {
+				members = append(members, m)
+			}
+		}
+		if len(members) == len(collection.MemberIDs) {
+			return fmt.Errorf("not a member")
+		}
+
+		i := clamp(position, 0, len(members))
+		members = append(members[:i], append([]string{memberID}, members[i:]...)...)
+		collection.MemberIDs = members
+		return nil
+	})
+}
+
+// update replaces a collection with a modified copy.
+func (c *Collections) update(id string, fn func(collection *Collection) error) (*Collection, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	previous, ok := c.state.Collections[id]
+	if !ok {
+		return nil, fmt.Errorf("collection not found")
+	}
+
+	collection := *previous
+	collection.MemberIDs = append([]string(nil), previous.MemberIDs...)
+	if err := fn(&collection); err != nil {
+		return nil, err
+	}
+
+	return &collection, c.replace(id, &collection)
+}
+
+// replace stores (or deletes, if nil) a collection, reverting upon
+// failure.  Caller must hold the write lock.
+func (c *Collections) replace(id string, collection *Collection) error {
+	previous, existed := c.state.Collections[id]
+	if collection != nil {
+		c.state.Collections[id] = collection
+	} else {
+		delete(c.state.Collections, id)
+	}
+
+	c.version++
+	if err := writeState(c.path, &c.state); err != nil {
+		if existed {
+			c.state.Collections[id] = previous
+		} else {
+			delete(c.state.Collections, id)
+		}
+		return err
+	}
+
+	return nil
+}
+
+func dedupe(ids []string) []string {
+	seen := make(map[string]bool)
+	var unique []string
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	return unique
+}
+
+func clamp(n, min, max int) int {
+	if n < min {
+		return min
+	}
+	if n > max {
+		return max
+	}
+	return n
+}
+
+// CollectionMembers returns a collection's members present in the
+// library.  Smart collection members are the matching videos,
+// ordered by sortTitle.
+func (l *Library) CollectionMembers(collection *Collection) []CollectionMember {
+	s := l.Snapshot()
+
+	var members []CollectionMember
+	if collection.Smart() {
+		var videos []*Video
+		for _, metavideo := range s.Metavideos {
+			if collection.Filter.Matches(&metavideo.Video) {
+				videos = append(videos, &metavideo.Video)
+			}
+		}
+		sort.Sort(ByVideoTitle(videos))
+
+		for _, video := range videos {
+			members = append(members, video)
+		}
+		return members
+	}
+
+	for _, id := range collection.MemberIDs {
+		node, ok := s.node(id)
+		if !ok {
+			continue
+		}
+		if member, ok := node.(CollectionMember); ok {
+			members = append(members, member)
+		}
+	}
+	return members
+}
+
+// CollectionMemberID validates a prospective member, returning its
+// global ID.
+func (l *Library) CollectionMemberID(id string) (string, error) {
+	node, ok := l.Node(id)
+	if !ok {
+		return "", fmt.Errorf("%q not found", id)
+	}
+
+	switch node := node.(type) {
+	case *Video:
+		return node.ID, nil
+	case *Series:
+		return node.ID, nil
+	case *Episode:
+		return node.ID, nil
+	}
+
+	return "", fmt.Errorf("%q is not a video, series, or episode", id)
+}
+
+// CollectionArtwork returns the artwork of the first member having
+// some.  Series use the artwork of their earliest episode having some.
+func (l *Library) CollectionArtwork(members []CollectionMember) (*Artwork, bool) {
+	s := l.Snapshot()
+
+	for _, member := range members {
+		var videos []*Video
+		switch member := member.(type) {
+		case *Video:
+			videos = append(videos, member)
+		case *Episode:
+			videos = append(videos, member.Video)
+		case *Series:
+			for _, episode := range s.indexed().episodes[member.ID] {
+				videos = append(videos, episode.Video)
+			}
+		}
+
+		for _, video := range videos {
+			if metavideo, ok := s.metavideo(video.ID); ok && metavideo.HasArtwork {
+				return &Artwork{ID: video.ID}, true
+			}
+		}
+	}
+
+	return nil, false
+}
+
+// Matches reports whether a video satisfies the filter.  A nil filter
+// matches everything.
+func (f *VideoFilter) Matches(video *Video) bool {
+	if f == nil {
+		return true
+	}
+	if f.Title != nil && video.Title != *f.Title {
+		return false
+	}
+	if f.TitleContains != nil && !strings.Contains(strings.ToLower(video.Title), strings.ToLower(*f.TitleContains)) {
+		return false
+	}
+	if f.Genre != nil && (video.Genre == nil || *video.Genre != *f.Genre) {
+		return false
+	}
+	if f.ContentRating != nil && (video.ContentRating == nil || *video.ContentRating != *f.ContentRating) {
+		return false
+	}
+	if f.ReleasedFrom != nil && video.ReleaseYear < *f.ReleasedFrom {
+		return false
+	}
+	if f.ReleasedUntil != nil && video.ReleaseYear > *f.ReleasedUntil {
+		return false
+	}
+	if f.Episodic != nil && (video.Episode != nil) != *f.Episodic {
+		return false
+	}
+	if f.Series != nil && (video.Episode == nil || !f.Series.Matches(video.Episode.Season.Series)) {
+		return false
+	}
+	return true
+}
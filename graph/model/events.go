@@ -0,0 +1,102 @@
+package model
+
+import (
+	"context"
+	"sync"
+)
+
+type EventKind int
+
+const (
+	EventVideoAdded EventKind = iota
+	EventVideoUpdated
+	EventVideoRemoved
+	EventScanProgress
+)
+
+func (k EventKind) String() string {
+	switch k {
+	case EventVideoAdded:
+		return "added"
+	case EventVideoUpdated:
+		return "updated"
+	case EventVideoRemoved:
+		return "removed"
+	case EventScanProgress:
+		return "progress"
+	}
+	return "unknown"
+}
+
+// Event describes a library change.
+type Event struct {
+	Kind EventKind
+	// for video events
+	Video *Video
+	// for EventVideoRemoved, as the video is no longer resolvable
+	VideoID string
+	// for EventVideoRemoved, where its renditions were, keyed by ID
+	Paths map[string]string
+	// for EventScanProgress
+	ScanProgress *ScanProgress
+}
+
+// EventBus fans library events out to subscribers.
+//
+// Publishing never blocks: a subscriber whose buffer is full loses its
+// oldest pending event, so a slow client sees recent state rather
+// than stalling the survey.
+type EventBus struct {
+	mutex       sync.Mutex
+	subscribers map[chan Event]struct{}
+}
+
+func NewEventBus() *EventBus {
+	return &EventBus{
+		subscribers: make(map[chan Event]struct{}),
+	}
+}
+
+// Subscribe returns a channel of events, closed once ctx is done.
+func (b *EventBus) Subscribe(ctx context.Context, buffer int) <-chan Event {
+	if buffer < 1 {
+		buffer = 1
+	}
+	ch := make(chan Event, buffer)
+
+	b.mutex.Lock()
+	b.subscribers[ch] = struct{}{}
+	b.mutex.Unlock()
+
+	go func() {
+		<-ctx.Done()
+
+		b.mutex.Lock()
+		delete(b.subscribers, ch)
+		close(ch)
+		b.mutex.Unlock()
+	}()
+
+	return ch
+}
+
+func (b *EventBus) Publish(event Event) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	for ch := range b.subscribers {
+		for {
+			select {
+			case ch <- event:
+			default:
+				// full; drop the oldest and retry
+				select {
+				case <-ch:
+				default:
+				}
+				continue
+			}
+			break
+		}
+	}
+}
@@ -0,0 +1,179 @@
+package model
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/idiomatic/tvql/metadata/mp4"
+)
+
+// franchiseSidecarExt names a text file beside a video, holding its
+// franchise name and, optionally on the next line, in-universe order.
+const franchiseSidecarExt = ".franchise"
+
+// Franchise groups related videos (e.g., sequels and prequels).
+type Franchise struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	// per metadata, rather than title heuristics
+	Explicit bool `json:"explicit"`
+	// ordered by release year
+	Videos []*Video `json:"videos"`
+	// ordered in-universe
+	chronology []*Video
+}
+
+func (Franchise) IsNode() {}
+
+// Ordered returns the franchise's videos in the given order.
+func (f *Franchise) Ordered(order FranchiseOrder) []*Video {
+	if order == FranchiseOrderChronological {
+		return f.chronology
+	}
+	return f.Videos
+}
+
+// surveyFranchise reads explicit franchise metadata from FRANCHISE
+// atoms, else a sidecar file.
+func surveyFranchise(videoFile *mp4.File, path string) (string, int) {
+	if name, err := videoFile.Franchise(); err == nil && name != "" {
+		order, _ := videoFile.FranchiseOrder()
+		return name, order
+	}
+
+	buf, err := ioutil.ReadFile(strings.TrimSuffix(path, filepath.Ext(path)) + franchiseSidecarExt)
+	if err != nil {
+		return "", 0
+	}
+
+	lines := strings.SplitN(strings.TrimSpace(string(buf)), "\n", 3)
+	name := strings.TrimSpace(lines[0])
+	var order int
+	if len(lines) > 1 {
+		order, _ = strconv.Atoi(strings.TrimSpace(lines[1]))
+	}
+	return name, order
+}
+
+var (
+	// e.g., "Part II", "3", "Chapter 4"
+	sequelSuffix = regexp.MustCompile(`(?i)(\s+(part|chapter|vol\.?|volume))?\s+([0-9]+|[ivx]+)$`)
+	// e.g., "³"
+	superscriptSuffix = regexp.MustCompile(`[²³¹⁴⁵⁶⁷⁸⁹]+$`)
+)
+
+// franchiseStem normalizes a title to what its sequels share, e.g.,
+// "The Godfather Part II" => "godfather".
+func franchiseStem(title string) string {
+	if i := strings.Index(title, ":"); i != -1 {
+		title = title[:i]
+	}
+	title = strings.TrimSpace(SortableTitle(title))
+	title = superscriptSuffix.ReplaceAllString(title, "")
+	if stem := sequelSuffix.ReplaceAllString(title, ""); stem != "" {
+		title = stem
+	}
+	return strings.ToLower(strings.TrimSpace(title))
+}
+
+// buildFranchises groups movies by explicit franchise name, else by
+// title stem, keeping heuristic groups of two or more.
+func (s *Snapshot) buildFranchises(index *libraryIndex) {
+	type group struct {
+		name       string
+		explicit   bool
+		metavideos []*Metavideo
+	}
+	groups := make(map[string]*group)
+
+	for _, metavideo := range s.Metavideos {
+		if metavideo.Video.Episode != nil {
+			continue
+		}
+
+		key := franchiseStem(metavideo.Video.Title)
+		if metavideo.Franchise != "" {
+			key = franchiseStem(metavideo.Franchise)
+		}
+
+		g, ok := groups[key]
+		if !ok {
+			g = &group{}
+			groups[key] = g
+		}
+		if metavideo.Franchise != "" && !g.explicit {
+			g.name = metavideo.Franchise
+			g.explicit = true
+		}
+		g.metavideos = append(g.metavideos, metavideo)
+	}
+
+	for key, g := range groups {
+		if !g.explicit && len(g.metavideos) < 2 {
+			continue
+		}
+
+		sort.Slice(g.metavideos, func(i, j int) bool {
+			a, b := &g.metavideos[i].Video, &g.metavideos[j].Video
+			if a.ReleaseYear != b.ReleaseYear {
+				return a.ReleaseYear < b.ReleaseYear
+			}
+			return a.SortTitle < b.SortTitle
+		})
+
+		name := g.name
+		if name == "" {
+			// the original's title, sans subtitle
+			name = strings.TrimSpace(strings.SplitN(g.metavideos[0].Video.Title, ":", 2)[0])
+		}
+
+		franchise := &Franchise{
+			ID:       NewGlobalID(NodeKindFranchise, key),
+			Name:     name,
+			Explicit: g.explicit,
+		}
+		for _, metavideo := range g.metavideos {
+			franchise.Videos = append(franchise.Videos, &metavideo.Video)
+		}
+
+		// explicitly ordered first, then the rest by release year
+		chronology := append([]*Metavideo(nil), g.metavideos...)
+		sort.SliceStable(chronology, func(i, j int) bool {
+			a, b := chronology[i].FranchiseOrder, chronology[j].FranchiseOrder
+			if (a == 0) != (b == 0) {
+				return a != 0
+			}
+			return a < b
+		})
+		for _, metavideo := range chronology {
+			franchise.chronology = append(franchise.chronology, &metavideo.Video)
+		}
+
+		index.franchises[franchise.ID] = franchise
+		for _, video := range franchise.Videos {
+			index.franchiseByVideo[video.ID] = franchise
+		}
+	}
+}
+
+// Franchises returns all franchises, ordered by name.
+func (l *Library) Franchises() []*Franchise {
+	var franchises []*Franchise
+	for _, franchise := range l.Snapshot().indexed().franchises {
+		franchises = append(franchises, franchise)
+	}
+	sort.Slice(franchises, func(i, j int) bool {
+		return SortableTitle(franchises[i].Name) < SortableTitle(franchises[j].Name)
+	})
+	return franchises
+}
+
+// FranchiseOf returns the franchise of a video, if any.
+func (l *Library) FranchiseOf(videoID string) (*Franchise, bool) {
+	franchise, ok := l.Snapshot().indexed().franchiseByVideo[videoID]
+	return franchise, ok
+}
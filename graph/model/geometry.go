@@ -0,0 +1,175 @@
+package model
+
+import (
+	"encoding/hex"
+	"fmt"
+	"image"
+	"image/color"
+	"math"
+	"strings"
+
+	"github.com/disintegration/imaging"
+)
+
+// maxDPR bounds device pixel ratios, e.g., of 3x phones.
+const maxDPR = 4
+
+var imagingAnchors = map[ArtworkAnchor]imaging.Anchor{
+	ArtworkAnchorCenter:      imaging.Center,
+	ArtworkAnchorTop:         imaging.Top,
+	ArtworkAnchorBottom:      imaging.Bottom,
+	ArtworkAnchorLeft:        imaging.Left,
+	ArtworkAnchorRight:       imaging.Right,
+	ArtworkAnchorTopLeft:     imaging.TopLeft,
+	ArtworkAnchorTopRight:    imaging.TopRight,
+	ArtworkAnchorBottomLeft:  imaging.BottomLeft,
+	ArtworkAnchorBottomRight: imaging.BottomRight,
+}
+
+// Validate reports nonsensical geometry, e.g., of clients' URLs.
+func (g *GeometryFilter) Validate() error {
+	if g.Width != nil && *g.Width < 1 {
+		return fmt.Errorf("artwork width %d out of range", *g.Width)
+	}
+	if g.Height != nil && *g.Height < 1 {
+		return fmt.Errorf("artwork height %d out of range", *g.Height)
+	}
+	if g.Fit != nil && !g.Fit.IsValid() {
+		return fmt.Errorf("unknown artwork fit %s", *g.Fit)
+	}
+	if g.Anchor != nil && !g.Anchor.IsValid() {
+		return fmt.Errorf("unknown artwork anchor %s", *g.Anchor)
+	}
+	if g.Background != nil {
+		if _, err := parseColor(*g.Background); err != nil {
+			return err
+		}
+	}
+	if g.Dpr != nil && !(*g.Dpr >= 1 && *g.Dpr <= maxDPR) {
+		return fmt.Errorf("artwork dpr %g out of range", *g.Dpr)
+	}
+	if g.Quality != nil && (*g.Quality < 1 || *g.Quality > 100) {
+		return fmt.Errorf("artwork quality %d out of range", *g.Quality)
+	}
+	return nil
+}
+
+// box is the requested size in device pixels, zero where unspecified.
+func (g *GeometryFilter) box() (width, height int) {
+	dpr := 1.0
+	if g.Dpr != nil {
+		dpr = *g.Dpr
+	}
+	if g.Width != nil {
+		width = int(math.Round(float64(*g.Width) * dpr))
+	}
+	if g.Height != nil {
+		height = int(math.Round(float64(*g.Height) * dpr))
+	}
+	return width, height
+}
+
+// DefaultArtworkFit is how artwork fits a width and height absent a
+// fit, i.e., stretched, as before fits were introduced.
+const DefaultArtworkFit = ArtworkFitFill
+
+// fit downsamples an image per geometry.
+func (g *GeometryFilter) fit(img image.Image) (image.Image, error) {
+	size := img.Bounds().Size()
+	width, height := g.box()
+
+	fit := DefaultArtworkFit
+	if g.Fit != nil {
+		fit = *g.Fit
+	}
+	anchor := ArtworkAnchorCenter
+	if g.Anchor != nil {
+		anchor = *g.Anchor
+	}
+
+	switch {
+	case width == 0 && height == 0:
+		width, height = size.X, size.Y
+	case width == 0:
+		if height > size.Y {
+			height = size.Y
+		}
+		width = height * size.X / size.Y
+	case height == 0:
+		if width > size.X {
+			width = size.X
+		}
+		height = width * size.Y / size.X
+	case fit == ArtworkFitContain:
+		return imaging.Fit(img, width, height, imaging.Lanczos), nil
+	case fit == ArtworkFitCover:
+		width, height = shrink(width, height, size)
+		return imaging.Fill(img, width, height, imagingAnchors[anchor], imaging.Lanczos), nil
+	case fit == ArtworkFitPad:
+		background := color.Color(color.Black)
+		if g.Background != nil {
+			var err error
+			if background, err = parseColor(*g.Background); err != nil {
+				return nil, err
+			}
+		}
+		width, height = shrink(width, height, size)
+		fitted := imaging.Fit(img, width, height, imaging.Lanczos)
+		canvas := imaging.New(width, height, background)
+		return imaging.Paste(canvas, fitted, anchored(anchor, canvas.Bounds().Size(), fitted.Bounds().Size())), nil
+	default:
+		// i.e., FILL
+		width, height = shrink(width, height, size)
+	}
+
+	if width == size.X && height == size.Y {
+		return img, nil
+	}
+	return imaging.Resize(img, width, height, imaging.Lanczos), nil
+}
+
+// shrink scales a box, preserving its aspect ratio, to within size,
+// lest artwork be upsampled.
+func shrink(width, height int, size image.Point) (int, int) {
+	scale := math.Min(float64(size.X)/float64(width), float64(size.Y)/float64(height))
+	if scale >= 1 {
+		return width, height
+	}
+	width = int(math.Max(1, math.Round(float64(width)*scale)))
+	height = int(math.Max(1, math.Round(float64(height)*scale)))
+	return width, height
+}
+
+// anchored positions an inner rectangle within an outer one.
+func anchored(anchor ArtworkAnchor, outer, inner image.Point) image.Point {
+	position := image.Pt((outer.X-inner.X)/2, (outer.Y-inner.Y)/2)
+	switch anchor {
+	case ArtworkAnchorLeft, ArtworkAnchorTopLeft, ArtworkAnchorBottomLeft:
+		position.X = 0
+	case ArtworkAnchorRight, ArtworkAnchorTopRight, ArtworkAnchorBottomRight:
+		position.X = outer.X - inner.X
+	}
+	switch anchor {
+	case ArtworkAnchorTop, ArtworkAnchorTopLeft, ArtworkAnchorTopRight:
+		position.Y = 0
+	case ArtworkAnchorBottom, ArtworkAnchorBottomLeft, ArtworkAnchorBottomRight:
+		position.Y = outer.Y - inner.Y
+	}
+	return position
+}
+
+// parseColor parses "#rgb", "#rrggbb", or "#rrggbbaa" ("#" optional).
+func parseColor(s string) (color.NRGBA, error) {
+	digits := strings.TrimPrefix(s, "#")
+	if len(digits) == 3 {
+		digits = string([]byte{digits[0], digits[0], digits[1], digits[1], digits[2], digits[2]})
+	}
+	if len(digits) == 6 {
+		digits += "ff"
+	}
+	rgba, err := hex.DecodeString(digits)
+	if err != nil || len(rgba) != 4 {
+		return color.NRGBA{}, fmt.Errorf("artwork background %q not a color", s)
+	}
+	return color.NRGBA{R: rgba[0], G: rgba[1], B: rgba[2], A: rgba[3]}, nil
+}
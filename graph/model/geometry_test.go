@@ -0,0 +1,137 @@
+package model
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/disintegration/imaging"
+)
+
+var (
+	red  = color.NRGBA{R: 255, A: 255}
+	blue = color.NRGBA{B: 255, A: 255}
+)
+
+// halves is a 200x100 image, red on the left and blue on the right.
+func halves() image.Image {
+	img := imaging.New(200, 100, red)
+	return imaging.Paste(img, imaging.New(100, 100, blue), image.Pt(100, 0))
+}
+
+func intp(n int) *int               { return &n }
+func floatp(f float64) *float64     { return &f }
+func fitp(f ArtworkFit) *ArtworkFit { return &f }
+
+func anchorp(a ArtworkAnchor) *ArtworkAnchor { return &a }
+func stringp(s string) *string               { return &s }
+
+func TestGeometryFit(t *testing.T) {
+	for _, test := range []struct {
+		name          string
+		geometry      GeometryFilter
+		width, height int
+	}{
+		{"original", GeometryFilter{}, 200, 100},
+		{"width", GeometryFilter{Width: intp(100)}, 100, 50},
+		{"height", GeometryFilter{Height: intp(50)}, 100, 50},
+		{"width not upsampled", GeometryFilter{Width: intp(400)}, 200, 100},
+		{"stretched by default", GeometryFilter{Width: intp(50), Height: intp(50)}, 50, 50},
+		{"FILL", GeometryFilter{Width: intp(50), Height: intp(50), Fit: fitp(ArtworkFitFill)}, 50, 50},
+		{"FILL shrunk evenly", GeometryFilter{Width: intp(400), Height: intp(100), Fit: fitp(ArtworkFitFill)}, 200, 50},
+		{"CONTAIN", GeometryFilter{Width: intp(50), Height: intp(50), Fit: fitp(ArtworkFitContain)}, 50, 25},
+		{"COVER", GeometryFilter{Width: intp(50), Height: intp(50), Fit: fitp(ArtworkFitCover)}, 50, 50},
+		{"COVER shrunk evenly", GeometryFilter{Width: intp(300), Height: intp(300), Fit: fitp(ArtworkFitCover)}, 100, 100},
+		{"PAD", GeometryFilter{Width: intp(50), Height: intp(50), Fit: fitp(ArtworkFitPad)}, 50, 50},
+		{"dpr", GeometryFilter{Width: intp(50), Dpr: floatp(2)}, 100, 50},
+		{"dpr COVER", GeometryFilter{Width: intp(40), Height: intp(40), Dpr: floatp(2), Fit: fitp(ArtworkFitCover)}, 80, 80},
+	} {
+		if err := test.geometry.Validate(); err != nil {
+			t.Errorf("%s: %v", test.name, err)
+			continue
+		}
+		img, err := test.geometry.fit(halves())
+		if err != nil {
+			t.Errorf("%s: %v", test.name, err)
+			continue
+		}
+		if size := img.Bounds().Size(); size.X != test.width || size.Y != test.height {
+			t.Errorf("%s: %dx%d, want %dx%d", test.name, size.X, size.Y, test.width, test.height)
+		}
+	}
+}
+
+func TestGeometryAnchor(t *testing.T) {
+	near := func(c color.Color, want color.NRGBA) bool {
+		got := color.NRGBAModel.Convert(c).(color.NRGBA)
+		d := func(a, b uint8) int {
+			if a > b {
+				return int(a - b)
+			}
+			return int(b - a)
+		}
+		return d(got.R, want.R) < 8 && d(got.G, want.G) < 8 && d(got.B, want.B) < 8 && d(got.A, want.A) < 8
+	}
+	white := color.NRGBA{R: 255, G: 255, B: 255, A: 255}
+
+	for _, test := range []struct {
+		name     string
+		geometry GeometryFilter
+		// color of sampled points
+		points map[image.Point]color.NRGBA
+	}{
+		{
+			name:     "COVER left",
+			geometry: GeometryFilter{Width: intp(50), Height: intp(50), Fit: fitp(ArtworkFitCover), Anchor: anchorp(ArtworkAnchorLeft)},
+			points:   map[image.Point]color.NRGBA{{0, 25}: red, {49, 25}: red},
+		},
+		{
+			name:     "COVER right",
+			geometry: GeometryFilter{Width: intp(50), Height: intp(50), Fit: fitp(ArtworkFitCover), Anchor: anchorp(ArtworkAnchorRight)},
+			points:   map[image.Point]color.NRGBA{{0, 25}: blue, {49, 25}: blue},
+		},
+		{
+			name:     "COVER centered",
+			geometry: GeometryFilter{Width: intp(50), Height: intp(50), Fit: fitp(ArtworkFitCover)},
+			points:   map[image.Point]color.NRGBA{{2, 25}: red, {47, 25}: blue},
+		},
+		{
+			name:     "PAD centered",
+			geometry: GeometryFilter{Width: intp(50), Height: intp(50), Fit: fitp(ArtworkFitPad), Background: stringp("#fff")},
+			points:   map[image.Point]color.NRGBA{{25, 2}: white, {2, 25}: red, {47, 25}: blue, {25, 47}: white},
+		},
+		{
+			name:     "PAD top",
+			geometry: GeometryFilter{Width: intp(50), Height: intp(50), Fit: fitp(ArtworkFitPad), Anchor: anchorp(ArtworkAnchorTop), Background: stringp("ffffff")},
+			points:   map[image.Point]color.NRGBA{{2, 2}: red, {47, 2}: blue, {25, 47}: white},
+		},
+	} {
+		img, err := test.geometry.fit(halves())
+		if err != nil {
+			t.Errorf("%s: %v", test.name, err)
+			continue
+		}
+		for point, want := range test.points {
+			if got := img.At(point.X, point.Y); !near(got, want) {
+				t.Errorf("%s: %v is %v, want %v", test.name, point, got, want)
+			}
+		}
+	}
+}
+
+func TestGeometryValidate(t *testing.T) {
+	unknown := ArtworkFit("SQUISH")
+	for name, geometry := range map[string]GeometryFilter{
+		"zero width":  {Width: intp(0)},
+		"dpr":         {Dpr: floatp(5)},
+		"fractional":  {Dpr: floatp(0.5)},
+		"fit":         {Fit: &unknown},
+		"background":  {Background: stringp("#ggg")},
+		"quality":     {Quality: intp(101)},
+		"zero height": {Height: intp(0)},
+	} {
+		if err := geometry.Validate(); err == nil {
+			t.Errorf("%s: validated", name)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package model
+
+import (
+	"sort"
+)
+
+// libraryIndex precomputes series -> seasons -> episodes, so nested
+// resolvers need not scan every video.
+type libraryIndex struct {
+	// keyed by series id
+	seasons map[string][]*Season
+	// keyed by series or season id
+	episodes map[string][]*Episode
+	// position within its series' episodes, keyed by episode id
+	positions map[string]int
+	// keyed by franchise id
+	franchises map[string]*Franchise
+	// keyed by video id
+	franchiseByVideo map[string]*Franchise
+}
+
+// indexed returns the snapshot's index, built upon first use.
+func (s *Snapshot) indexed() *libraryIndex {
+	s.indexOnce.Do(func() {
+		s.index = s.buildIndex()
+	})
+	return s.index
+}
+
+func (s *Snapshot) buildIndex() *libraryIndex {
+	index := &libraryIndex{
+		seasons:   make(map[string][]*Season),
+		episodes:  make(map[string][]*Episode),
+		positions: make(map[string]int),
+
+		franchises:       make(map[string]*Franchise),
+		franchiseByVideo: make(map[string]*Franchise),
+	}
+
+	for _, season := range s.Seasons {
+		index.seasons[season.Series.ID] = append(index.seasons[season.Series.ID], season)
+	}
+	for _, seasons := range index.seasons {
+		sort.Sort(BySeason(seasons))
+	}
+
+	for _, metavideo := range s.Metavideos {
+		episode := metavideo.Video.Episode
+		if episode == nil {
+			continue
+		}
+		index.episodes[episode.Season.ID] = append(index.episodes[episode.Season.ID], episode)
+		index.episodes[episode.Season.Series.ID] = append(index.episodes[episode.Season.Series.ID], episode)
+	}
+	for _, episodes := range index.episodes {
+		sort.Sort(ByEpisode(episodes))
+	}
+	for _, series := range s.Series {
+		for i, episode := range index.episodes[series.ID] {
+			index.positions[episode.ID] = i
+		}
+	}
+
+	s.buildFranchises(index)
+
+	return index
+}
+
+// SeasonsBySeries returns the seasons of each series, ordered by
+// season number.
+func (l *Library) SeasonsBySeries(seriesIDs []string) [][]*Season {
+	index := l.Snapshot().indexed()
+
+	seasons := make([][]*Season, len(seriesIDs))
+	for i, id := range seriesIDs {
+		seasons[i] = index.seasons[id]
+	}
+	return seasons
+}
+
+// EpisodesByParent returns the episodes of each series or season,
+// ordered by season number then episode number.
+func (l *Library) EpisodesByParent(parentIDs []string) [][]*Episode {
+	index := l.Snapshot().indexed()
+
+	episodes := make([][]*Episode, len(parentIDs))
+	for i, id := range parentIDs {
+		episodes[i] = index.episodes[id]
+	}
+	return episodes
+}
+
+// HasArtwork reports whether each video has cover art, per the
+// latest survey.
+func (l *Library) HasArtwork(videoIDs []string) []bool {
+	s := l.Snapshot()
+
+	hasArtwork := make([]bool, len(videoIDs))
+	for i, id := range videoIDs {
+		if metavideo, ok := s.metavideo(id); ok {
+			hasArtwork[i] = metavideo.HasArtwork
+		}
+	}
+	return hasArtwork
+}
@@ -1,6 +1,7 @@
 package model
 
 import (
+	"context"
 	"crypto/sha256"
 	"encoding/base64"
 	"fmt"
@@ -9,6 +10,8 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/idiomatic/tvql/metadata/mp4"
 	"github.com/sunfish-shogi/bufseekio"
@@ -16,24 +19,23 @@ import (
 
 type Metavideo struct {
 	Video
-	Path string
+	Path       string
+	HasArtwork bool
+	// explicit franchise name and in-universe order (optional)
+	Franchise      string
+	FranchiseOrder int
 }
 
 type Metarendition struct {
-	Path string
-}
-
-// useful for toll-free use of encoded VideoID/EpisodeID via client
-type Stringer struct {
-	S string
-}
-
-func (s Stringer) String() string {
-	return s.S
+	Path    string
+	VideoID string
+	// length, if known
+	Duration time.Duration
 }
 
 type MetavideoID interface {
 	fmt.Stringer
+	legacyID() string
 }
 
 type VideoID struct {
@@ -43,52 +45,188 @@ type VideoID struct {
 
 // note: exposed to client
 func (id VideoID) String() string {
-	key := fmt.Sprintf("%s (%d)", id.Title, id.ReleaseYear)
-	return key
-	return hashToStr(key)
+	return NewGlobalID(NodeKindVideo, id.legacyID())
+}
+
+func (id VideoID) legacyID() string {
+	return fmt.Sprintf("%s (%d)", id.Title, id.ReleaseYear)
 }
 
 type SeriesID string
 
+// note: exposed to client
+func (id SeriesID) String() string {
+	return NewGlobalID(NodeKindSeries, string(id))
+}
+
 type SeasonID struct {
 	SeriesID     SeriesID
 	SeasonNumber int
 }
 
+// note: exposed to client
+func (id SeasonID) String() string {
+	return NewGlobalID(NodeKindSeason, fmt.Sprintf("%s %d", id.SeriesID, id.SeasonNumber))
+}
+
 type EpisodeID struct {
 	SeasonID
 	EpisodeNumber int
 	VideoID
 }
 
+// String identifies the episode's video.
+//
 // note: exposed to client
 func (id EpisodeID) String() string {
-	key := fmt.Sprintf("%s %d%02d %s", id.SeriesID, id.SeasonNumber, id.EpisodeNumber, id.VideoID)
-	return key
-	return hashToStr(key)
+	return NewGlobalID(NodeKindVideo, id.legacyID())
+}
+
+// EpisodeString identifies the episode itself.
+//
+// note: exposed to client
+func (id EpisodeID) EpisodeString() string {
+	return NewGlobalID(NodeKindEpisode, id.legacyID())
+}
+
+func (id EpisodeID) legacyID() string {
+	// as named, not SeriesID.String()
+	return fmt.Sprintf("%s %d%02d %s", string(id.SeriesID), id.SeasonNumber, id.EpisodeNumber, id.VideoID.legacyID())
 }
 
 type Library struct {
-	Metavideos     map[MetavideoID]*Metavideo
-	Series         map[SeriesID]*Series
-	Seasons        map[SeasonID]*Season
-	Metarenditions map[string]*Metarendition
-	Mutex          sync.Mutex
+	Events *EventBus
+
+	// OnSurveyed, if set, is told of each survey's outcome, e.g., to
+	// log or measure it.
+	OnSurveyed func(result SurveyResult)
+	// OnFileSurveyed, if set, is told of each file's outcome.
+	OnFileSurveyed func(result FileSurveyResult)
+
+	// *Snapshot
+	current atomic.Value
+	// serializes surveys
+	surveyMutex sync.Mutex
+	// nonzero once a survey has completed
+	surveyed int32
+	// of the latest survey
+	surveyErrMutex sync.Mutex
+	surveyErr      error
 }
 
 func NewLibrary() *Library {
-	return &Library{
-		Metavideos:     make(map[MetavideoID]*Metavideo),
-		Series:         make(map[SeriesID]*Series),
-		Seasons:        make(map[SeasonID]*Season),
-		Metarenditions: make(map[string]*Metarendition),
+	l := &Library{
+		Events: NewEventBus(),
+	}
+	l.current.Store(newSnapshot())
+	return l
+}
+
+// SurveyResult is the outcome of a survey.
+type SurveyResult struct {
+	Root        string
+	Files       int
+	VideosAdded int
+	Duration    time.Duration
+	// why the survey stopped, if it did
+	Err error
+}
+
+// FileSurveyResult is the outcome of surveying a file, or of skipping
+// an unreadable path.
+type FileSurveyResult struct {
+	Root string
+	Path string
+	// "added", "updated", "unchanged", "failed", or "skipped"
+	Outcome  string
+	Duration time.Duration
+	// why the file was unreadable, if it was
+	Err error
+	// why its metadata was unreadable, if it was (listed nonetheless)
+	MetadataErr error
+}
+
+// fileSurveyed reports a file's outcome, if anyone listens.
+func (l *Library) fileSurveyed(result FileSurveyResult) {
+	if l.OnFileSurveyed != nil {
+		l.OnFileSurveyed(result)
 	}
 }
 
-func (l *Library) Survey(root string) error {
-	err := filepath.Walk(root,
+// surveyProgressInterval is how many files are surveyed between
+// scanProgress events and snapshot publications.
+const surveyProgressInterval = 25
+
+// Survey scans root for videos.  Rescans are idempotent; renditions
+// no longer found under root are removed.
+//
+// Progress is published in batches, each a new snapshot.  Once ctx is
+// done, the survey stops, publishing (but not pruning) what it found.
+func (l *Library) Survey(ctx context.Context, root string) (err error) {
+	l.surveyMutex.Lock()
+	defer l.surveyMutex.Unlock()
+
+	progress := &ScanProgress{Root: root}
+	defer func(start time.Time) {
+		l.surveyErrMutex.Lock()
+		l.surveyErr = err
+		l.surveyErrMutex.Unlock()
+
+		if l.OnSurveyed != nil {
+			l.OnSurveyed(SurveyResult{
+				Root:        root,
+				Files:       progress.FilesScanned,
+				VideosAdded: progress.Videos,
+				Duration:    time.Since(start),
+				Err:         err,
+			})
+		}
+	}(time.Now())
+	seen := make(map[string]bool)
+	batch := l.Snapshot().clone()
+	var events []Event
+
+	publish := func() {
+		for _, event := range events {
+			if event.Kind != EventScanProgress {
+				batch.Version++
+				break
+			}
+		}
+
+		l.current.Store(batch)
+		for _, event := range events {
+			if event.Video != nil && event.Kind != EventVideoRemoved {
+				// latest copy within the batch
+				video, ok := batch.Nodes[event.Video.ID].(*Video)
+				if !ok {
+					// since removed, as a later event tells
+					continue
+				}
+				event.Video = video
+			}
+			l.Events.Publish(event)
+		}
+		events = nil
+		batch = batch.clone()
+	}
+
+	err = filepath.Walk(root,
 		func(path string, info os.FileInfo, err error) error {
 			if err != nil {
+				if path == root {
+					return err
+				}
+				// e.g., unreadable; skipped, keeping renditions found there before
+				l.fileSurveyed(FileSurveyResult{Root: root, Path: path, Outcome: "skipped", Err: err})
+				for renditionID, metarendition := range batch.Metarenditions {
+					if within(metarendition.Path, path) {
+						seen[renditionID] = true
+					}
+				}
+				return nil
+			}
+			if err := ctx.Err(); err != nil {
 				return err
 			}
 
@@ -97,143 +235,392 @@ func (l *Library) Survey(root string) error {
 				return nil
 			}
 
-			return func() error {
-				file, err := os.Open(path)
-				if err != nil {
-					return err
+			relativePath, err := filepath.Rel(root, path)
+			if err != nil {
+				return err
+			}
+
+			renditionID := NewGlobalID(NodeKindRendition, relativePath)
+			seen[renditionID] = true
+
+			start := time.Now()
+			fileEvents, metadataErr, err := batch.surveyFile(path, renditionID, info)
+			if err != nil {
+				// e.g., unreadable; any prior rendition is kept
+				l.fileSurveyed(FileSurveyResult{Root: root, Path: path, Outcome: "failed", Duration: time.Since(start), Err: err})
+				return nil
+			}
+			outcome := "unchanged"
+			for _, event := range fileEvents {
+				outcome = event.Kind.String()
+				if event.Kind == EventVideoAdded {
+					progress.Videos++
 				}
-				defer file.Close()
+			}
+			l.fileSurveyed(FileSurveyResult{Root: root, Path: path, Outcome: outcome, Duration: time.Since(start), MetadataErr: metadataErr})
+			events = append(events, fileEvents...)
+
+			progress.FilesScanned++
+			if progress.FilesScanned%surveyProgressInterval == 0 {
+				p := *progress
+				events = append(events, Event{Kind: EventScanProgress, ScanProgress: &p})
+				publish()
+			}
 
-				bufferedFile := bufseekio.NewReadSeeker(file, 1024, 4)
+			return nil
+		})
+	if err != nil {
+		publish()
+		return err
+	}
 
-				videoFile := mp4.NewFile(bufferedFile)
+	events = append(events, batch.prune(root, seen)...)
 
-				title, err := videoFile.Title()
-				if err != nil || title == "" {
-					// HACK
-					title = strings.TrimSuffix(filepath.Base(path), ext)
-				}
+	progress.Done = true
+	events = append(events, Event{Kind: EventScanProgress, ScanProgress: progress})
+	publish()
+	atomic.StoreInt32(&l.surveyed, 1)
 
-				releaseDate, err := videoFile.ReleaseDate()
-				if err != nil || releaseDate == "" {
-					// HACK
-					releaseDate = "1900"
-				}
-				releaseYear, _ := strconv.Atoi(releaseDate[:4])
+	return nil
+}
 
-				seriesName, seriesNameErr := videoFile.TVShowName()
-				seasonNumber, seasonNumberErr := videoFile.TVSeason()
-				episodeNumber, episodeNumberErr := videoFile.TVEpisode()
+// Surveyed reports whether any survey has completed, i.e., whether the
+// library is complete.
+func (l *Library) Surveyed() bool {
+	return atomic.LoadInt32(&l.surveyed) != 0
+}
 
-				var (
-					videoID   = VideoID{title, releaseYear}
-					seriesID  = SeriesID(seriesName)
-					seasonID  = SeasonID{seriesID, seasonNumber}
-					episodeID = EpisodeID{seasonID, episodeNumber, videoID}
+// SurveyErr reports why the latest survey stopped, if it did.
+func (l *Library) SurveyErr() error {
+	l.surveyErrMutex.Lock()
+	defer l.surveyErrMutex.Unlock()
+	return l.surveyErr
+}
 
-					metavideoID MetavideoID = videoID
-				)
+// surveyFile adds one video file to an unpublished snapshot.  Yields
+// why its metadata was unreadable, if it was.
+func (s *Snapshot) surveyFile(path string, renditionID string, info os.FileInfo) ([]Event, error, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer file.Close()
 
-				if seriesNameErr == nil && seasonNumberErr == nil && episodeNumberErr == nil && seriesName != "" && seasonNumber > 0 {
-					metavideoID = episodeID
-				}
+	bufferedFile := bufseekio.NewReadSeeker(file, 1024, 4)
 
-				l.Mutex.Lock()
-				metavideo, ok := l.Metavideos[videoID]
-				if !ok {
-					metavideo = &Metavideo{
-						Video{
-							ID:          metavideoID.String(),
-							Title:       title,
-							ReleaseYear: releaseYear,
-						},
-						path,
-					}
-					l.Metavideos[metavideoID] = metavideo
-				}
-				l.Mutex.Unlock()
+	videoFile := mp4.NewFile(bufferedFile)
+	// listed nonetheless, by file name
+	metadataErr := videoFile.Parse()
 
-				video := &metavideo.Video
+	title, err := videoFile.Title()
+	if err != nil || title == "" {
+		// HACK
+		title = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
 
-				if sortTitle, err := videoFile.SortTitle(); err != nil || sortTitle == "" {
-					video.SortTitle = SortableTitle(title)
-				} else {
-					video.SortTitle = sortTitle
-				}
+	releaseDate, err := videoFile.ReleaseDate()
+	if err != nil || releaseDate == "" {
+		// HACK
+		releaseDate = "1900"
+	}
+	releaseYear, _ := strconv.Atoi(releaseDate[:4])
 
-				if g, err := videoFile.Genre(); err == nil && g != "" {
-					video.Genre = &g
-				}
+	seriesName, seriesNameErr := videoFile.TVShowName()
+	seasonNumber, seasonNumberErr := videoFile.TVSeason()
+	episodeNumber, episodeNumberErr := videoFile.TVEpisode()
 
-				if d, err := videoFile.Description(); err == nil && d != "" {
-					video.Description = &d
-				}
+	var (
+		videoID   = VideoID{title, releaseYear}
+		seriesID  = SeriesID(seriesName)
+		seasonID  = SeasonID{seriesID, seasonNumber}
+		episodeID = EpisodeID{seasonID, episodeNumber, videoID}
 
-				// XXX switch off mediakind?
-				if seriesNameErr == nil && seriesName != "" {
-					l.Mutex.Lock()
-					series, ok := l.Series[seriesID]
-					if !ok {
-						series = &Series{
-							Name: seriesName,
-						}
-						l.Series[seriesID] = series
-					}
+		metavideoID MetavideoID = videoID
+	)
 
-					season, ok := l.Seasons[seasonID]
-					if !ok {
-						season = &Season{
-							Season: seasonNumber,
-							Series: series,
-						}
-						l.Seasons[seasonID] = season
-					}
+	if seriesNameErr == nil && seasonNumberErr == nil && episodeNumberErr == nil && seriesName != "" && seasonNumber > 0 {
+		metavideoID = episodeID
+	}
 
-					episode := video.Episode
-					if episode == nil {
-						episode = &Episode{
-							Season:  season,
-							Episode: episodeNumber,
-							Video:   video, // XXX cyclic reference loop
-						}
-						video.Episode = episode
-					}
-					l.Mutex.Unlock()
+	// copy-on-write
+	metavideo := &Metavideo{
+		Video: Video{
+			ID:          metavideoID.String(),
+			Title:       title,
+			ReleaseYear: releaseYear,
+		},
+		Path: path,
+	}
+	previous, found := s.Metavideos[metavideoID.String()]
+	if found {
+		clone := *previous
+		metavideo = &clone
+	}
 
-					if sortSeriesName, err := videoFile.TVSortShowName(); err == nil && sortSeriesName != "" {
-						series.SortName = sortSeriesName
-					} else {
-						series.SortName = SortableTitle(seriesName)
-					}
-				}
+	video := &metavideo.Video
 
-				renditionID := hashToStr(path)
-				rendition := &Rendition{
-					ID:      renditionID,
-					Quality: qualityFromPath(path),
-					Size:    int(info.Size()),
-				}
+	if metavideo.Path == path {
+		metavideo.HasArtwork = videoFile.HasCoverArt()
+		metavideo.Franchise, metavideo.FranchiseOrder = surveyFranchise(videoFile, path)
+	}
+
+	if sortTitle, err := videoFile.SortTitle(); err != nil || sortTitle == "" {
+		video.SortTitle = SortableTitle(title)
+	} else {
+		video.SortTitle = sortTitle
+	}
 
-				{
-					l.Mutex.Lock()
+	if g, err := videoFile.Genre(); err == nil && g != "" {
+		video.Genre = &g
+	}
 
-					l.Metarenditions[renditionID] = &Metarendition{
-						path,
-					}
+	if d, err := videoFile.Description(); err == nil && d != "" {
+		video.Description = &d
+	}
 
-					if video.Renditions == nil {
-						video.Renditions = &Renditions{}
-					}
-					video.Renditions.All = append(video.Renditions.All, rendition)
-					l.Mutex.Unlock()
-				}
+	if rating, err := videoFile.ContentRating(); err == nil {
+		video.ContentRating = &rating
+	}
 
-				return nil
-			}()
-		})
-	return err
+	// XXX switch off mediakind?
+	if seriesNameErr == nil && seriesName != "" {
+		series, ok := s.Series[seriesID]
+		if !ok {
+			series = &Series{
+				ID:   seriesID.String(),
+				Name: seriesName,
+			}
+
+			if sortSeriesName, err := videoFile.TVSortShowName(); err == nil && sortSeriesName != "" {
+				series.SortName = sortSeriesName
+			} else {
+				series.SortName = SortableTitle(seriesName)
+			}
+
+			s.Series[seriesID] = series
+			s.register(series.ID, "", series)
+		}
+
+		season, ok := s.Seasons[seasonID]
+		if !ok {
+			season = &Season{
+				ID:     seasonID.String(),
+				Season: seasonNumber,
+				Series: series,
+			}
+			s.Seasons[seasonID] = season
+			s.register(season.ID, "", season)
+		}
+
+		video.Episode = &Episode{
+			ID:      episodeID.EpisodeString(),
+			Season:  season,
+			Episode: episodeNumber,
+			Video:   video, // XXX cyclic reference loop
+		}
+	}
+
+	rendition := &Rendition{
+		ID:           renditionID,
+		Quality:      qualityFromPath(path),
+		IsStreamable: videoFile.FastStart(),
+		Size:         int(info.Size()),
+	}
+	duration, err := videoFile.Duration()
+	if err == nil {
+		minutes := int(duration.Round(time.Minute) / time.Minute)
+		rendition.Duration = &minutes
+	}
+
+	changed := true
+	var renditions []*Rendition
+	if video.Renditions != nil {
+		for _, r := range video.Renditions.All {
+			if r.ID == renditionID {
+				changed = r.Size != rendition.Size || r.IsStreamable != rendition.IsStreamable ||
+					!sameMetadata(previous, metavideo)
+				continue
+			}
+			renditions = append(renditions, r)
+		}
+	}
+	video.Renditions = &Renditions{
+		All: append(renditions, rendition),
+	}
+
+	var events []Event
+	if prior, ok := s.Metarenditions[renditionID]; ok && prior.VideoID != video.ID {
+		// e.g., retitled; lest the former video linger
+		if event := s.detach(prior.VideoID, map[string]string{renditionID: prior.Path}); event != nil {
+			events = append(events, *event)
+		}
+	}
+
+	s.Metarenditions[renditionID] = &Metarendition{
+		Path:     path,
+		VideoID:  video.ID,
+		Duration: duration,
+	}
+	s.register(renditionID, hashToStr(path), rendition)
+	s.put(metavideo, metavideoID.legacyID())
+
+	switch {
+	case !found:
+		events = append(events, Event{Kind: EventVideoAdded, Video: video})
+	case changed:
+		events = append(events, Event{Kind: EventVideoUpdated, Video: video})
+	}
+	return events, metadataErr, nil
+}
+
+// sameMetadata reports whether a survey left a video's metadata (as
+// opposed to its renditions) as it was.
+func sameMetadata(previous, metavideo *Metavideo) bool {
+	a, b := &previous.Video, &metavideo.Video
+	return a.Title == b.Title &&
+		a.SortTitle == b.SortTitle &&
+		equalStringPtr(a.Genre, b.Genre) &&
+		equalStringPtr(a.Description, b.Description) &&
+		equalStringPtr(a.ContentRating, b.ContentRating) &&
+		sameEpisode(a.Episode, b.Episode) &&
+		previous.HasArtwork == metavideo.HasArtwork &&
+		previous.Franchise == metavideo.Franchise &&
+		previous.FranchiseOrder == metavideo.FranchiseOrder
+}
+
+func sameEpisode(a, b *Episode) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.ID == b.ID && a.Season.ID == b.Season.ID && a.Season.Series.SortName == b.Season.Series.SortName
+}
+
+func equalStringPtr(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// prune removes renditions under root that were not seen by the
+// latest survey, and videos left without renditions.  Only for
+// unpublished snapshots.
+func (s *Snapshot) prune(root string, seen map[string]bool) []Event {
+	unseen := make(map[string]map[string]string)
+	for renditionID, metarendition := range s.Metarenditions {
+		if seen[renditionID] || !within(metarendition.Path, root) {
+			continue
+		}
+
+		delete(s.Metarenditions, renditionID)
+		delete(s.Nodes, renditionID)
+
+		if unseen[metarendition.VideoID] == nil {
+			unseen[metarendition.VideoID] = make(map[string]string)
+		}
+		unseen[metarendition.VideoID][renditionID] = metarendition.Path
+	}
+
+	var events []Event
+	for videoID, renditionPaths := range unseen {
+		if event := s.detach(videoID, renditionPaths); event != nil {
+			events = append(events, *event)
+		}
+	}
+
+	return events
 }
 
+// detach removes renditions (and their paths, keyed by ID) from a
+// video, and the video itself if left without renditions.  Only for
+// unpublished snapshots.
+func (s *Snapshot) detach(videoID string, renditionPaths map[string]string) *Event {
+	previous, ok := s.Metavideos[videoID]
+	if !ok {
+		return nil
+	}
+
+	var remaining []*Rendition
+	if previous.Video.Renditions != nil {
+		for _, rendition := range previous.Video.Renditions.All {
+			if _, ok := renditionPaths[rendition.ID]; !ok {
+				remaining = append(remaining, rendition)
+			}
+		}
+	}
+
+	if len(remaining) > 0 {
+		// copy-on-write
+		metavideo := *previous
+		metavideo.Video.Renditions = &Renditions{All: remaining}
+		// HasArtwork is refreshed by the next survey
+		metavideo.Path = s.Metarenditions[remaining[0].ID].Path
+		s.put(&metavideo, "")
+
+		return &Event{Kind: EventVideoUpdated, Video: &metavideo.Video}
+	}
+
+	delete(s.Metavideos, videoID)
+	delete(s.Nodes, videoID)
+	if episode := previous.Video.Episode; episode != nil {
+		delete(s.Nodes, episode.ID)
+		s.pruneSeason(episode.Season)
+	}
+
+	return &Event{Kind: EventVideoRemoved, Video: &previous.Video, VideoID: videoID, Paths: renditionPaths}
+}
+
+// within reports whether path is root or beneath it.
+func within(path, root string) bool {
+	relativePath, err := filepath.Rel(root, path)
+	if err != nil {
+		return false
+	}
+	return relativePath != ".." && !strings.HasPrefix(relativePath, ".."+string(filepath.Separator))
+}
+
+// pruneSeason removes a season without episodes, and its series if
+// left without seasons.  Only for unpublished snapshots.
+func (s *Snapshot) pruneSeason(season *Season) {
+	for _, metavideo := range s.Metavideos {
+		if episode := metavideo.Video.Episode; episode != nil && episode.Season == season {
+			return
+		}
+	}
+
+	seriesID := SeriesID(season.Series.Name)
+	delete(s.Seasons, SeasonID{seriesID, season.Season})
+	delete(s.Nodes, season.ID)
+
+	for _, other := range s.Seasons {
+		if other.Series == season.Series {
+			return
+		}
+	}
+
+	delete(s.Series, seriesID)
+	delete(s.Nodes, season.Series.ID)
+}
+
+// Watch periodically resurveys root until ctx is done.  A failed
+// survey (e.g., root briefly unmounted) is retried at the next
+// interval, as reported by SurveyErr meanwhile.
+func (l *Library) Watch(ctx context.Context, root string, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			// logged by Survey
+			l.Survey(ctx, root)
+		}
+	}
+}
+
+// hashToStr is the legacy rendition ID scheme.
 func hashToStr(payload string) string {
 	msg := sha256.Sum256([]byte(payload))
 	return base64.StdEncoding.EncodeToString(msg[:])
@@ -287,27 +674,90 @@ func qualityFromPath(path string) *Quality {
 	return q
 }
 
+// Metarendition looks up a rendition's local details by its ID.
+func (l *Library) Metarendition(id string) (*Metarendition, bool) {
+	s := l.Snapshot()
+
+	node, ok := s.node(id)
+	if !ok {
+		return nil, false
+	}
+
+	rendition, ok := node.(*Rendition)
+	if !ok {
+		return nil, false
+	}
+
+	metarendition, ok := s.Metarenditions[rendition.ID]
+	return metarendition, ok
+}
+
+// Duration returns the length of a video's longest rendition, if
+// known.
+func (l *Library) Duration(videoID string) (time.Duration, bool) {
+	s := l.Snapshot()
+	metavideo, ok := s.metavideo(videoID)
+	if !ok || metavideo.Video.Renditions == nil {
+		return 0, false
+	}
+
+	var longest time.Duration
+	for _, rendition := range metavideo.Video.Renditions.All {
+		if metarendition, ok := s.Metarenditions[rendition.ID]; ok && metarendition.Duration > longest {
+			longest = metarendition.Duration
+		}
+	}
+	return longest, longest > 0
+}
+
+// RenditionPath locates the file of a rendition.
+func (l *Library) RenditionPath(id string) (string, bool) {
+	metarendition, ok := l.Metarendition(id)
+	if !ok {
+		return "", false
+	}
+	return metarendition.Path, true
+}
+
+// RenditionVideoID names the video of a rendition.
+func (l *Library) RenditionVideoID(id string) (string, bool) {
+	metarendition, ok := l.Metarendition(id)
+	if !ok {
+		return "", false
+	}
+	return metarendition.VideoID, true
+}
+
+// RenditionIDs lists the renditions of a video.
+func (l *Library) RenditionIDs(videoID string) ([]string, bool) {
+	metavideo, ok := l.Snapshot().metavideo(videoID)
+	if !ok || metavideo.Video.Renditions == nil {
+		return nil, false
+	}
+
+	var ids []string
+	for _, rendition := range metavideo.Video.Renditions.All {
+		ids = append(ids, rendition.ID)
+	}
+	return ids, true
+}
+
 func (l *Library) Episodes(series *SeriesFilter, season *SeasonFilter) ([]*Episode, error) {
-	l.Mutex.Lock()
-	defer l.Mutex.Unlock()
+	s := l.Snapshot()
 
 	var matches []*Episode
-	for _, metavideo := range l.Metavideos {
+	for _, metavideo := range s.Metavideos {
 		episode := metavideo.Video.Episode
 		if episode == nil {
 			continue
 		}
 
-		if season != nil && season.Season != nil {
-			if episode.Season.Season != *season.Season {
-				continue
-			}
+		if !season.Matches(episode.Season) {
+			continue
 		}
 
-		if series != nil && series.Name != nil {
-			if episode.Season.Series.Name != *series.Name {
-				continue
-			}
+		if !series.Matches(episode.Season.Series) {
+			continue
 		}
 
 		matches = append(matches, episode)
@@ -315,3 +765,52 @@ func (l *Library) Episodes(series *SeriesFilter, season *SeasonFilter) ([]*Episo
 
 	return matches, nil
 }
+
+// SeriesByID looks up a series by its ID.
+func (l *Library) SeriesByID(id string) (*Series, bool) {
+	return l.Snapshot().seriesByID(id)
+}
+
+func (s *Snapshot) seriesByID(id string) (*Series, bool) {
+	node, ok := s.node(id)
+	if !ok {
+		return nil, false
+	}
+
+	series, ok := node.(*Series)
+	return series, ok
+}
+
+// Season looks up a season by its series ID and season number.
+func (l *Library) Season(seriesID string, number int) (*Season, bool) {
+	return l.Snapshot().season(seriesID, number)
+}
+
+func (s *Snapshot) season(seriesID string, number int) (*Season, bool) {
+	series, ok := s.seriesByID(seriesID)
+	if !ok {
+		return nil, false
+	}
+
+	season, ok := s.Seasons[SeasonID{SeriesID(series.Name), number}]
+	return season, ok
+}
+
+// Episode looks up an episode by its series ID, season number, and
+// episode number.
+func (l *Library) Episode(seriesID string, seasonNumber int, episodeNumber int) (*Episode, bool) {
+	s := l.Snapshot()
+
+	season, ok := s.season(seriesID, seasonNumber)
+	if !ok {
+		return nil, false
+	}
+
+	for _, episode := range s.indexed().episodes[season.ID] {
+		if episode.Episode == episodeNumber {
+			return episode, true
+		}
+	}
+
+	return nil, false
+}
@@ -0,0 +1,209 @@
+package model
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/idiomatic/tvql/metadata/mp4/mp4test"
+)
+
+func writeFixture(t *testing.T, path string, spec mp4test.Spec) {
+	t.Helper()
+	if err := mp4test.WriteFile(path, spec); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func survey(t *testing.T, l *Library, root string) []Event {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events := l.Events.Subscribe(ctx, 100)
+
+	if err := l.Survey(ctx, root); err != nil {
+		t.Fatal(err)
+	}
+
+	var published []Event
+	for {
+		select {
+		case event := <-events:
+			if event.Kind != EventScanProgress {
+				published = append(published, event)
+			}
+		default:
+			return published
+		}
+	}
+}
+
+func titles(l *Library) map[string]int {
+	counts := make(map[string]int)
+	for _, metavideo := range l.Snapshot().Metavideos {
+		counts[metavideo.Title]++
+	}
+	return counts
+}
+
+func TestSurveyRetitled(t *testing.T) {
+	root := t.TempDir()
+	path := filepath.Join(root, "HQ 1080p30", "Movie.m4v")
+	writeFixture(t, path, mp4test.Spec{Title: "Before", Day: "1999"})
+
+	l := NewLibrary()
+	survey(t, l, root)
+
+	writeFixture(t, path, mp4test.Spec{Title: "After", Day: "1999"})
+	events := survey(t, l, root)
+
+	if got := titles(l); len(got) != 1 || got["After"] != 1 {
+		t.Errorf("titles = %v, want only After", got)
+	}
+	kinds := make(map[EventKind]int)
+	for _, event := range events {
+		kinds[event.Kind]++
+	}
+	if kinds[EventVideoRemoved] != 1 || kinds[EventVideoAdded] != 1 {
+		t.Errorf("events = %v, want one removed and one added", kinds)
+	}
+}
+
+func TestSurveyRetitledSibling(t *testing.T) {
+	root := t.TempDir()
+	hq := filepath.Join(root, "HQ 1080p30", "Movie.m4v")
+	fast := filepath.Join(root, "Fast 480p30", "Movie.m4v")
+	writeFixture(t, hq, mp4test.Spec{Title: "Before", Day: "1999"})
+	writeFixture(t, fast, mp4test.Spec{Title: "Before", Day: "1999"})
+
+	l := NewLibrary()
+	survey(t, l, root)
+
+	writeFixture(t, hq, mp4test.Spec{Title: "After", Day: "1999"})
+	survey(t, l, root)
+
+	if got := titles(l); len(got) != 2 || got["Before"] != 1 || got["After"] != 1 {
+		t.Fatalf("titles = %v, want Before and After", got)
+	}
+	for _, metavideo := range l.Snapshot().Metavideos {
+		if n := len(metavideo.Renditions.All); n != 1 {
+			t.Errorf("%s has %d renditions, want 1", metavideo.Title, n)
+		}
+	}
+}
+
+func TestSurveyMetadataChanged(t *testing.T) {
+	root := t.TempDir()
+	path := filepath.Join(root, "Movie.m4v")
+	writeFixture(t, path, mp4test.Spec{Title: "Movie", Day: "1999", Rating: "PG"})
+
+	l := NewLibrary()
+	survey(t, l, root)
+	if events := survey(t, l, root); len(events) != 0 {
+		t.Errorf("unchanged resurvey published %v", events)
+	}
+
+	writeFixture(t, path, mp4test.Spec{Title: "Movie", Day: "1999", Rating: "R"})
+	events := survey(t, l, root)
+	if len(events) != 1 || events[0].Kind != EventVideoUpdated {
+		t.Fatalf("events = %v, want one update", events)
+	}
+	if rating := events[0].Video.ContentRating; rating == nil || *rating != "R" {
+		t.Errorf("contentRating = %v, want R", rating)
+	}
+}
+
+func TestSurveyPrunesOnlyWithinRoot(t *testing.T) {
+	parent := t.TempDir()
+	tv := filepath.Join(parent, "tv")
+	tv2 := filepath.Join(parent, "tv2")
+	writeFixture(t, filepath.Join(tv, "One.m4v"), mp4test.Spec{Title: "One", Day: "2001"})
+	writeFixture(t, filepath.Join(tv2, "Two.m4v"), mp4test.Spec{Title: "Two", Day: "2002"})
+
+	l := NewLibrary()
+	survey(t, l, tv)
+	survey(t, l, tv2)
+	survey(t, l, tv)
+
+	if got := titles(l); got["One"] != 1 || got["Two"] != 1 {
+		t.Errorf("titles = %v, want One and Two", got)
+	}
+}
+
+func TestWithin(t *testing.T) {
+	for _, test := range []struct {
+		path, root string
+		want       bool
+	}{
+		{"/media/tv/a.m4v", "/media/tv", true},
+		{"/media/tv/a.m4v", "/media/tv/", true},
+		{"/media/tv", "/media/tv", true},
+		{"/media/tv2/a.m4v", "/media/tv", false},
+		{"/media/a.m4v", "/media/tv", false},
+		{"a/b.m4v", ".", true},
+	} {
+		if got := within(test.path, test.root); got != test.want {
+			t.Errorf("within(%q, %q) = %v, want %v", test.path, test.root, got, test.want)
+		}
+	}
+}
+
+func TestSurveySkipsUnreadable(t *testing.T) {
+	if os.Geteuid() == 0 {
+		t.Skip("permissions do not bind root")
+	}
+	root := t.TempDir()
+	writeFixture(t, filepath.Join(root, "a", "One.m4v"), mp4test.Spec{Title: "One", Day: "2001"})
+	writeFixture(t, filepath.Join(root, "b", "Two.m4v"), mp4test.Spec{Title: "Two", Day: "2002"})
+
+	l := NewLibrary()
+	survey(t, l, root)
+
+	unreadable := filepath.Join(root, "b")
+	if err := os.Chmod(unreadable, 0); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chmod(unreadable, 0755) })
+	writeFixture(t, filepath.Join(root, "c", "Three.m4v"), mp4test.Spec{Title: "Three", Day: "2003"})
+
+	if events := survey(t, l, root); len(events) != 1 || events[0].Kind != EventVideoAdded {
+		t.Errorf("events %v, want Three added and nothing removed", events)
+	}
+	if got := titles(l); got["One"] != 1 || got["Two"] != 1 || got["Three"] != 1 {
+		t.Errorf("titles = %v, want One, Two, and Three", got)
+	}
+}
+
+func TestWatchOutlastsFailedSurvey(t *testing.T) {
+	root := filepath.Join(t.TempDir(), "unmounted")
+	l := NewLibrary()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- l.Watch(ctx, root, time.Millisecond)
+	}()
+
+	await := func(what string, cond func() bool) {
+		t.Helper()
+		for deadline := time.Now().Add(5 * time.Second); !cond(); time.Sleep(time.Millisecond) {
+			if time.Now().After(deadline) {
+				t.Fatalf("timed out awaiting %s", what)
+			}
+		}
+	}
+	await("a failed survey", func() bool { return l.SurveyErr() != nil })
+
+	writeFixture(t, filepath.Join(root, "One.m4v"), mp4test.Spec{Title: "One", Day: "2001"})
+	await("a survey", func() bool { return l.Surveyed() && l.SurveyErr() == nil })
+	if got := titles(l); got["One"] != 1 {
+		t.Errorf("titles = %v, want One", got)
+	}
+
+	cancel()
+	if err := <-done; err != context.Canceled {
+		t.Errorf("Watch returned %v, want context.Canceled", err)
+	}
+}
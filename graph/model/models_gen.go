@@ -8,6 +8,16 @@ import (
 	"strconv"
 )
 
+type CollectionMember interface {
+	IsCollectionMember()
+}
+
+// Identifiable object.
+// IDs are opaque, URL-safe, and stable across rescans.
+type Node interface {
+	IsNode()
+}
+
 // NYI
 type Contributor struct {
 	Name string `json:"name"`
@@ -20,6 +30,9 @@ type ContributorFilter struct {
 
 // Episode (i.e., TV Show) details.
 type Episode struct {
+	// Episode identity.
+	// Distinct from the identity of its video.
+	ID string `json:"id"`
 	// Season.
 	Season *Season `json:"season"`
 	// Episode number, within a season.
@@ -30,17 +43,40 @@ type Episode struct {
 	EpisodeID *string `json:"episodeID"`
 	// Video.
 	Video *Video `json:"video"`
+	// Following episode in the series, crossing seasons (optional).
+	Next *Episode `json:"next"`
+	// Preceding episode in the series, crossing seasons (optional).
+	Previous *Episode `json:"previous"`
 }
 
+func (Episode) IsCollectionMember() {}
+func (Episode) IsNode()             {}
+
 // Episode selection.
 type EpisodeFilter struct {
 	Episode *int `json:"episode"`
 }
 
 // Geometry selection.
+// Artwork is downsampled, never upsampled.
 type GeometryFilter struct {
-	Width  *int `json:"width"`
+	// Width, in CSS pixels (optional).
+	Width *int `json:"width"`
+	// Height, in CSS pixels (optional).
 	Height *int `json:"height"`
+	// How artwork fits both width and height (if specified); by default, FILL.
+	Fit *ArtworkFit `json:"fit"`
+	// Where artwork is cropped from (per COVER) or placed (per PAD); by default, CENTER.
+	Anchor *ArtworkAnchor `json:"anchor"`
+	// Padding color (per PAD), as "#rgb", "#rrggbb", or "#rrggbbaa"; by default, black.
+	// Alpha applies to PNG and WEBP only.
+	Background *string `json:"background"`
+	// Device pixel ratio, scaling width and height, 1 to 4 (optional).
+	Dpr *float64 `json:"dpr"`
+	// Encoding (optional); by default, that of the cover art.
+	Format *ArtworkFormat `json:"format"`
+	// Lossy encoding quality, 1 to 100 (optional).
+	Quality *int `json:"quality"`
 }
 
 // Select a slice of identifiable objects.
@@ -69,7 +105,7 @@ type QualityFilter struct {
 // Video rendition details.
 type Rendition struct {
 	// Rendition identity.
-	// Currently a hash of local path for idempotence.
+	// Currently a hash of path, relative to the library root, for idempotence.
 	ID string `json:"id"`
 	// Video rendition download URL.
 	URL string `json:"url"`
@@ -84,17 +120,37 @@ type Rendition struct {
 	// Is video high definition, i.e., 1080p?
 	// Currently obtained from the mp4 moov.udta.meta.ilst.hdvd.data atom.
 	IsHd *bool `json:"isHD"`
+	// Does the moov atom precede mdat, so playback may begin before download completes?
+	// Regardless, url serves the moov first, relocated on the fly.
+	IsStreamable bool `json:"isStreamable"`
 	// Size of the video, in bytes.
 	Size int `json:"size"`
 }
 
+func (Rendition) IsNode() {}
+
 type Renditions struct {
 	All       []*Rendition `json:"all"`
 	Rendition *Rendition   `json:"rendition"`
 }
 
+// Survey progress.
+type ScanProgress struct {
+	// Library root being surveyed.
+	Root string `json:"root"`
+	// Count of video files scanned so far.
+	FilesScanned int `json:"filesScanned"`
+	// Count of videos found so far.
+	Videos int `json:"videos"`
+	// Has the survey completed?
+	Done bool `json:"done"`
+}
+
 // Season details.
 type Season struct {
+	// Season identity.
+	// Currently a hash of series name + season number for idempotence.
+	ID string `json:"id"`
 	// Series.
 	Series *Series `json:"series"`
 	// Season number, within a series.
@@ -104,8 +160,12 @@ type Season struct {
 	Episodes []*Episode `json:"episodes"`
 	// Count of episodes in season.
 	EpisodeCount int `json:"episodeCount"`
+	// Count of episodes in season the selected profile has not watched.
+	UnwatchedCount int `json:"unwatchedCount"`
 }
 
+func (Season) IsNode() {}
+
 // Season selection.
 type SeasonFilter struct {
 	Season *int `json:"season"`
@@ -113,6 +173,9 @@ type SeasonFilter struct {
 
 // Series details.
 type Series struct {
+	// Series identity.
+	// Currently a hash of name for idempotence.
+	ID string `json:"id"`
 	// Series name.
 	// May include reboot qualifiers (e.g., "The Twilight Zone (2019)").
 	// Currently obtained from the mp4 moov.udta.meta.ilst.tvsh.data atom.
@@ -130,17 +193,25 @@ type Series struct {
 	Episodes []*Episode `json:"episodes"`
 	// Count of episodes, regardless of season.
 	EpisodeCount int `json:"episodeCount"`
+	// Episode the selected profile should watch next (optional).
+	// Resumes an episode in progress, else follows the most recently watched episode.
+	// Null once all remaining episodes are watched.
+	NextUp *Episode `json:"nextUp"`
 }
 
+func (Series) IsCollectionMember() {}
+func (Series) IsNode()             {}
+
 // Series selection.
 type SeriesFilter struct {
+	ID   *string `json:"id"`
 	Name *string `json:"name"`
 }
 
 // Video details.
 type Video struct {
 	// Video identity.
-	// Currently a hash of title + releaseYear for idempotence.
+	// Currently a hash of title + releaseYear (plus episode details, if any) for idempotence.
 	ID string `json:"id"`
 	// Title, in en-US, without cut or year parenthetical qualifiers.
 	// Currently obtained from the mp4 moov.udta.meta.ilst.©nam.data atom.
@@ -161,6 +232,14 @@ type Video struct {
 	// Filter by rendition quality (if specified).
 	// Null or empty list implies this video is a placeholder, and renditions are coming soon.
 	Renditions *Renditions `json:"renditions"`
+	// HLS master playlist URL (optional), listing each rendition as a variant.
+	// Segments are remuxed from the renditions on the fly.
+	// Null without renditions.
+	HlsURL *string `json:"hlsUrl"`
+	// DASH manifest URL (optional), listing each rendition as a representation.
+	// Shares segments with hlsUrl.
+	// Null without renditions.
+	DashURL *string `json:"dashUrl"`
 	// Cover art image (optional).
 	// Currently obtained from the mp4 moov.udta.meta.ilst.covr.data atom.
 	Artwork *Artwork `json:"artwork"`
@@ -176,12 +255,235 @@ type Video struct {
 	// Primary genre (optional).
 	// Currently obtained from the mp4 moov.udta.meta.ilst.©gen.data atom.
 	Genre *string `json:"genre"`
-	// Content advisory rating (optional).
+	// Content advisory rating (optional), e.g., "PG-13" or "TV-14".
+	// Currently obtained from the mp4 moov.udta.meta.ilst.----.iTunEXTC atom.
 	ContentRating *string `json:"contentRating"`
 	// Rotten Tomatoes reviewer score (optional).
 	Tomatometer *int `json:"tomatometer"`
 	// Episodic details (optional).
 	Episode *Episode `json:"episode"`
+	// Franchise, e.g., of sequels and prequels (optional).
+	Franchise *Franchise `json:"franchise"`
+	// Watch state of the selected profile (optional).
+	Progress *Progress `json:"progress"`
+	// Has the selected profile watched this video?
+	Watched bool `json:"watched"`
+}
+
+func (Video) IsNode()             {}
+func (Video) IsCollectionMember() {}
+
+// Video selection.
+type VideoFilter struct {
+	Title *string `json:"title"`
+	// Case-insensitive title substring.
+	TitleContains *string `json:"titleContains"`
+	Genre         *string `json:"genre"`
+	ContentRating *string `json:"contentRating"`
+	// Earliest release year, inclusive.
+	ReleasedFrom *int `json:"releasedFrom"`
+	// Latest release year, inclusive.
+	ReleasedUntil *int `json:"releasedUntil"`
+	// Episodes only (true) or movies only (false).
+	Episodic *bool `json:"episodic"`
+	// Episodes of matching series only.
+	Series *SeriesFilter `json:"series"`
+}
+
+// Artwork gravity, for cropping or padding.
+type ArtworkAnchor string
+
+const (
+	ArtworkAnchorCenter      ArtworkAnchor = "CENTER"
+	ArtworkAnchorTop         ArtworkAnchor = "TOP"
+	ArtworkAnchorBottom      ArtworkAnchor = "BOTTOM"
+	ArtworkAnchorLeft        ArtworkAnchor = "LEFT"
+	ArtworkAnchorRight       ArtworkAnchor = "RIGHT"
+	ArtworkAnchorTopLeft     ArtworkAnchor = "TOP_LEFT"
+	ArtworkAnchorTopRight    ArtworkAnchor = "TOP_RIGHT"
+	ArtworkAnchorBottomLeft  ArtworkAnchor = "BOTTOM_LEFT"
+	ArtworkAnchorBottomRight ArtworkAnchor = "BOTTOM_RIGHT"
+)
+
+var AllArtworkAnchor = []ArtworkAnchor{
+	ArtworkAnchorCenter,
+	ArtworkAnchorTop,
+	ArtworkAnchorBottom,
+	ArtworkAnchorLeft,
+	ArtworkAnchorRight,
+	ArtworkAnchorTopLeft,
+	ArtworkAnchorTopRight,
+	ArtworkAnchorBottomLeft,
+	ArtworkAnchorBottomRight,
+}
+
+func (e ArtworkAnchor) IsValid() bool {
+	switch e {
+	case ArtworkAnchorCenter, ArtworkAnchorTop, ArtworkAnchorBottom, ArtworkAnchorLeft, ArtworkAnchorRight, ArtworkAnchorTopLeft, ArtworkAnchorTopRight, ArtworkAnchorBottomLeft, ArtworkAnchorBottomRight:
+		return true
+	}
+	return false
+}
+
+func (e ArtworkAnchor) String() string {
+	return string(e)
+}
+
+func (e *ArtworkAnchor) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ArtworkAnchor(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ArtworkAnchor", str)
+	}
+	return nil
+}
+
+func (e ArtworkAnchor) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// How artwork fits a width and height.
+type ArtworkFit string
+
+const (
+	// Scale to fit within, preserving aspect ratio.
+	ArtworkFitContain ArtworkFit = "CONTAIN"
+	// Scale to cover, preserving aspect ratio, and crop the excess.
+	ArtworkFitCover ArtworkFit = "COVER"
+	// Scale to exactly, distorting aspect ratio (unless shrunk lest artwork be upsampled).
+	ArtworkFitFill ArtworkFit = "FILL"
+	// Scale to fit within, preserving aspect ratio, and pad with background.
+	ArtworkFitPad ArtworkFit = "PAD"
+)
+
+var AllArtworkFit = []ArtworkFit{
+	ArtworkFitContain,
+	ArtworkFitCover,
+	ArtworkFitFill,
+	ArtworkFitPad,
+}
+
+func (e ArtworkFit) IsValid() bool {
+	switch e {
+	case ArtworkFitContain, ArtworkFitCover, ArtworkFitFill, ArtworkFitPad:
+		return true
+	}
+	return false
+}
+
+func (e ArtworkFit) String() string {
+	return string(e)
+}
+
+func (e *ArtworkFit) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ArtworkFit(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ArtworkFit", str)
+	}
+	return nil
+}
+
+func (e ArtworkFit) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// Image encodings.
+// WEBP is available only if tvql was built with an encoder.
+type ArtworkFormat string
+
+const (
+	ArtworkFormatJpeg ArtworkFormat = "JPEG"
+	ArtworkFormatPng  ArtworkFormat = "PNG"
+	ArtworkFormatWebp ArtworkFormat = "WEBP"
+)
+
+var AllArtworkFormat = []ArtworkFormat{
+	ArtworkFormatJpeg,
+	ArtworkFormatPng,
+	ArtworkFormatWebp,
+}
+
+func (e ArtworkFormat) IsValid() bool {
+	switch e {
+	case ArtworkFormatJpeg, ArtworkFormatPng, ArtworkFormatWebp:
+		return true
+	}
+	return false
+}
+
+func (e ArtworkFormat) String() string {
+	return string(e)
+}
+
+func (e *ArtworkFormat) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ArtworkFormat(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ArtworkFormat", str)
+	}
+	return nil
+}
+
+func (e ArtworkFormat) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// Franchise video ordering.
+type FranchiseOrder string
+
+const (
+	// By release year.
+	FranchiseOrderRelease FranchiseOrder = "release"
+	// In-universe, per FRANCHISE ORDER metadata.
+	// Videos lacking an order follow, by release year.
+	FranchiseOrderChronological FranchiseOrder = "chronological"
+)
+
+var AllFranchiseOrder = []FranchiseOrder{
+	FranchiseOrderRelease,
+	FranchiseOrderChronological,
+}
+
+func (e FranchiseOrder) IsValid() bool {
+	switch e {
+	case FranchiseOrderRelease, FranchiseOrderChronological:
+		return true
+	}
+	return false
+}
+
+func (e FranchiseOrder) String() string {
+	return string(e)
+}
+
+func (e *FranchiseOrder) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = FranchiseOrder(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid FranchiseOrder", str)
+	}
+	return nil
+}
+
+func (e FranchiseOrder) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
 }
 
 // Amount of time and bitrate afforded to HandBrake transcode.
@@ -50,3 +50,30 @@ func (a ByEpisode) Less(i, j int) bool {
 	return a[i].Episode < a[j].Episode
 }
 func (a ByEpisode) Swap(i, j int) { a[i], a[j] = a[j], a[i] }
+
+// Matches reports whether a series satisfies the filter.  A nil filter
+// matches everything.
+func (f *SeriesFilter) Matches(series *Series) bool {
+	if f == nil || series == nil {
+		return f == nil
+	}
+	if f.ID != nil && series.ID != *f.ID {
+		return false
+	}
+	if f.Name != nil && series.Name != *f.Name {
+		return false
+	}
+	return true
+}
+
+// Matches reports whether a season satisfies the filter.  A nil filter
+// matches everything.
+func (f *SeasonFilter) Matches(season *Season) bool {
+	if f == nil || season == nil {
+		return f == nil
+	}
+	if f.Season != nil && season.Season != *f.Season {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,138 @@
+package model
+
+import (
+	"sort"
+	"time"
+)
+
+// AdjacentEpisode returns the episode offset (e.g., +1 for next, -1
+// for previous) from the identified one within its series, crossing
+// season boundaries.
+func (l *Library) AdjacentEpisode(episodeID string, offset int) (*Episode, bool) {
+	s := l.Snapshot()
+	index := s.indexed()
+
+	node, ok := s.node(episodeID)
+	if !ok {
+		return nil, false
+	}
+	episode, ok := node.(*Episode)
+	if !ok {
+		return nil, false
+	}
+
+	i, ok := index.positions[episode.ID]
+	if !ok {
+		return nil, false
+	}
+
+	episodes := index.episodes[episode.Season.Series.ID]
+	i += offset
+	if i < 0 || i >= len(episodes) {
+		return nil, false
+	}
+	return episodes[i], true
+}
+
+// NextUp returns the episode of a series to watch next, per watch
+// state keyed by video ID, and when the series was last watched (zero
+// if never).  Only episodes permitted (if permits is non-nil) count.
+//
+// An episode in progress is resumed; otherwise, the first unwatched
+// episode after the most recently watched one is next.
+func (l *Library) NextUp(seriesID string, progress map[string]Progress, permits func(video *Video) bool) (*Episode, time.Time, bool) {
+	s := l.Snapshot()
+
+	series, ok := s.seriesByID(seriesID)
+	if !ok {
+		return nil, time.Time{}, false
+	}
+
+	return s.nextUp(series, progress, permits)
+}
+
+func (s *Snapshot) nextUp(series *Series, progress map[string]Progress, permits func(video *Video) bool) (*Episode, time.Time, bool) {
+	episodes := s.indexed().episodes[series.ID]
+	if permits != nil {
+		var permitted []*Episode
+		for _, episode := range episodes {
+			if permits(episode.Video) {
+				permitted = append(permitted, episode)
+			}
+		}
+		episodes = permitted
+	}
+
+	latest := -1
+	var lastWatched time.Time
+	for i, episode := range episodes {
+		if p, ok := progress[episode.Video.ID]; ok && p.LastWatched.After(lastWatched) {
+			latest = i
+			lastWatched = p.LastWatched
+		}
+	}
+
+	if latest != -1 && !progress[episodes[latest].Video.ID].Watched {
+		return episodes[latest], lastWatched, true
+	}
+
+	for _, episode := range episodes[latest+1:] {
+		if !progress[episode.Video.ID].Watched {
+			return episode, lastWatched, true
+		}
+	}
+
+	return nil, lastWatched, false
+}
+
+// ContinueWatching returns partially watched movies and the next-up
+// episode of each series underway, most recently watched first.  Only
+// videos permitted (if permits is non-nil) count.
+func (l *Library) ContinueWatching(progress map[string]Progress, permits func(video *Video) bool) []*Video {
+	s := l.Snapshot()
+
+	type candidate struct {
+		video       *Video
+		lastWatched time.Time
+	}
+	var candidates []candidate
+
+	seriesSeen := make(map[string]bool)
+	for videoID, p := range progress {
+		metavideo, ok := s.Metavideos[videoID]
+		if !ok {
+			continue
+		}
+
+		episode := metavideo.Video.Episode
+		if episode == nil {
+			if permits != nil && !permits(&metavideo.Video) {
+				continue
+			}
+			if p.Seconds > 0 && !p.Watched {
+				candidates = append(candidates, candidate{&metavideo.Video, p.LastWatched})
+			}
+			continue
+		}
+
+		series := episode.Season.Series
+		if seriesSeen[series.ID] {
+			continue
+		}
+		seriesSeen[series.ID] = true
+
+		if next, lastWatched, ok := s.nextUp(series, progress, permits); ok {
+			candidates = append(candidates, candidate{next.Video, lastWatched})
+		}
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].lastWatched.After(candidates[j].lastWatched)
+	})
+
+	videos := make([]*Video, len(candidates))
+	for i, c := range candidates {
+		videos[i] = c.video
+	}
+	return videos
+}
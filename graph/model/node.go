@@ -0,0 +1,92 @@
+package model
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+)
+
+// NodeKind tags a global ID with the type of object it identifies.
+type NodeKind byte
+
+const (
+	NodeKindVideo      NodeKind = 'v'
+	NodeKindSeries     NodeKind = 's'
+	NodeKindSeason     NodeKind = 'n'
+	NodeKindEpisode    NodeKind = 'e'
+	NodeKindRendition  NodeKind = 'r'
+	NodeKindProfile    NodeKind = 'p'
+	NodeKindCollection NodeKind = 'c'
+	NodeKindFranchise  NodeKind = 'f'
+)
+
+const globalIDHashSize = 15
+
+// NewGlobalID returns an opaque, URL-safe ID for an object of the
+// given kind.  The key is the object's natural identity (e.g., title
+// + release year), so the ID is stable across rescans.
+//
+// note: exposed to client
+func NewGlobalID(kind NodeKind, key string) string {
+	msg := sha256.Sum256([]byte(key))
+	payload := append([]byte{byte(kind)}, msg[:globalIDHashSize]...)
+	return base64.RawURLEncoding.EncodeToString(payload)
+}
+
+// ParseGlobalID returns the kind of object a global ID identifies.
+func ParseGlobalID(id string) (NodeKind, error) {
+	payload, err := base64.RawURLEncoding.DecodeString(id)
+	if err != nil || len(payload) != 1+globalIDHashSize {
+		return 0, fmt.Errorf("malformed id %q", id)
+	}
+
+	kind := NodeKind(payload[0])
+	switch kind {
+	case NodeKindVideo, NodeKindSeries, NodeKindSeason, NodeKindEpisode, NodeKindRendition, NodeKindProfile, NodeKindCollection, NodeKindFranchise:
+		return kind, nil
+	}
+
+	return 0, fmt.Errorf("unknown id kind %q", id)
+}
+
+// Node looks up any identifiable object by its global ID.  Legacy IDs
+// (e.g., "Title (1999)") are also accepted.
+func (l *Library) Node(id string) (Node, bool) {
+	s := l.Snapshot()
+	if node, ok := s.node(id); ok {
+		return node, true
+	}
+
+	// derived, so only in the index
+	franchise, ok := s.indexed().franchises[id]
+	return franchise, ok
+}
+
+func (s *Snapshot) node(id string) (Node, bool) {
+	if _, err := ParseGlobalID(id); err != nil {
+		legacyID, ok := s.LegacyIDs[id]
+		if !ok {
+			return nil, false
+		}
+		id = legacyID
+	}
+
+	node, ok := s.Nodes[id]
+	return node, ok
+}
+
+// metavideo looks up a video's local details by video ID.
+func (s *Snapshot) metavideo(id string) (*Metavideo, bool) {
+	node, ok := s.node(id)
+	if !ok {
+		return nil, false
+	}
+
+	video, ok := node.(*Video)
+	if !ok {
+		return nil, false
+	}
+
+	metavideo, ok := s.Metavideos[video.ID]
+	return metavideo, ok
+}
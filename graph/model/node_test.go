@@ -0,0 +1,73 @@
+package model
+
+import (
+	"encoding/base64"
+	"path/filepath"
+	"testing"
+
+	"github.com/idiomatic/tvql/metadata/mp4/mp4test"
+)
+
+func TestGlobalIDRoundTrip(t *testing.T) {
+	kinds := []NodeKind{NodeKindVideo, NodeKindSeries, NodeKindSeason, NodeKindEpisode, NodeKindRendition, NodeKindProfile, NodeKindCollection, NodeKindFranchise}
+	seen := make(map[string]bool)
+	for _, kind := range kinds {
+		id := NewGlobalID(kind, "Heat (1995)")
+		if seen[id] {
+			t.Errorf("%c: id %s collides", kind, id)
+		}
+		seen[id] = true
+
+		if id != NewGlobalID(kind, "Heat (1995)") {
+			t.Errorf("%c: id unstable", kind)
+		}
+		if got, err := ParseGlobalID(id); err != nil || got != kind {
+			t.Errorf("%c: parsed %c, %v", kind, got, err)
+		}
+	}
+}
+
+func TestParseGlobalIDMalformed(t *testing.T) {
+	unknown := base64.RawURLEncoding.EncodeToString(append([]byte{'x'}, make([]byte, globalIDHashSize)...))
+	for _, id := range []string{
+		"",
+		"Heat (1995)",
+		NewGlobalID(NodeKindVideo, "Heat (1995)")[1:],
+		NewGlobalID(NodeKindVideo, "Heat (1995)") + "AA",
+		unknown,
+	} {
+		if _, err := ParseGlobalID(id); err == nil {
+			t.Errorf("%q: parsed", id)
+		}
+	}
+}
+
+func TestNodeLegacyID(t *testing.T) {
+	root := t.TempDir()
+	writeFixture(t, filepath.Join(root, "HQ 1080p30", "Heat.m4v"), mp4test.Spec{Title: "Heat", Day: "1995"})
+	writeFixture(t, filepath.Join(root, "HQ 1080p30", "Pilot.m4v"), mp4test.Spec{Title: "Pilot", Day: "2001", Show: "The Show", Season: 1, Episode: 1})
+
+	l := NewLibrary()
+	survey(t, l, root)
+
+	ids := make(map[string]string)
+	for id, metavideo := range l.Snapshot().Metavideos {
+		ids[metavideo.Title] = id
+	}
+	for legacyID, title := range map[string]string{
+		"Heat (1995)":               "Heat",
+		"The Show 101 Pilot (2001)": "Pilot",
+		"Nobody (1995)":             "",
+	} {
+		node, ok := l.Node(legacyID)
+		if title == "" {
+			if ok {
+				t.Errorf("%q: found %v", legacyID, node)
+			}
+			continue
+		}
+		if want, _ := l.Node(ids[title]); !ok || node != want {
+			t.Errorf("%q: %v, want %v", legacyID, node, want)
+		}
+	}
+}
@@ -0,0 +1,285 @@
+package model
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+const defaultProfileName = "default"
+
+// defaultSaveDelay coalesces the writes of playback positions, which
+// players report every few seconds.
+const defaultSaveDelay = 10 * time.Second
+
+// Profile is a viewer, with their own watch state.
+type Profile struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	// see only videos rated at most this (optional)
+	MaxContentRating *string `json:"maxContentRating,omitempty"`
+	// see only videos under these directories (optional)
+	Roots []string `json:"roots,omitempty"`
+}
+
+// Progress is a profile's watch state for one video.
+type Progress struct {
+	Seconds     int       `json:"seconds"`
+	Watched     bool      `json:"watched"`
+	LastWatched time.Time `json:"lastWatched"`
+}
+
+// Profiles persists profiles and their watch state to a JSON file.
+type Profiles struct {
+	path  string
+	mutex sync.RWMutex
+	state profilesState
+	// incremented whenever profiles change
+	version uint64
+	// incremented whenever watch state changes, keyed by profile id
+	watchVersions map[string]uint64
+	// unsaved changes are written within saveDelay
+	saveDelay time.Duration
+	pending   *time.Timer
+}
+
+type profilesState struct {
+	Profiles map[string]*Profile `json:"profiles"`
+	// keyed by profile id, then video id
+	Progress map[string]map[string]Progress `json:"progress"`
+}
+
+// OpenProfiles loads profiles from path, if it exists.
+func OpenProfiles(path string) (*Profiles, error) {
+	p := &Profiles{
+		path:          path,
+		watchVersions: make(map[string]uint64),
+		saveDelay:     defaultSaveDelay,
+		state: profilesState{
+			Profiles: make(map[string]*Profile),
+			Progress: make(map[string]map[string]Progress),
+		},
+	}
+
+	if err := readState(path, &p.state); err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// save writes state atomically.  Caller must hold the write lock.
+func (p *Profiles) save() error {
+	if err := writeState(p.path, &p.state); err != nil {
+		return err
+	}
+
+	if p.pending != nil {
+		p.pending.Stop()
+		p.pending = nil
+	}
+	return nil
+}
+
+// saveLater writes state within saveDelay, retrying upon failure.
+// Caller must hold the write lock.
+func (p *Profiles) saveLater() {
+	if p.pending != nil {
+		return
+	}
+
+	var timer *time.Timer
+	timer = time.AfterFunc(p.saveDelay, func() {
+		p.mutex.Lock()
+		defer p.mutex.Unlock()
+
+		if p.pending != timer {
+			// saved since
+			return
+		}
+		p.pending = nil
+		if err := p.save(); err != nil {
+			p.saveLater()
+		}
+	})
+	p.pending = timer
+}
+
+// Flush writes any unsaved state, e.g., upon shutdown.
+func (p *Profiles) Flush() error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.pending == nil {
+		return nil
+	}
+	return p.save()
+}
+
+// Version changes whenever profiles, or the watch state of a profile
+// (else the default profile), change.
+func (p *Profiles) Version(profileID string) uint64 {
+	if profileID == "" {
+		profileID = DefaultProfileID()
+	}
+
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+
+	return p.version + p.watchVersions[profileID]
+}
+
+func (p *Profiles) Create(name string) (*Profile, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	return p.create(name)
+}
+
+func (p *Profiles) create(name string) (*Profile, error) {
+	profile := &Profile{
+		ID:   NewGlobalID(NodeKindProfile, name),
+		Name: name,
+	}
+	if _, ok := p.state.Profiles[profile.ID]; ok {
+		return nil, fmt.Errorf("profile %q exists", name)
+	}
+
+	p.state.Profiles[profile.ID] = profile
+	p.version++
+	if err := p.save(); err != nil {
+		delete(p.state.Profiles, profile.ID)
+		return nil, err
+	}
+
+	return profile, nil
+}
+
+func (p *Profiles) Get(id string) (*Profile, bool) {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+
+	profile, ok := p.state.Profiles[id]
+	return profile, ok
+}
+
+// DefaultProfileID identifies the profile used when none is selected.
+func DefaultProfileID() string {
+	return NewGlobalID(NodeKindProfile, defaultProfileName)
+}
+
+// Default returns the profile used when none is selected, creating
+// it upon first use.
+func (p *Profiles) Default() (*Profile, error) {
+	id := DefaultProfileID()
+	if profile, ok := p.Get(id); ok {
+		return profile, nil
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if profile, ok := p.state.Profiles[id]; ok {
+		return profile, nil
+	}
+	return p.create(defaultProfileName)
+}
+
+// All returns profiles ordered by name.
+func (p *Profiles) All() []*Profile {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+
+	var profiles []*Profile
+	for _, profile := range p.state.Profiles {
+		profiles = append(profiles, profile)
+	}
+	sort.Slice(profiles, func(i, j int) bool { return profiles[i].Name < profiles[j].Name })
+	return profiles
+}
+
+// Progress returns a profile's watch state for a video, if any.
+func (p *Profiles) Progress(profileID string, videoID string) (*Progress, bool) {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+
+	progress, ok := p.state.Progress[profileID][videoID]
+	if !ok {
+		return nil, false
+	}
+	return &progress, true
+}
+
+// WatchState returns a copy of a profile's watch state, keyed by
+// video ID.
+func (p *Profiles) WatchState(profileID string) map[string]Progress {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+
+	state := make(map[string]Progress, len(p.state.Progress[profileID]))
+	for videoID, progress := range p.state.Progress[profileID] {
+		state[videoID] = progress
+	}
+	return state
+}
+
+// ReportProgress records a playback position.  Positions are saved
+// lazily, as they are frequent and expendable.
+func (p *Profiles) ReportProgress(profileID string, videoID string, seconds int) (*Progress, error) {
+	if seconds < 0 {
+		return nil, fmt.Errorf("seconds negative")
+	}
+
+	return p.update(profileID, videoID, true, func(progress *Progress) {
+		progress.Seconds = seconds
+	})
+}
+
+// MarkWatched records completion (or its absence).
+func (p *Profiles) MarkWatched(profileID string, videoID string, watched bool) (*Progress, error) {
+	return p.update(profileID, videoID, false, func(progress *Progress) {
+		progress.Watched = watched
+		if !watched {
+			progress.Seconds = 0
+		}
+	})
+}
+
+func (p *Profiles) update(profileID string, videoID string, lazy bool, fn func(progress *Progress)) (*Progress, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if _, ok := p.state.Profiles[profileID]; !ok {
+		return nil, fmt.Errorf("profile not found")
+	}
+
+	progresses, ok := p.state.Progress[profileID]
+	if !ok {
+		progresses = make(map[string]Progress)
+		p.state.Progress[profileID] = progresses
+	}
+
+	previous, existed := progresses[videoID]
+	progress := previous
+	fn(&progress)
+	progress.LastWatched = time.Now()
+	progresses[videoID] = progress
+	p.watchVersions[profileID]++
+
+	if lazy {
+		p.saveLater()
+		return &progress, nil
+	}
+
+	if err := p.save(); err != nil {
+		if existed {
+			progresses[videoID] = previous
+		} else {
+			delete(progresses, videoID)
+		}
+		return nil, err
+	}
+
+	return &progress, nil
+}
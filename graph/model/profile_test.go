@@ -0,0 +1,130 @@
+package model
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestProfilesVersionPerProfile(t *testing.T) {
+	p, err := OpenProfiles(filepath.Join(t.TempDir(), "profiles.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	kid, err := p.Create("kid")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	before := p.Version("")
+	kidBefore := p.Version(kid.ID)
+	if _, err := p.ReportProgress(kid.ID, "video", 10); err != nil {
+		t.Fatal(err)
+	}
+	if p.Version("") != before {
+		t.Error("another profile's progress changed the default profile's version")
+	}
+	if p.Version(kid.ID) == kidBefore {
+		t.Error("progress did not change the profile's version")
+	}
+
+	if _, err := p.Create("guest"); err != nil {
+		t.Fatal(err)
+	}
+	if p.Version("") == before {
+		t.Error("creating a profile did not change the version")
+	}
+}
+
+func TestReportProgressSavesLazily(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "profiles.json")
+	p, err := OpenProfiles(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p.saveDelay = time.Hour
+	profile, err := p.Default()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	saved := func() map[string]Progress {
+		t.Helper()
+		reopened, err := OpenProfiles(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return reopened.WatchState(profile.ID)
+	}
+
+	if _, err := p.ReportProgress(profile.ID, "a", 10); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := p.ReportProgress(profile.ID, "a", 20); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := saved()["a"]; ok {
+		t.Error("progress saved eagerly")
+	}
+	if progress, _ := p.Progress(profile.ID, "a"); progress == nil || progress.Seconds != 20 {
+		t.Errorf("progress = %v, want 20 seconds", progress)
+	}
+
+	if _, err := p.MarkWatched(profile.ID, "b", true); err != nil {
+		t.Fatal(err)
+	}
+	if state := saved(); state["a"].Seconds != 20 || !state["b"].Watched {
+		t.Errorf("saved state = %v, want both", state)
+	}
+
+	if _, err := p.ReportProgress(profile.ID, "a", 30); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	if state := saved(); state["a"].Seconds != 30 {
+		t.Errorf("flushed state = %v, want 30 seconds", state)
+	}
+}
+
+func TestReportProgressSavesEventually(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "profiles.json")
+	p, err := OpenProfiles(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p.saveDelay = time.Millisecond
+	profile, err := p.Default()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := p.ReportProgress(profile.ID, "a", 10); err != nil {
+		t.Fatal(err)
+	}
+	for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline); time.Sleep(time.Millisecond) {
+		reopened, err := OpenProfiles(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if progress, ok := reopened.Progress(profile.ID, "a"); ok && progress.Seconds == 10 {
+			return
+		}
+	}
+	t.Error("progress never saved")
+}
+
+func TestReportProgressNegative(t *testing.T) {
+	p, err := OpenProfiles(filepath.Join(t.TempDir(), "profiles.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	profile, err := p.Default()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := p.ReportProgress(profile.ID, "a", -1); err == nil {
+		t.Error("negative seconds accepted")
+	}
+}
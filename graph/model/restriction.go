@@ -0,0 +1,130 @@
+package model
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// contentRatingRanks orders film and TV advisory ratings on one scale.
+var contentRatingRanks = map[string]int{
+	"TV-Y":  0,
+	"TV-Y7": 1,
+	"G":     1,
+	"TV-G":  1,
+	"PG":    2,
+	"TV-PG": 2,
+	"PG-13": 3,
+	"TV-14": 3,
+	"R":     4,
+	"TV-MA": 4,
+	"NC-17": 5,
+}
+
+// contentRatingRank ranks a rating, else reports it unknown.
+func contentRatingRank(rating string) (int, bool) {
+	rank, ok := contentRatingRanks[strings.ToUpper(strings.TrimSpace(rating))]
+	return rank, ok
+}
+
+// Restricted reports whether the profile sees less than everything.
+func (p *Profile) Restricted() bool {
+	return p.MaxContentRating != nil || len(p.Roots) != 0
+}
+
+// Permits reports whether the profile may see a video.  Restricted
+// profiles see neither unrated videos nor those without renditions
+// under their roots.
+func (l *Library) Permits(p *Profile, video *Video) bool {
+	return permits(p, video, l.RenditionPath)
+}
+
+// PermitsEvent reports whether the profile may learn of a video event.
+// Removed videos are judged by where their renditions were.
+func (l *Library) PermitsEvent(p *Profile, event Event) bool {
+	if event.Kind != EventVideoRemoved {
+		return l.Permits(p, event.Video)
+	}
+
+	return permits(p, event.Video, func(renditionID string) (string, bool) {
+		path, ok := event.Paths[renditionID]
+		return path, ok
+	})
+}
+
+// permits is Permits, locating renditions via renditionPath.
+func permits(p *Profile, video *Video, renditionPath func(renditionID string) (string, bool)) bool {
+	if p == nil || !p.Restricted() {
+		return true
+	}
+
+	if p.MaxContentRating != nil {
+		max, _ := contentRatingRank(*p.MaxContentRating)
+		if video.ContentRating == nil {
+			return false
+		}
+		rank, ok := contentRatingRank(*video.ContentRating)
+		if !ok || rank > max {
+			return false
+		}
+	}
+
+	if len(p.Roots) != 0 {
+		if video.Renditions == nil {
+			return false
+		}
+		for _, rendition := range video.Renditions.All {
+			path, ok := renditionPath(rendition.ID)
+			if ok && underRoots(path, p.Roots) {
+				return true
+			}
+		}
+		return false
+	}
+
+	return true
+}
+
+// underRoots reports whether path lies within any of roots.
+func underRoots(path string, roots []string) bool {
+	for _, root := range roots {
+		if rel, err := filepath.Rel(filepath.Clean(root), path); err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return true
+		}
+	}
+	return false
+}
+
+// Restrict limits what a profile sees, by maximum content rating
+// and/or library directories.  Nil or empty lifts a restriction.
+func (p *Profiles) Restrict(profileID string, maxContentRating *string, roots []string) (*Profile, error) {
+	if maxContentRating != nil {
+		if *maxContentRating == "" {
+			maxContentRating = nil
+		} else if _, ok := contentRatingRank(*maxContentRating); !ok {
+			return nil, fmt.Errorf("unknown content rating %q", *maxContentRating)
+		}
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	previous, ok := p.state.Profiles[profileID]
+	if !ok {
+		return nil, fmt.Errorf("profile not found")
+	}
+
+	// copy-on-write, as readers hold profiles unlocked
+	profile := *previous
+	profile.MaxContentRating = maxContentRating
+	profile.Roots = roots
+	p.state.Profiles[profileID] = &profile
+	p.version++
+
+	if err := p.save(); err != nil {
+		p.state.Profiles[profileID] = previous
+		return nil, err
+	}
+
+	return &profile, nil
+}
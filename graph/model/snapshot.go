@@ -0,0 +1,101 @@
+package model
+
+import (
+	"sync"
+)
+
+// Snapshot is an immutable view of the library.
+//
+// Objects reachable from a published snapshot are never modified.
+// Surveys instead build a successor snapshot, replacing changed
+// objects with copies, and swap it in atomically, so readers need no
+// locks.
+type Snapshot struct {
+	Metavideos     map[string]*Metavideo
+	Series         map[SeriesID]*Series
+	Seasons        map[SeasonID]*Season
+	Metarenditions map[string]*Metarendition
+	Nodes          map[string]Node
+	LegacyIDs      map[string]string
+	// incremented whenever videos change
+	Version uint64
+
+	indexOnce sync.Once
+	index     *libraryIndex
+}
+
+func newSnapshot() *Snapshot {
+	return &Snapshot{
+		Metavideos:     make(map[string]*Metavideo),
+		Series:         make(map[SeriesID]*Series),
+		Seasons:        make(map[SeasonID]*Season),
+		Metarenditions: make(map[string]*Metarendition),
+		Nodes:          make(map[string]Node),
+		LegacyIDs:      make(map[string]string),
+	}
+}
+
+// RenditionBytes is the total size of all renditions.
+func (s *Snapshot) RenditionBytes() int64 {
+	var total int64
+	for id := range s.Metarenditions {
+		if rendition, ok := s.Nodes[id].(*Rendition); ok {
+			total += int64(rendition.Size)
+		}
+	}
+	return total
+}
+
+// clone returns an unpublished successor, sharing all objects.
+func (s *Snapshot) clone() *Snapshot {
+	c := newSnapshot()
+	c.Version = s.Version
+	for k, v := range s.Metavideos {
+		c.Metavideos[k] = v
+	}
+	for k, v := range s.Series {
+		c.Series[k] = v
+	}
+	for k, v := range s.Seasons {
+		c.Seasons[k] = v
+	}
+	for k, v := range s.Metarenditions {
+		c.Metarenditions[k] = v
+	}
+	for k, v := range s.Nodes {
+		c.Nodes[k] = v
+	}
+	for k, v := range s.LegacyIDs {
+		c.LegacyIDs[k] = v
+	}
+	return c
+}
+
+// register indexes a node by global ID, and optionally by legacy ID.
+// Only for unpublished snapshots.
+func (s *Snapshot) register(id string, legacyID string, node Node) {
+	s.Nodes[id] = node
+	if legacyID != "" {
+		s.LegacyIDs[legacyID] = id
+	}
+}
+
+// put indexes a new metavideo, repointing a copy of its episode at
+// it.  Only for unpublished snapshots.
+func (s *Snapshot) put(metavideo *Metavideo, legacyID string) {
+	video := &metavideo.Video
+	if video.Episode != nil {
+		episode := *video.Episode
+		episode.Video = video
+		video.Episode = &episode
+		s.register(episode.ID, "", &episode)
+	}
+
+	s.Metavideos[video.ID] = metavideo
+	s.register(video.ID, legacyID, video)
+}
+
+// Snapshot returns the current view of the library.
+func (l *Library) Snapshot() *Snapshot {
+	return l.current.Load().(*Snapshot)
+}
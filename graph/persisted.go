@@ -0,0 +1,114 @@
+package graph
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/99designs/gqlgen/graphql/errcode"
+	"github.com/99designs/gqlgen/graphql/handler/lru"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+const errPersistedQueryNotAllowed = "PERSISTED_QUERY_NOT_ALLOWED"
+
+// PersistedQueries is an automatic persisted query cache, preloaded
+// with known queries.
+//
+// In allowlist mode, only preloaded queries are served; clients may
+// neither register nor send other queries.
+type PersistedQueries struct {
+	known     map[string]string
+	learned   graphql.Cache
+	allowlist bool
+}
+
+var _ interface {
+	graphql.Cache
+	graphql.HandlerExtension
+	graphql.OperationParameterMutator
+} = &PersistedQueries{}
+
+// NewPersistedQueries loads one query document per file matching the
+// patterns (e.g., "examples/*.graphql"), addressed by SHA-256.
+func NewPersistedQueries(allowlist bool, patterns ...string) (*PersistedQueries, error) {
+	p := &PersistedQueries{
+		known:     make(map[string]string),
+		learned:   lru.New(1000),
+		allowlist: allowlist,
+	}
+
+	for _, pattern := range patterns {
+		paths, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, path := range paths {
+			query, err := ioutil.ReadFile(path)
+			if err != nil {
+				return nil, err
+			}
+			p.known[queryHash(string(query))] = string(query)
+		}
+	}
+
+	return p, nil
+}
+
+func (p *PersistedQueries) Get(ctx context.Context, hash string) (interface{}, bool) {
+	if query, ok := p.known[hash]; ok {
+		return query, true
+	}
+	if p.allowlist {
+		return nil, false
+	}
+	return p.learned.Get(ctx, hash)
+}
+
+func (p *PersistedQueries) Add(ctx context.Context, hash string, query interface{}) {
+	if p.allowlist {
+		return
+	}
+	p.learned.Add(ctx, hash, query)
+}
+
+// Known reports whether a query was preloaded, rather than sent or
+// registered by a client.
+func (p *PersistedQueries) Known(query string) bool {
+	_, ok := p.known[queryHash(query)]
+	return ok
+}
+
+func (PersistedQueries) ExtensionName() string {
+	return "PersistedQueryAllowlist"
+}
+
+func (PersistedQueries) Validate(schema graphql.ExecutableSchema) error {
+	return nil
+}
+
+// MutateOperationParameters rejects unknown queries in allowlist
+// mode.  Must follow extension.AutomaticPersistedQuery, which
+// resolves hashes to queries.
+func (p *PersistedQueries) MutateOperationParameters(ctx context.Context, rawParams *graphql.RawParams) *gqlerror.Error {
+	if !p.allowlist {
+		return nil
+	}
+
+	if !p.Known(rawParams.Query) {
+		err := gqlerror.Errorf("only persisted queries are allowed")
+		errcode.Set(err, errPersistedQueryNotAllowed)
+		return err
+	}
+
+	return nil
+}
+
+func queryHash(query string) string {
+	msg := sha256.Sum256([]byte(query))
+	return hex.EncodeToString(msg[:])
+}
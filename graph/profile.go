@@ -0,0 +1,100 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/idiomatic/tvql/auth"
+	"github.com/idiomatic/tvql/graph/model"
+)
+
+type profileContextKey struct{}
+
+// ProfileHeader selects the viewer profile of a request.
+const ProfileHeader = "X-Profile"
+
+// ProfileParam binds a signed stream URL to the viewer profile it was
+// issued to.
+const ProfileParam = "profile"
+
+// WithProfileHeader carries the selected profile ID, if any, in the
+// request context.
+func WithProfileHeader(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if id := r.Header.Get(ProfileHeader); id != "" {
+			r = r.WithContext(context.WithValue(r.Context(), profileContextKey{}, id))
+		}
+		h.ServeHTTP(w, r)
+	})
+}
+
+// SelectedProfile returns the ID of the profile selected by header or
+// bound to the principal, else "" (i.e., the default profile).
+func SelectedProfile(ctx context.Context) string {
+	id, _ := selectedProfile(ctx)
+	return id
+}
+
+// selectedProfile is like SelectedProfile, but refuses principals
+// other than administrators selecting another profile than their own.
+func selectedProfile(ctx context.Context) (string, error) {
+	id, _ := ctx.Value(profileContextKey{}).(string)
+	if principal, ok := auth.PrincipalFrom(ctx); ok && !principal.Admin {
+		// unbound, the default profile
+		own := principal.Profile
+		if own == "" {
+			own = model.DefaultProfileID()
+		}
+		if id != "" && id != own {
+			return principal.Profile, fmt.Errorf("profile not selectable: %w", ErrForbidden)
+		}
+		id = principal.Profile
+	}
+	return id, nil
+}
+
+// profile returns the selected profile, else the default profile.
+// Only administrators may select any profile; others get their own
+// (i.e., bound) profile, else the default profile.
+func (r *Resolver) profile(ctx context.Context) (*model.Profile, error) {
+	id, err := selectedProfile(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if id == "" {
+		return r.profiles.Default()
+	}
+
+	profile, ok := r.profiles.Get(id)
+	if !ok {
+		return nil, fmt.Errorf("profile not found")
+	}
+	return profile, nil
+}
+
+// video looks up a video by ID.
+func (r *Resolver) video(id string) (*model.Video, error) {
+	node, ok := r.library.Node(id)
+	if !ok {
+		return nil, fmt.Errorf("video not found")
+	}
+
+	video, ok := node.(*model.Video)
+	if !ok {
+		return nil, fmt.Errorf("video not found")
+	}
+
+	return video, nil
+}
+
+// streamURL is a stream path, bound to the selected profile.
+func (r *Resolver) streamURL(ctx context.Context, path string) (*url.URL, error) {
+	profile, err := r.profile(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &url.URL{Path: path, RawQuery: url.Values{ProfileParam: {profile.ID}}.Encode()}, nil
+}
@@ -0,0 +1,56 @@
+package graph_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/99designs/gqlgen/client"
+)
+
+func TestReportProgressBounds(t *testing.T) {
+	s := newTestServer(t)
+	c := s.client(admin)
+
+	var videos struct {
+		Videos []struct{ ID, Title string }
+	}
+	c.MustPost(`{ videos { id title } }`, &videos)
+	var id string
+	for _, video := range videos.Videos {
+		if video.Title == "Heat" {
+			id = video.ID
+		}
+	}
+
+	// fixtures last 60 frames at 29.97 fps, i.e., 2.002 seconds
+	for seconds, ok := range map[int]bool{0: true, 3: true, -1: false, 4: false} {
+		err := post(c, `mutation($id: ID!, $seconds: Int!) { reportProgress(videoId: $id, seconds: $seconds) { id } }`,
+			client.Var("id", id), client.Var("seconds", seconds))
+		if (err == nil) != ok {
+			t.Errorf("reportProgress(%d): error %v", seconds, err)
+		}
+	}
+}
+
+func TestAdminOnly(t *testing.T) {
+	s := newTestServer(t)
+	kid := s.client(s.restricted(t, "kid", "PG"))
+	administrator := s.client(admin)
+
+	var created struct{ CreateProfile struct{ ID string } }
+	administrator.MustPost(`mutation { createProfile(name: "guest") { id } }`, &created)
+
+	for _, query := range []string{
+		`{ profiles { id } }`,
+		`mutation { createProfile(name: "mine") { id } }`,
+		`mutation($id: ID!) { restrictProfile(id: $id, maxContentRating: "R") { id } }`,
+	} {
+		err := post(kid, query, client.Var("id", created.CreateProfile.ID))
+		if err == nil || !strings.Contains(err.Error(), "FORBIDDEN") {
+			t.Errorf("%s by kid: %v, want forbidden", query, err)
+		}
+		if err := post(administrator, query, client.Var("id", created.CreateProfile.ID)); err != nil {
+			t.Errorf("%s by admin: %v", query, err)
+		}
+	}
+}
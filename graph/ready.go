@@ -0,0 +1,36 @@
+package graph
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/idiomatic/tvql/graph/model"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+const errUnavailable = "UNAVAILABLE"
+
+// surveyRetryAfter is how long (in seconds) clients are asked to wait
+// for the initial survey.
+const surveyRetryAfter = "5"
+
+// AwaitSurvey refuses GraphQL requests (503) until the library's first
+// survey completes, rather than answering with a partial library.
+func AwaitSurvey(library *model.Library, h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if library.Surveyed() {
+			h.ServeHTTP(w, r)
+			return
+		}
+
+		err := gqlerror.Errorf("library survey in progress")
+		err.Extensions = map[string]interface{}{"code": errUnavailable}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Retry-After", surveyRetryAfter)
+		w.WriteHeader(http.StatusServiceUnavailable)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"errors": gqlerror.List{err},
+		})
+	})
+}
@@ -1,9 +1,14 @@
 package graph
 
 import (
+	"context"
 	"net/url"
 
+	"github.com/idiomatic/tvql/artcache"
+	"github.com/idiomatic/tvql/graph/loaders"
 	"github.com/idiomatic/tvql/graph/model"
+	"github.com/idiomatic/tvql/proxy"
+	"github.com/idiomatic/tvql/signing"
 )
 
 // This file will not be regenerated automatically.
@@ -12,14 +17,81 @@ import (
 
 type Resolver struct {
 	library     *model.Library
+	profiles    *model.Profiles
+	collections *model.Collections
+	// relative to the public base URL of each request
 	videoBase   *url.URL
 	artworkBase *url.URL
+	streamBase  *url.URL
+	// nil leaves media URLs unsigned
+	signer   *signing.Signer
+	artworks *artcache.Cache
 }
 
-func NewResolver(library *model.Library, videoBase *url.URL, artworkBase *url.URL) *Resolver {
+func NewResolver(library *model.Library, profiles *model.Profiles, collections *model.Collections, videoBase *url.URL, artworkBase *url.URL, streamBase *url.URL, signer *signing.Signer, artworks *artcache.Cache) *Resolver {
 	return &Resolver{
 		library:     library,
+		profiles:    profiles,
+		collections: collections,
 		videoBase:   videoBase,
 		artworkBase: artworkBase,
+		streamBase:  streamBase,
+		signer:      signer,
+		artworks:    artworks,
 	}
 }
+
+// appURL joins a URL to a base relative to the app.
+func appURL(base *url.URL, ref *url.URL) *url.URL {
+	u := *ref
+	u.Path = base.Path + ref.Path
+	return &u
+}
+
+// absoluteURL resolves a URL, relative to base, against the request's
+// public base URL.
+func (r *Resolver) absoluteURL(ctx context.Context, base *url.URL, ref *url.URL) string {
+	return proxy.BaseURL(ctx).ResolveReference(appURL(base, ref)).String()
+}
+
+// signedURL is like absoluteURL, but signs the media URL for the
+// requesting client.
+func (r *Resolver) signedURL(ctx context.Context, base *url.URL, ref *url.URL) string {
+	u := appURL(base, ref)
+	if r.signer != nil {
+		u = r.signer.Sign(u, signing.Client(ctx))
+	}
+	return proxy.BaseURL(ctx).ResolveReference(u).String()
+}
+
+// loaders returns the operation's loaders, else unshared ones.
+func (r *Resolver) loaders(ctx context.Context) *loaders.Loaders {
+	if l, ok := loaders.For(ctx); ok {
+		return l
+	}
+	return loaders.New(r.library)
+}
+
+// subscriptionBuffer is how many events a subscriber may fall behind
+// before its oldest are dropped.
+const subscriptionBuffer = 16
+
+// videoEvents forwards video events of one kind from the library, for
+// permitted videos.
+func (r *Resolver) videoEvents(ctx context.Context, kind model.EventKind, permits func(event model.Event) bool) <-chan *model.Video {
+	ch := make(chan *model.Video)
+	go func() {
+		defer close(ch)
+		for event := range r.library.Events.Subscribe(ctx, subscriptionBuffer) {
+			if event.Kind != kind || !permits(event) {
+				continue
+			}
+			select {
+			case ch <- event.Video:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return ch
+}
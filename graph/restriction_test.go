@@ -0,0 +1,294 @@
+package graph_test
+
+import (
+	"bufio"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/99designs/gqlgen/client"
+	"github.com/idiomatic/tvql/auth"
+	"github.com/idiomatic/tvql/graph"
+	"github.com/idiomatic/tvql/graph/model"
+	"github.com/idiomatic/tvql/stream"
+)
+
+// videoID looks up a fixture video by title.
+func (s *testServer) videoID(t *testing.T, title string) string {
+	t.Helper()
+	for id, metavideo := range s.library.Snapshot().Metavideos {
+		if metavideo.Video.Title == title {
+			return id
+		}
+	}
+	t.Fatalf("no video %q", title)
+	return ""
+}
+
+// series looks up a fixture series by name.
+func (s *testServer) series(t *testing.T, name string) *model.Series {
+	t.Helper()
+	for _, series := range s.library.Snapshot().Series {
+		if series.Name == name {
+			return series
+		}
+	}
+	t.Fatalf("no series %q", name)
+	return nil
+}
+
+// seasonID looks up the first season of a fixture series.
+func (s *testServer) seasonID(t *testing.T, series *model.Series) string {
+	t.Helper()
+	for _, season := range s.library.Snapshot().Seasons {
+		if season.Series.ID == series.ID && season.Season == 1 {
+			return season.ID
+		}
+	}
+	t.Fatalf("no season of %q", series.Name)
+	return ""
+}
+
+// stream serves playlists and segments much as server.go does.
+func (s *testServer) stream() http.Handler {
+	h := stream.NewHandler(s.library)
+	h.Permits = func(r *http.Request, videoID string) bool {
+		profile, ok := s.profiles.Get(r.URL.Query().Get(graph.ProfileParam))
+		if !ok {
+			return false
+		}
+		node, ok := s.library.Node(videoID)
+		if !ok {
+			return false
+		}
+		video, ok := node.(*model.Video)
+		return ok && s.library.Permits(profile, video)
+	}
+	h.Sign = func(r *http.Request, path string) string {
+		return s.signer.Derive(r, &url.URL{Path: "stream/" + path}).RawQuery
+	}
+	return s.signer.Require(http.StripPrefix("/stream/", h))
+}
+
+func fetch(h http.Handler, u *url.URL) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, u.RequestURI(), nil))
+	return w
+}
+
+// uris lists the URIs of a playlist, resolved against its URL.
+func uris(t *testing.T, u *url.URL, playlist string) []*url.URL {
+	t.Helper()
+	var listed []*url.URL
+	scanner := bufio.NewScanner(strings.NewReader(playlist))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(line, "#EXT-X-MAP:URI=") {
+			line = strings.Trim(strings.TrimPrefix(line, "#EXT-X-MAP:URI="), `"`)
+		} else if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		ref, err := url.Parse(line)
+		if err != nil {
+			t.Fatal(err)
+		}
+		listed = append(listed, u.ResolveReference(ref))
+	}
+	return listed
+}
+
+func TestRestrictedNodes(t *testing.T) {
+	s := newTestServer(t)
+	kid := s.client(s.restricted(t, "kid", "TV-PG"))
+
+	grownups := s.series(t, "Grownups")
+	theShow := s.series(t, "The Show")
+	hidden := map[string]string{
+		"movie":  s.videoID(t, "Heat"),
+		"series": grownups.ID,
+		"season": s.seasonID(t, grownups),
+	}
+	for kind, id := range hidden {
+		var response struct{ Node interface{} }
+		kid.MustPost(`query($id: ID!) { node(id: $id) { id } }`, &response, client.Var("id", id))
+		if response.Node != nil {
+			t.Errorf("restricted %s visible: %v", kind, response.Node)
+		}
+	}
+	for _, id := range []string{s.videoID(t, "Up"), theShow.ID, s.seasonID(t, theShow)} {
+		var response struct{ Node interface{} }
+		kid.MustPost(`query($id: ID!) { node(id: $id) { id } }`, &response, client.Var("id", id))
+		if response.Node == nil {
+			t.Errorf("permitted node %s hidden", id)
+		}
+	}
+
+	var videos struct{ Videos []struct{ Title string } }
+	kid.MustPost(`{ videos(title: "Heat") { title } }`, &videos)
+	if len(videos.Videos) != 0 {
+		t.Errorf("restricted search results: %v", videos.Videos)
+	}
+
+	var series struct{ Series []struct{ Name string } }
+	kid.MustPost(`{ series { name } }`, &series)
+	if len(series.Series) != 1 || series.Series[0].Name != "The Show" {
+		t.Errorf("series %v, want only The Show", series.Series)
+	}
+
+	if err := post(kid, `{ profiles { id } }`); err == nil {
+		t.Error("profiles listed to a restricted principal")
+	}
+}
+
+func TestRestrictedNextUp(t *testing.T) {
+	s := newTestServer(t)
+	kid := s.client(s.restricted(t, "kid", "TV-PG"))
+
+	if err := post(kid, `mutation($id: ID!) { markWatched(videoId: $id) { id } }`, client.Var("id", s.videoID(t, "Pilot"))); err != nil {
+		t.Fatal(err)
+	}
+
+	var response struct {
+		Node struct {
+			NextUp struct{ Video struct{ Title string } }
+		}
+	}
+	kid.MustPost(`query($id: ID!) { node(id: $id) { ... on Series { nextUp { video { title } } } } }`, &response,
+		client.Var("id", s.series(t, "The Show").ID))
+	if got := response.Node.NextUp.Video.Title; got != "Third" {
+		t.Errorf("next up %q, want Third past the restricted Second", got)
+	}
+}
+
+func TestRestrictedStream(t *testing.T) {
+	s := newTestServer(t)
+	principal := s.restricted(t, "kid", "PG")
+	kid := s.client(principal)
+	h := s.stream()
+
+	var response struct {
+		Node struct {
+			HlsURL  string `json:"hlsUrl"`
+			DashURL string `json:"dashUrl"`
+		}
+	}
+	kid.MustPost(`query($id: ID!) { node(id: $id) { ... on Video { hlsUrl dashUrl } } }`, &response,
+		client.Var("id", s.videoID(t, "Up")))
+	master, err := url.Parse(response.Node.HlsURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if master.Query().Get(graph.ProfileParam) != principal.Profile {
+		t.Errorf("hlsUrl %s not bound to profile %s", master, principal.Profile)
+	}
+
+	// every listed URI is signed, down to the segments
+	w := fetch(h, master)
+	if w.Code != http.StatusOK {
+		t.Fatalf("master playlist: status %d", w.Code)
+	}
+	variants := uris(t, master, w.Body.String())
+	if len(variants) == 0 {
+		t.Fatal("master playlist lists no variants")
+	}
+	var segments []*url.URL
+	for _, variant := range variants {
+		w := fetch(h, variant)
+		if w.Code != http.StatusOK {
+			t.Fatalf("%s: status %d", variant.Path, w.Code)
+		}
+		segments = append(segments, uris(t, variant, w.Body.String())...)
+	}
+	for _, segment := range segments {
+		if w := fetch(h, segment); w.Code != http.StatusOK {
+			t.Errorf("%s: status %d", segment.Path, w.Code)
+		}
+	}
+
+	dash, err := url.Parse(response.Node.DashURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if w := fetch(h, dash); w.Code != http.StatusOK || !strings.Contains(w.Body.String(), "signature=") {
+		t.Errorf("manifest: status %d\n%s", w.Code, w.Body.String())
+	}
+
+	// neither the path nor the profile may be altered
+	forged := *master
+	forged.Path = fmt.Sprintf("/stream/%s.m3u8", s.videoID(t, "Heat"))
+	if w := fetch(h, &forged); w.Code != http.StatusForbidden {
+		t.Errorf("forged path: status %d", w.Code)
+	}
+	forged = *master
+	query := forged.Query()
+	query.Set(graph.ProfileParam, "")
+	forged.RawQuery = query.Encode()
+	if w := fetch(h, &forged); w.Code != http.StatusForbidden {
+		t.Errorf("forged profile: status %d", w.Code)
+	}
+
+	// restrictions apply to URLs already issued
+	rating := "G"
+	if _, err := s.profiles.Restrict(principal.Profile, &rating, nil); err != nil {
+		t.Fatal(err)
+	}
+	for _, u := range []*url.URL{master, variants[0], segments[len(segments)-1]} {
+		if w := fetch(h, u); w.Code != http.StatusNotFound {
+			t.Errorf("%s after restriction: status %d", u.Path, w.Code)
+		}
+	}
+}
+
+func TestUnboundPrincipal(t *testing.T) {
+	s := newTestServer(t)
+	defaultProfile, err := s.profiles.Default()
+	if err != nil {
+		t.Fatal(err)
+	}
+	rating := "TV-PG"
+	if _, err := s.profiles.Restrict(defaultProfile.ID, &rating, nil); err != nil {
+		t.Fatal(err)
+	}
+	grownup, err := s.profiles.Create("grownup")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tv := s.client(&auth.Principal{Name: "tv"})
+	administrator := s.client(admin)
+
+	const query = `{ videos(title: "Heat") { title } }`
+	for _, test := range []struct {
+		name      string
+		c         *client.Client
+		profile   string
+		forbidden bool
+		visible   bool
+	}{
+		{"unselected", tv, "", false, false},
+		{"default", tv, defaultProfile.ID, false, false},
+		{"another", tv, grownup.ID, true, false},
+		{"unknown", tv, "someone", true, false},
+		{"administrator", administrator, grownup.ID, false, true},
+	} {
+		var options []client.Option
+		if test.profile != "" {
+			options = append(options, client.AddHeader(graph.ProfileHeader, test.profile))
+		}
+		var videos struct{ Videos []struct{ Title string } }
+		err := test.c.Post(query, &videos, options...)
+		if test.forbidden {
+			if err == nil || !strings.Contains(err.Error(), "FORBIDDEN") {
+				t.Errorf("%s: %v, want forbidden", test.name, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %v", test.name, err)
+		} else if visible := len(videos.Videos) != 0; visible != test.visible {
+			t.Errorf("%s: Heat visible %v, want %v", test.name, visible, test.visible)
+		}
+	}
+}
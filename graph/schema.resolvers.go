@@ -7,9 +7,11 @@ import (
 	"context"
 	"encoding/base64"
 	"fmt"
+	"math"
 	"net/url"
 	"sort"
 	"strconv"
+	"strings"
 
 	"github.com/idiomatic/tvql/graph/generated"
 	"github.com/idiomatic/tvql/graph/model"
@@ -18,62 +20,325 @@ import (
 func (r *artworkResolver) URL(ctx context.Context, obj *model.Artwork, geometry *model.GeometryFilter) (string, error) {
 	values := make(url.Values)
 	if geometry != nil {
+		if err := geometry.Validate(); err != nil {
+			return "", err
+		}
 		if geometry.Width != nil {
 			values.Set("width", strconv.Itoa(*geometry.Width))
 		}
 		if geometry.Height != nil {
 			values.Set("height", strconv.Itoa(*geometry.Height))
 		}
+		if geometry.Dpr != nil {
+			values.Set("dpr", strconv.FormatFloat(*geometry.Dpr, 'g', -1, 64))
+		}
+		if geometry.Fit != nil {
+			values.Set("fit", strings.ToLower(geometry.Fit.String()))
+		}
+		if geometry.Anchor != nil {
+			values.Set("anchor", strings.ToLower(geometry.Anchor.String()))
+		}
+		if geometry.Background != nil {
+			values.Set("background", *geometry.Background)
+		}
+		if geometry.Format != nil {
+			if !geometry.Format.Available() {
+				return "", fmt.Errorf("artwork format %s unavailable", *geometry.Format)
+			}
+			values.Set("format", strings.ToLower(geometry.Format.String()))
+		}
+		if geometry.Quality != nil {
+			values.Set("quality", strconv.Itoa(*geometry.Quality))
+		}
 	}
 
 	relativeURL := &url.URL{
-		Path:     obj.ID.String(),
+		Path:     obj.ID,
 		RawQuery: values.Encode(),
 	}
-	resolvedURL := r.artworkBase.ResolveReference(relativeURL)
-
-	return resolvedURL.String(), nil
+	return r.signedURL(ctx, r.artworkBase, relativeURL), nil
 }
 
 func (r *artworkResolver) Base64(ctx context.Context, obj *model.Artwork, geometry *model.GeometryFilter) (string, error) {
-	artwork, err := r.library.GetArtwork(obj.ID.String())
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
+	artwork, err := r.artworks.Get(obj.ID, geometry)
 	if err != nil {
 		return "", err
 	}
 
-	artwork, err = model.ResizeArtwork(artwork, geometry)
+	return base64.StdEncoding.EncodeToString(artwork.Data), nil
+}
+
+func (r *collectionResolver) Owner(ctx context.Context, obj *model.Collection) (*model.Profile, error) {
+	if obj.Shared() {
+		return nil, nil
+	}
+
+	profile, ok := r.profiles.Get(obj.OwnerID)
+	if !ok {
+		return nil, nil
+	}
+	return profile, nil
+}
+
+func (r *collectionResolver) Members(ctx context.Context, obj *model.Collection) ([]model.CollectionMember, error) {
+	return r.permittedMembers(ctx, r.library.CollectionMembers(obj))
+}
+
+func (r *collectionResolver) MemberCount(ctx context.Context, obj *model.Collection) (int, error) {
+	members, err := r.permittedMembers(ctx, r.library.CollectionMembers(obj))
+	if err != nil {
+		return 0, err
+	}
+
+	return len(members), nil
+}
+
+func (r *collectionResolver) Artwork(ctx context.Context, obj *model.Collection) (*model.Artwork, error) {
+	members, err := r.permittedMembers(ctx, r.library.CollectionMembers(obj))
+	if err != nil {
+		return nil, err
+	}
+
+	artwork, ok := r.library.CollectionArtwork(members)
+	if !ok {
+		return nil, nil
+	}
+
+	return artwork, nil
+}
+
+func (r *episodeResolver) Next(ctx context.Context, obj *model.Episode) (*model.Episode, error) {
+	next, ok := r.library.AdjacentEpisode(obj.ID, 1)
+	if !ok {
+		return nil, nil
+	}
+
+	permits, err := r.permits(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !permits(next.Video) {
+		return nil, nil
+	}
+
+	return next, nil
+}
+
+func (r *episodeResolver) Previous(ctx context.Context, obj *model.Episode) (*model.Episode, error) {
+	previous, ok := r.library.AdjacentEpisode(obj.ID, -1)
+	if !ok {
+		return nil, nil
+	}
+
+	permits, err := r.permits(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !permits(previous.Video) {
+		return nil, nil
+	}
+
+	return previous, nil
+}
+
+func (r *franchiseResolver) Videos(ctx context.Context, obj *model.Franchise, order *model.FranchiseOrder) ([]*model.Video, error) {
+	if order == nil {
+		return r.permittedVideos(ctx, obj.Videos)
+	}
+
+	return r.permittedVideos(ctx, obj.Ordered(*order))
+}
+
+func (r *franchiseResolver) VideoCount(ctx context.Context, obj *model.Franchise) (int, error) {
+	videos, err := r.permittedVideos(ctx, obj.Videos)
+	if err != nil {
+		return 0, err
+	}
+
+	return len(videos), nil
+}
+
+func (r *mutationResolver) CreateProfile(ctx context.Context, name string) (*model.Profile, error) {
+	return r.profiles.Create(name)
+}
+
+func (r *mutationResolver) RestrictProfile(ctx context.Context, id string, maxContentRating *string, roots []string) (*model.Profile, error) {
+	return r.profiles.Restrict(id, maxContentRating, roots)
+}
+
+func (r *mutationResolver) ReportProgress(ctx context.Context, videoID string, seconds int) (*model.Video, error) {
+	profile, err := r.profile(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	video, err := r.permittedVideo(ctx, videoID)
+	if err != nil {
+		return nil, err
+	}
+
+	if duration, ok := r.library.Duration(video.ID); ok && float64(seconds) > math.Ceil(duration.Seconds()) {
+		return nil, fmt.Errorf("seconds beyond duration")
+	}
+
+	if _, err := r.profiles.ReportProgress(profile.ID, video.ID, seconds); err != nil {
+		return nil, err
+	}
+
+	return video, nil
+}
+
+func (r *mutationResolver) MarkWatched(ctx context.Context, videoID string, watched *bool) (*model.Video, error) {
+	profile, err := r.profile(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	video, err := r.permittedVideo(ctx, videoID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := r.profiles.MarkWatched(profile.ID, video.ID, watched == nil || *watched); err != nil {
+		return nil, err
+	}
+
+	return video, nil
+}
+
+func (r *mutationResolver) CreateCollection(ctx context.Context, name string, memberIds []string, filter *model.VideoFilter, shared *bool) (*model.Collection, error) {
+	ownerID, err := r.collectionOwner(ctx, shared)
+	if err != nil {
+		return nil, err
+	}
+
+	memberIds, err = r.collectionMemberIDs(memberIds, true)
 	if err != nil {
+		return nil, err
+	}
+
+	return r.collections.Create(name, ownerID, memberIds, filter)
+}
+
+func (r *mutationResolver) RenameCollection(ctx context.Context, id string, name string) (*model.Collection, error) {
+	if err := r.mayEditCollection(ctx, id); err != nil {
+		return nil, err
+	}
+
+	return r.collections.Rename(id, name)
+}
+
+func (r *mutationResolver) FilterCollection(ctx context.Context, id string, filter model.VideoFilter) (*model.Collection, error) {
+	if err := r.mayEditCollection(ctx, id); err != nil {
+		return nil, err
+	}
+
+	return r.collections.SetFilter(id, &filter)
+}
+
+func (r *mutationResolver) DeleteCollection(ctx context.Context, id string) (string, error) {
+	if err := r.mayEditCollection(ctx, id); err != nil {
+		return "", err
+	}
+
+	if err := r.collections.Delete(id); err != nil {
 		return "", err
 	}
 
-	return base64.StdEncoding.EncodeToString(artwork), nil
+	return id, nil
 }
 
-func (r *queryResolver) Video(ctx context.Context, id string) (*model.Video, error) {
-	r.library.Mutex.Lock()
-	defer r.library.Mutex.Unlock()
+func (r *mutationResolver) AddToCollection(ctx context.Context, id string, memberIds []string, position *int) (*model.Collection, error) {
+	if err := r.mayEditCollection(ctx, id); err != nil {
+		return nil, err
+	}
+
+	memberIds, err := r.collectionMemberIDs(memberIds, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.collections.Add(id, memberIds, position)
+}
+
+func (r *mutationResolver) RemoveFromCollection(ctx context.Context, id string, memberIds []string) (*model.Collection, error) {
+	if err := r.mayEditCollection(ctx, id); err != nil {
+		return nil, err
+	}
+
+	memberIds, err := r.collectionMemberIDs(memberIds, false)
+	if err != nil {
+		return nil, err
+	}
 
-	metavideo, ok := r.library.Metavideos[model.Stringer{id}]
+	return r.collections.Remove(id, memberIds)
+}
+
+func (r *mutationResolver) MoveInCollection(ctx context.Context, id string, memberID string, position int) (*model.Collection, error) {
+	if err := r.mayEditCollection(ctx, id); err != nil {
+		return nil, err
+	}
+
+	memberIds, err := r.collectionMemberIDs([]string{memberID}, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.collections.Move(id, memberIds[0], position)
+}
+
+func (r *queryResolver) Node(ctx context.Context, id string) (model.Node, error) {
+	node, ok := r.node(id)
 	if !ok {
-		return nil, fmt.Errorf("video not found")
+		return nil, nil
 	}
 
-	return &metavideo.Video, nil
+	return r.permittedNode(ctx, node)
+}
+
+func (r *queryResolver) Nodes(ctx context.Context, ids []string) ([]model.Node, error) {
+	nodes := make([]model.Node, len(ids))
+	for i, id := range ids {
+		if node, ok := r.node(id); ok {
+			permitted, err := r.permittedNode(ctx, node)
+			if err != nil {
+				return nil, err
+			}
+			nodes[i] = permitted
+		}
+	}
+
+	return nodes, nil
+}
+
+func (r *queryResolver) Video(ctx context.Context, id string) (*model.Video, error) {
+	return r.permittedVideo(ctx, id)
 }
 
 func (r *queryResolver) Videos(ctx context.Context, paginate *model.Paginate, title *string, contributor *model.ContributorFilter) ([]*model.Video, error) {
-	r.library.Mutex.Lock()
-	defer r.library.Mutex.Unlock()
+	snapshot := r.library.Snapshot()
 
 	if contributor != nil {
 		panic(fmt.Errorf("not implemented"))
 	}
 
+	permits, err := r.permits(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	var matches []*model.Video
-	for _, metavideo := range r.library.Metavideos {
+	for _, metavideo := range snapshot.Metavideos {
 		if title != nil && metavideo.Video.Title != *title {
 			continue
 		}
+		if !permits(&metavideo.Video) {
+			continue
+		}
 
 		matches = append(matches, &metavideo.Video)
 	}
@@ -98,11 +363,16 @@ func (r *queryResolver) Videos(ctx context.Context, paginate *model.Paginate, ti
 }
 
 func (r *queryResolver) Series(ctx context.Context, paginate *model.Paginate) ([]*model.Series, error) {
-	r.library.Mutex.Lock()
-	defer r.library.Mutex.Unlock()
+	permits, err := r.permitsParent(ctx)
+	if err != nil {
+		return nil, err
+	}
 
 	var matches []*model.Series
-	for _, series := range r.library.Series {
+	for _, series := range r.library.Snapshot().Series {
+		if !permits(series.ID) {
+			continue
+		}
 		matches = append(matches, series)
 	}
 
@@ -110,7 +380,8 @@ func (r *queryResolver) Series(ctx context.Context, paginate *model.Paginate) ([
 
 	if paginate != nil && paginate.After != nil {
 		for i, series := range matches {
-			if series.Name == *paginate.After {
+			// legacy cursors were series names
+			if series.ID == *paginate.After || series.Name == *paginate.After {
 				matches = matches[i+1:]
 				break
 			}
@@ -124,16 +395,67 @@ func (r *queryResolver) Series(ctx context.Context, paginate *model.Paginate) ([
 	return matches, nil
 }
 
+func (r *queryResolver) SeriesByID(ctx context.Context, id string) (*model.Series, error) {
+	series, ok := r.library.SeriesByID(id)
+	if !ok {
+		return nil, nil
+	}
+
+	permits, err := r.permitsParent(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !permits(series.ID) {
+		return nil, nil
+	}
+
+	return series, nil
+}
+
+func (r *queryResolver) Season(ctx context.Context, seriesID string, number int) (*model.Season, error) {
+	season, ok := r.library.Season(seriesID, number)
+	if !ok {
+		return nil, nil
+	}
+
+	permits, err := r.permitsParent(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !permits(season.ID) {
+		return nil, nil
+	}
+
+	return season, nil
+}
+
+func (r *queryResolver) Episode(ctx context.Context, seriesID string, season int, episode int) (*model.Episode, error) {
+	match, ok := r.library.Episode(seriesID, season, episode)
+	if !ok {
+		return nil, nil
+	}
+
+	permits, err := r.permits(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !permits(match.Video) {
+		return nil, nil
+	}
+
+	return match, nil
+}
+
 func (r *queryResolver) Seasons(ctx context.Context, series *model.SeriesFilter) ([]*model.Season, error) {
-	r.library.Mutex.Lock()
-	defer r.library.Mutex.Unlock()
+	permits, err := r.permitsParent(ctx)
+	if err != nil {
+		return nil, err
+	}
 
 	var matches []*model.Season
-	for _, season := range r.library.Seasons {
-		if series != nil {
-			if season.Series.Name != *series.Name {
-				continue
-			}
+	for _, season := range r.library.Snapshot().Seasons {
+		if !series.Matches(season.Series) || !permits(season.ID) {
+			continue
 		}
 
 		matches = append(matches, season)
@@ -150,6 +472,11 @@ func (r *queryResolver) Episodes(ctx context.Context, series *model.SeriesFilter
 		return nil, err
 	}
 
+	matches, err = r.permittedEpisodes(ctx, matches)
+	if err != nil {
+		return nil, err
+	}
+
 	sort.Sort(model.ByEpisode(matches))
 
 	return matches, nil
@@ -161,25 +488,92 @@ func (r *queryResolver) EpisodeCount(ctx context.Context, series *model.SeriesFi
 		return 0, err
 	}
 
+	matches, err = r.permittedEpisodes(ctx, matches)
+	if err != nil {
+		return 0, err
+	}
+
 	return len(matches), nil
 }
 
+func (r *queryResolver) Profiles(ctx context.Context) ([]*model.Profile, error) {
+	return r.profiles.All(), nil
+}
+
+func (r *queryResolver) ContinueWatching(ctx context.Context, first *int) ([]*model.Video, error) {
+	profile, err := r.profile(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	matches := r.library.ContinueWatching(r.profiles.WatchState(profile.ID), func(video *model.Video) bool {
+		return r.library.Permits(profile, video)
+	})
+
+	if first != nil && len(matches) > *first {
+		matches = matches[:*first]
+	}
+
+	return matches, nil
+}
+
+func (r *queryResolver) Collections(ctx context.Context) ([]*model.Collection, error) {
+	sees, err := r.seesCollection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var collections []*model.Collection
+	for _, collection := range r.collections.All() {
+		if sees(collection) {
+			collections = append(collections, collection)
+		}
+	}
+	return collections, nil
+}
+
+func (r *queryResolver) Collection(ctx context.Context, id string) (*model.Collection, error) {
+	collection, ok := r.collections.Get(id)
+	if !ok {
+		return nil, nil
+	}
+
+	sees, err := r.seesCollection(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !sees(collection) {
+		return nil, nil
+	}
+	return collection, nil
+}
+
+func (r *queryResolver) Franchises(ctx context.Context) ([]*model.Franchise, error) {
+	permitsFranchise, err := r.permitsFranchise(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var franchises []*model.Franchise
+	for _, franchise := range r.library.Franchises() {
+		if permitsFranchise(franchise) {
+			franchises = append(franchises, franchise)
+		}
+	}
+	return franchises, nil
+}
+
 func (r *renditionResolver) URL(ctx context.Context, obj *model.Rendition) (string, error) {
-	_, ok := r.library.Metarenditions[obj.ID]
+	_, ok := r.library.Metarendition(obj.ID)
 	if !ok {
 		return "", fmt.Errorf("rendition not found")
 	}
 
 	relativeURL := &url.URL{Path: obj.ID}
-	resolvedURL := r.videoBase.ResolveReference(relativeURL)
-
-	return resolvedURL.String(), nil
+	return r.signedURL(ctx, r.videoBase, relativeURL), nil
 }
 
 func (r *renditionsResolver) Rendition(ctx context.Context, obj *model.Renditions, quality *model.QualityFilter) (*model.Rendition, error) {
-	r.library.Mutex.Lock()
-	defer r.library.Mutex.Unlock()
-
 	for _, rendition := range obj.All {
 		if quality != nil && rendition.Quality != nil {
 			// XXX func (vc *VideoCodec) Matches(quality *QualityFilter) bool
@@ -196,24 +590,66 @@ func (r *renditionsResolver) Rendition(ctx context.Context, obj *model.Rendition
 }
 
 func (r *seasonResolver) Episodes(ctx context.Context, obj *model.Season) ([]*model.Episode, error) {
-	series := &model.SeriesFilter{Name: &obj.Series.Name}
-	season := &model.SeasonFilter{Season: &obj.Season}
+	return r.permittedChildEpisodes(ctx, obj.ID)
+}
 
-	matches, err := r.library.Episodes(series, season)
+func (r *seasonResolver) EpisodeCount(ctx context.Context, obj *model.Season) (int, error) {
+	matches, err := r.permittedChildEpisodes(ctx, obj.ID)
+	if err != nil {
+		return 0, err
+	}
+
+	return len(matches), nil
+}
+
+func (r *seasonResolver) UnwatchedCount(ctx context.Context, obj *model.Season) (int, error) {
+	profile, err := r.profile(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	episodes, err := r.permittedChildEpisodes(ctx, obj.ID)
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for _, episode := range episodes {
+		if progress, ok := r.profiles.Progress(profile.ID, episode.Video.ID); !ok || !progress.Watched {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+func (r *seriesResolver) Seasons(ctx context.Context, obj *model.Series) ([]*model.Season, error) {
+	seasons, err := r.loaders(ctx).Seasons.Load(obj.ID)
 	if err != nil {
 		return nil, err
 	}
 
-	sort.Sort(model.ByEpisode(matches))
+	permits, err := r.permitsParent(ctx)
+	if err != nil {
+		return nil, err
+	}
 
-	return matches, nil
+	var permitted []*model.Season
+	for _, season := range seasons {
+		if permits(season.ID) {
+			permitted = append(permitted, season)
+		}
+	}
+
+	return permitted, nil
 }
 
-func (r *seasonResolver) EpisodeCount(ctx context.Context, obj *model.Season) (int, error) {
-	series := &model.SeriesFilter{Name: &obj.Series.Name}
-	season := &model.SeasonFilter{Season: &obj.Season}
+func (r *seriesResolver) Episodes(ctx context.Context, obj *model.Series) ([]*model.Episode, error) {
+	return r.permittedChildEpisodes(ctx, obj.ID)
+}
 
-	matches, err := r.library.Episodes(series, season)
+func (r *seriesResolver) EpisodeCount(ctx context.Context, obj *model.Series) (int, error) {
+	matches, err := r.permittedChildEpisodes(ctx, obj.ID)
 	if err != nil {
 		return 0, err
 	}
@@ -221,56 +657,168 @@ func (r *seasonResolver) EpisodeCount(ctx context.Context, obj *model.Season) (i
 	return len(matches), nil
 }
 
-func (r *seriesResolver) Seasons(ctx context.Context, obj *model.Series) ([]*model.Season, error) {
-	r.library.Mutex.Lock()
-	defer r.library.Mutex.Unlock()
+func (r *seriesResolver) NextUp(ctx context.Context, obj *model.Series) (*model.Episode, error) {
+	profile, err := r.profile(ctx)
+	if err != nil {
+		return nil, err
+	}
 
-	var matches []*model.Season
-	for _, season := range r.library.Seasons {
-		if season.Series != obj {
-			continue
-		}
+	next, _, ok := r.library.NextUp(obj.ID, r.profiles.WatchState(profile.ID), func(video *model.Video) bool {
+		return r.library.Permits(profile, video)
+	})
+	if !ok {
+		return nil, nil
+	}
 
-		matches = append(matches, season)
+	return next, nil
+}
+
+func (r *subscriptionResolver) VideoAdded(ctx context.Context) (<-chan *model.Video, error) {
+	permitsEvent, err := r.permitsEvent(ctx)
+	if err != nil {
+		return nil, err
 	}
 
-	sort.Sort(model.BySeason(matches))
+	return r.videoEvents(ctx, model.EventVideoAdded, permitsEvent), nil
+}
 
-	return matches, nil
+func (r *subscriptionResolver) VideoUpdated(ctx context.Context) (<-chan *model.Video, error) {
+	permitsEvent, err := r.permitsEvent(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.videoEvents(ctx, model.EventVideoUpdated, permitsEvent), nil
 }
 
-func (r *seriesResolver) Episodes(ctx context.Context, obj *model.Series) ([]*model.Episode, error) {
-	series := &model.SeriesFilter{Name: &obj.Name}
-	matches, err := r.library.Episodes(series, nil)
+func (r *subscriptionResolver) VideoRemoved(ctx context.Context) (<-chan string, error) {
+	permitsEvent, err := r.permitsEvent(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	sort.Sort(model.ByEpisode(matches))
+	ch := make(chan string)
+	go func() {
+		defer close(ch)
+		for event := range r.library.Events.Subscribe(ctx, subscriptionBuffer) {
+			if event.Kind != model.EventVideoRemoved || !permitsEvent(event) {
+				continue
+			}
+			select {
+			case ch <- event.VideoID:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return ch, nil
+}
 
-	return matches, nil
+func (r *subscriptionResolver) ScanProgress(ctx context.Context) (<-chan *model.ScanProgress, error) {
+	ch := make(chan *model.ScanProgress)
+	go func() {
+		defer close(ch)
+		for event := range r.library.Events.Subscribe(ctx, subscriptionBuffer) {
+			if event.Kind != model.EventScanProgress {
+				continue
+			}
+			select {
+			case ch <- event.ScanProgress:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return ch, nil
 }
 
-func (r *seriesResolver) EpisodeCount(ctx context.Context, obj *model.Series) (int, error) {
-	series := &model.SeriesFilter{Name: &obj.Name}
-	matches, err := r.library.Episodes(series, nil)
+func (r *videoResolver) HlsURL(ctx context.Context, obj *model.Video) (*string, error) {
+	if obj.Renditions == nil || len(obj.Renditions.All) == 0 {
+		return nil, nil
+	}
+
+	relativeURL, err := r.streamURL(ctx, obj.ID+".m3u8")
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
+	resolvedURL := r.signedURL(ctx, r.streamBase, relativeURL)
 
-	return len(matches), nil
+	return &resolvedURL, nil
+}
+
+func (r *videoResolver) DashURL(ctx context.Context, obj *model.Video) (*string, error) {
+	if obj.Renditions == nil || len(obj.Renditions.All) == 0 {
+		return nil, nil
+	}
+
+	relativeURL, err := r.streamURL(ctx, obj.ID+".mpd")
+	if err != nil {
+		return nil, err
+	}
+	resolvedURL := r.signedURL(ctx, r.streamBase, relativeURL)
+
+	return &resolvedURL, nil
 }
 
 func (r *videoResolver) Artwork(ctx context.Context, obj *model.Video) (*model.Artwork, error) {
-	if _, err := r.library.GetArtwork(obj.ID); err != nil {
+	return r.loaders(ctx).Artwork.Load(obj.ID)
+}
+
+func (r *videoResolver) Franchise(ctx context.Context, obj *model.Video) (*model.Franchise, error) {
+	franchise, ok := r.library.FranchiseOf(obj.ID)
+	if !ok {
 		return nil, nil
 	}
-	return &model.Artwork{ID: model.Stringer{obj.ID}}, nil
+
+	permitsFranchise, err := r.permitsFranchise(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !permitsFranchise(franchise) {
+		return nil, nil
+	}
+	return franchise, nil
+}
+
+func (r *videoResolver) Progress(ctx context.Context, obj *model.Video) (*model.Progress, error) {
+	profile, err := r.profile(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	progress, ok := r.profiles.Progress(profile.ID, obj.ID)
+	if !ok {
+		return nil, nil
+	}
+
+	return progress, nil
+}
+
+func (r *videoResolver) Watched(ctx context.Context, obj *model.Video) (bool, error) {
+	profile, err := r.profile(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	progress, ok := r.profiles.Progress(profile.ID, obj.ID)
+	return ok && progress.Watched, nil
 }
 
 // Artwork returns generated.ArtworkResolver implementation.
 func (r *Resolver) Artwork() generated.ArtworkResolver { return &artworkResolver{r} }
 
+// Collection returns generated.CollectionResolver implementation.
+func (r *Resolver) Collection() generated.CollectionResolver { return &collectionResolver{r} }
+
+// Episode returns generated.EpisodeResolver implementation.
+func (r *Resolver) Episode() generated.EpisodeResolver { return &episodeResolver{r} }
+
+// Franchise returns generated.FranchiseResolver implementation.
+func (r *Resolver) Franchise() generated.FranchiseResolver { return &franchiseResolver{r} }
+
+// Mutation returns generated.MutationResolver implementation.
+func (r *Resolver) Mutation() generated.MutationResolver { return &mutationResolver{r} }
+
 // Query returns generated.QueryResolver implementation.
 func (r *Resolver) Query() generated.QueryResolver { return &queryResolver{r} }
 
@@ -286,13 +834,21 @@ func (r *Resolver) Season() generated.SeasonResolver { return &seasonResolver{r}
 // Series returns generated.SeriesResolver implementation.
 func (r *Resolver) Series() generated.SeriesResolver { return &seriesResolver{r} }
 
+// Subscription returns generated.SubscriptionResolver implementation.
+func (r *Resolver) Subscription() generated.SubscriptionResolver { return &subscriptionResolver{r} }
+
 // Video returns generated.VideoResolver implementation.
 func (r *Resolver) Video() generated.VideoResolver { return &videoResolver{r} }
 
 type artworkResolver struct{ *Resolver }
+type collectionResolver struct{ *Resolver }
+type episodeResolver struct{ *Resolver }
+type franchiseResolver struct{ *Resolver }
+type mutationResolver struct{ *Resolver }
 type queryResolver struct{ *Resolver }
 type renditionResolver struct{ *Resolver }
 type renditionsResolver struct{ *Resolver }
 type seasonResolver struct{ *Resolver }
 type seriesResolver struct{ *Resolver }
+type subscriptionResolver struct{ *Resolver }
 type videoResolver struct{ *Resolver }
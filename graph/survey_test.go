@@ -0,0 +1,81 @@
+package graph_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/99designs/gqlgen/client"
+	"github.com/idiomatic/tvql/graph/model"
+)
+
+// TestSurveyConcurrentReads resurveys while snapshots are read and
+// queries resolved, for the race detector (go test -race).
+func TestSurveyConcurrentReads(t *testing.T) {
+	s := newTestServer(t)
+	c := s.client(admin)
+
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer close(done)
+		for i := 0; i < 5; i++ {
+			if err := s.library.Survey(context.Background(), s.root); err != nil {
+				t.Error(err)
+				return
+			}
+		}
+	}()
+
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+
+				for _, query := range []string{
+					`{ videos { id title renditions { all { id url } } } }`,
+					`{ series(paginate: {first: 3}) { name seasons { season episodes { id } } } }`,
+					`{ series { nextUp { id } } continueWatching { id } franchises { name } }`,
+				} {
+					var resp interface{}
+					if err := c.Post(query, &resp); err != nil {
+						t.Error(err)
+						return
+					}
+				}
+				var resp struct{ Videos []struct{ ID string } }
+				if err := c.Post(`{ videos { id } }`, &resp); err != nil {
+					t.Error(err)
+					return
+				}
+				for _, video := range resp.Videos {
+					var node struct{ Node *struct{ ID string } }
+					if err := c.Post(`query($id: ID!) { node(id: $id) { id } }`, &node, client.Var("id", video.ID)); err != nil {
+						t.Error(err)
+						return
+					}
+				}
+
+				snapshot := s.library.Snapshot()
+				for id := range snapshot.Series {
+					s.library.SeasonsBySeries([]string{id.String()})
+				}
+				if _, err := s.library.Episodes(nil, &model.SeasonFilter{}); err != nil {
+					t.Error(err)
+					return
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+}
@@ -0,0 +1,74 @@
+// Package health reports liveness and readiness to orchestrators.
+package health
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/idiomatic/tvql/logging"
+)
+
+// Check names a readiness condition, reporting why it is unmet.
+type Check struct {
+	Name  string
+	Check func() error
+}
+
+// Live reports that the process is serving.
+func Live() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		w.Header().Set("Cache-Control", "no-store")
+		fmt.Fprintln(w, "ok")
+	})
+}
+
+// Ready reports whether each check passes, failing (503) unless all
+// do.  Probes are anonymous, so why a check failed (e.g., naming a
+// path) is logged, not reported.
+func Ready(checks ...Check) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var report strings.Builder
+		status := http.StatusOK
+		for _, check := range checks {
+			if err := check.Check(); err != nil {
+				status = http.StatusServiceUnavailable
+				logging.Default().Debug("readiness check failed", "check", check.Name, "error", err)
+				fmt.Fprintf(&report, "[-] %s failed\n", check.Name)
+			} else {
+				fmt.Fprintf(&report, "[+] %s ok\n", check.Name)
+			}
+		}
+
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		w.Header().Set("Cache-Control", "no-store")
+		w.WriteHeader(status)
+		fmt.Fprint(w, report.String())
+	})
+}
+
+// Dir checks that path is an accessible directory.
+func Dir(path string) error {
+	dir, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer dir.Close()
+
+	info, err := dir.Stat()
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("%s: not a directory", path)
+	}
+	// e.g., a stale network mount
+	if _, err := dir.Readdirnames(1); err != nil && !errors.Is(err, io.EOF) {
+		return err
+	}
+	return nil
+}
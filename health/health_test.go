@@ -0,0 +1,29 @@
+package health
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestReady(t *testing.T) {
+	pass := Check{Name: "survey", Check: func() error { return nil }}
+	fail := Check{Name: "root", Check: func() error { return errors.New("open /srv/secret/media: no such file or directory") }}
+
+	w := httptest.NewRecorder()
+	Ready(pass).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	if w.Code != http.StatusOK || w.Body.String() != "[+] survey ok\n" {
+		t.Errorf("passing: status %d, %q", w.Code, w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	Ready(pass, fail).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	if w.Code != http.StatusServiceUnavailable || w.Body.String() != "[+] survey ok\n[-] root failed\n" {
+		t.Errorf("failing: status %d, %q", w.Code, w.Body.String())
+	}
+	if strings.Contains(w.Body.String(), "/srv") {
+		t.Error("failure reason reported")
+	}
+}
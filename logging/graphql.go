@@ -0,0 +1,36 @@
+package logging
+
+import (
+	"context"
+
+	"github.com/99designs/gqlgen/graphql"
+)
+
+// GraphQL annotates request records with GraphQL operation names and
+// types.
+type GraphQL struct{}
+
+var _ interface {
+	graphql.HandlerExtension
+	graphql.OperationInterceptor
+} = GraphQL{}
+
+func (GraphQL) ExtensionName() string {
+	return "Logging"
+}
+
+func (GraphQL) Validate(schema graphql.ExecutableSchema) error {
+	return nil
+}
+
+func (GraphQL) InterceptOperation(ctx context.Context, next graphql.OperationHandler) graphql.ResponseHandler {
+	rc := graphql.GetOperationContext(ctx)
+	if rc.Operation != nil {
+		operation := rc.Operation.Name
+		if operation == "" {
+			operation = "anonymous"
+		}
+		Annotate(ctx, "operation", operation, "operation_type", string(rc.Operation.Operation))
+	}
+	return next(ctx)
+}
@@ -0,0 +1,140 @@
+package logging
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// RequestIDHeader carries a request ID, from clients or proxies (if
+// any) and back to clients.
+const RequestIDHeader = "X-Request-ID"
+
+// maxRequestID bounds client-chosen request IDs.
+const maxRequestID = 64
+
+// annotations are attributes added to a request's record while it is
+// handled, e.g., GraphQL operation names.
+type annotations struct {
+	mutex sync.Mutex
+	kv    []interface{}
+}
+
+type annotationsContextKey struct{}
+
+// Annotate adds attributes to the record of the request in ctx.
+func Annotate(ctx context.Context, kv ...interface{}) {
+	if a, ok := ctx.Value(annotationsContextKey{}).(*annotations); ok {
+		a.mutex.Lock()
+		a.kv = append(a.kv, kv...)
+		a.mutex.Unlock()
+	}
+}
+
+// Requests logs each request once handled, and carries a logger,
+// bearing the request ID, in its context.
+func (l *Logger) Requests(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+
+		id := r.Header.Get(RequestIDHeader)
+		if id == "" || len(id) > maxRequestID {
+			id = newRequestID()
+		}
+		w.Header().Set(RequestIDHeader, id)
+
+		logger := l.With("request_id", id)
+		a := &annotations{}
+		ctx := context.WithValue(WithLogger(r.Context(), logger), annotationsContextKey{}, a)
+
+		recorder := &responseRecorder{ResponseWriter: w, status: http.StatusOK}
+		h.ServeHTTP(recorder, r.WithContext(ctx))
+
+		level := LevelInfo
+		if recorder.status >= http.StatusInternalServerError {
+			level = LevelError
+		}
+
+		a.mutex.Lock()
+		kv := append([]interface{}{
+			"method", r.Method,
+			"path", r.URL.Path,
+			"status", recorder.status,
+			"bytes", recorder.bytes,
+			"duration", time.Since(start),
+			"client", clientAddr(r),
+		}, a.kv...)
+		a.mutex.Unlock()
+		logger.Log(level, "request", kv...)
+	})
+}
+
+func clientAddr(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+func newRequestID() string {
+	id := make([]byte, 8)
+	if _, err := rand.Read(id); err != nil {
+		return "unknown"
+	}
+	return hex.EncodeToString(id)
+}
+
+// responseRecorder notes the status and size of a response, keeping
+// io.ReaderFrom (i.e., sendfile), http.Flusher, and http.Hijacker
+// (e.g., for websockets) usable.
+type responseRecorder struct {
+	http.ResponseWriter
+	status int
+	bytes  int64
+}
+
+func (w *responseRecorder) WriteHeader(status int) {
+	w.status = status
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *responseRecorder) Write(p []byte) (int, error) {
+	n, err := w.ResponseWriter.Write(p)
+	w.bytes += int64(n)
+	return n, err
+}
+
+func (w *responseRecorder) ReadFrom(r io.Reader) (int64, error) {
+	var n int64
+	var err error
+	if readerFrom, ok := w.ResponseWriter.(io.ReaderFrom); ok {
+		n, err = readerFrom.ReadFrom(r)
+	} else {
+		n, err = io.Copy(w.ResponseWriter, r)
+	}
+	w.bytes += n
+	return n, err
+}
+
+func (w *responseRecorder) Flush() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (w *responseRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, fmt.Errorf("hijacking unsupported")
+	}
+	w.status = http.StatusSwitchingProtocols
+	return hijacker.Hijack()
+}
@@ -0,0 +1,250 @@
+// Package logging writes leveled, structured (logfmt or JSON) logs,
+// after the fashion of log/slog.
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+)
+
+type Level int
+
+const (
+	LevelDebug Level = -4
+	LevelInfo  Level = 0
+	LevelWarn  Level = 4
+	LevelError Level = 8
+)
+
+func (l Level) String() string {
+	switch {
+	case l <= LevelDebug:
+		return "DEBUG"
+	case l <= LevelInfo:
+		return "INFO"
+	case l <= LevelWarn:
+		return "WARN"
+	}
+	return "ERROR"
+}
+
+// ParseLevel parses "debug", "info", "warn", or "error".
+func ParseLevel(s string) (Level, error) {
+	switch strings.ToLower(s) {
+	case "debug":
+		return LevelDebug, nil
+	case "info", "":
+		return LevelInfo, nil
+	case "warn", "warning":
+		return LevelWarn, nil
+	case "error":
+		return LevelError, nil
+	}
+	return 0, fmt.Errorf("unknown log level %q", s)
+}
+
+type Format int
+
+const (
+	FormatLogfmt Format = iota
+	FormatJSON
+)
+
+// ParseFormat parses "logfmt" or "json".
+func ParseFormat(s string) (Format, error) {
+	switch strings.ToLower(s) {
+	case "logfmt", "text", "":
+		return FormatLogfmt, nil
+	case "json":
+		return FormatJSON, nil
+	}
+	return 0, fmt.Errorf("unknown log format %q", s)
+}
+
+// output is shared by a logger and those derived from it.
+type output struct {
+	mutex  sync.Mutex
+	w      io.Writer
+	level  Level
+	format Format
+}
+
+// Logger writes records of a message and key-value attributes, e.g.,
+// logger.Info("surveyed", "path", path, "duration", d).
+type Logger struct {
+	out   *output
+	attrs []interface{}
+}
+
+func New(w io.Writer, level Level, format Format) *Logger {
+	return &Logger{out: &output{w: w, level: level, format: format}}
+}
+
+var defaultLogger = New(os.Stderr, LevelInfo, FormatLogfmt)
+
+// Default is the logger absent one in context.
+func Default() *Logger {
+	return defaultLogger
+}
+
+func SetDefault(l *Logger) {
+	defaultLogger = l
+}
+
+// With returns a logger adding attributes to every record.
+func (l *Logger) With(kv ...interface{}) *Logger {
+	attrs := make([]interface{}, 0, len(l.attrs)+len(kv))
+	attrs = append(append(attrs, l.attrs...), kv...)
+	return &Logger{out: l.out, attrs: attrs}
+}
+
+// Enabled reports whether records of a level are written.
+func (l *Logger) Enabled(level Level) bool {
+	return level >= l.out.level
+}
+
+func (l *Logger) Debug(msg string, kv ...interface{}) { l.Log(LevelDebug, msg, kv...) }
+func (l *Logger) Info(msg string, kv ...interface{})  { l.Log(LevelInfo, msg, kv...) }
+func (l *Logger) Warn(msg string, kv ...interface{})  { l.Log(LevelWarn, msg, kv...) }
+func (l *Logger) Error(msg string, kv ...interface{}) { l.Log(LevelError, msg, kv...) }
+
+// Log writes a record, if its level is enabled.
+func (l *Logger) Log(level Level, msg string, kv ...interface{}) {
+	if !l.Enabled(level) {
+		return
+	}
+
+	attrs := make([]interface{}, 0, 6+len(l.attrs)+len(kv))
+	attrs = append(attrs, "time", time.Now(), "level", level.String(), "msg", msg)
+	attrs = append(append(attrs, l.attrs...), kv...)
+
+	var buf bytes.Buffer
+	if l.out.format == FormatJSON {
+		writeJSON(&buf, attrs)
+	} else {
+		writeLogfmt(&buf, attrs)
+	}
+	buf.WriteByte('\n')
+
+	l.out.mutex.Lock()
+	defer l.out.mutex.Unlock()
+	l.out.w.Write(buf.Bytes())
+}
+
+// pairs calls fn with each key and value.  A missing value is
+// reported, rather than dropped.
+func pairs(kv []interface{}, fn func(key string, value interface{})) {
+	for i := 0; i < len(kv); i += 2 {
+		key := fmt.Sprint(kv[i])
+		if i+1 == len(kv) {
+			fn("!BADKEY", key)
+			break
+		}
+		fn(key, kv[i+1])
+	}
+}
+
+// normalize renders values as their logged form.
+func normalize(value interface{}) interface{} {
+	switch v := value.(type) {
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	case time.Duration:
+		return v.String()
+	case error:
+		return v.Error()
+	case fmt.Stringer:
+		return v.String()
+	}
+	return value
+}
+
+func writeJSON(buf *bytes.Buffer, attrs []interface{}) {
+	buf.WriteByte('{')
+	first := true
+	pairs(attrs, func(key string, value interface{}) {
+		if !first {
+			buf.WriteByte(',')
+		}
+		first = false
+
+		k, _ := json.Marshal(key)
+		buf.Write(k)
+		buf.WriteByte(':')
+		v, err := json.Marshal(normalize(value))
+		if err != nil {
+			v, _ = json.Marshal(fmt.Sprint(value))
+		}
+		buf.Write(v)
+	})
+	buf.WriteByte('}')
+}
+
+func writeLogfmt(buf *bytes.Buffer, attrs []interface{}) {
+	first := true
+	pairs(attrs, func(key string, value interface{}) {
+		if !first {
+			buf.WriteByte(' ')
+		}
+		first = false
+
+		buf.WriteString(key)
+		buf.WriteByte('=')
+		s := fmt.Sprint(normalize(value))
+		if needsQuoting(s) {
+			s = strconv.Quote(s)
+		}
+		buf.WriteString(s)
+	})
+}
+
+func needsQuoting(s string) bool {
+	if s == "" {
+		return true
+	}
+	for _, r := range s {
+		if r == '=' || r == '"' || unicode.IsSpace(r) || !unicode.IsPrint(r) {
+			return true
+		}
+	}
+	return false
+}
+
+// Writer adapts the logger for the standard log package (e.g., as
+// used by net/http), logging each line as a message.
+func (l *Logger) Writer(level Level) io.Writer {
+	return writerFunc(func(p []byte) (int, error) {
+		l.Log(level, strings.TrimRight(string(p), "\n"))
+		return len(p), nil
+	})
+}
+
+type writerFunc func(p []byte) (int, error)
+
+func (fn writerFunc) Write(p []byte) (int, error) {
+	return fn(p)
+}
+
+type loggerContextKey struct{}
+
+// WithLogger carries a logger in a context.
+func WithLogger(ctx context.Context, l *Logger) context.Context {
+	return context.WithValue(ctx, loggerContextKey{}, l)
+}
+
+// FromContext returns the logger carried by a context, else Default.
+func FromContext(ctx context.Context) *Logger {
+	if l, ok := ctx.Value(loggerContextKey{}).(*Logger); ok {
+		return l
+	}
+	return Default()
+}
@@ -6,6 +6,9 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"strconv"
+	"strings"
+	"time"
 
 	mp4 "github.com/abema/go-mp4"
 )
@@ -28,15 +31,48 @@ type File struct {
 	tven *mp4.Data
 	day  *mp4.Data
 	hdvd *mp4.Data
+	// keyed by ----.name
+	freeform map[string]*mp4.Data
+	// moov precedes mdat
+	fastStart bool
+	// survey outcome, lest accessors re-walk the file
+	surveyed  bool
+	surveyErr error
 }
 
 func NewFile(file io.ReadSeeker) *File {
 	return &File{file: file, ilst: nil}
 }
 
+// Parse reads the file's metadata, reporting whether it is readable.
+// Accessors parse on demand, so calling Parse is optional.
+func (f *File) Parse() error {
+	return f.survey()
+}
+
+// survey reads the file's metadata once.  Failing to find any (e.g.,
+// an untagged file) is remembered; failing partway is not, as the
+// metadata read thus far remains available.
 func (f *File) survey() error {
-	if f.ilst != nil {
-		return nil
+	if f.surveyed {
+		return f.surveyErr
+	}
+	f.surveyed = true
+
+	// top-level atoms only
+	mdatSeen := false
+	_, err := mp4.ReadBoxStructure(f.file, func(handle *mp4.ReadHandle) (interface{}, error) {
+		switch handle.BoxInfo.Type {
+		case mp4.BoxTypeMdat():
+			mdatSeen = true
+		case mp4.BoxTypeMoov():
+			f.fastStart = !mdatSeen
+		}
+		return nil, nil
+	})
+	if err != nil {
+		f.surveyErr = err
+		return err
 	}
 
 	// ExtractBoxWithPayload(r, ilst, ...) is broken when descending Ilst atomss.
@@ -46,13 +82,27 @@ func (f *File) survey() error {
 		mp4.BoxTypeMoov(), mp4.BoxTypeUdta(), mp4.BoxTypeMeta(), mp4.BoxTypeIlst(),
 	})
 	if err != nil {
+		f.surveyErr = err
 		return err
 	}
+	if len(ilstBoxes) == 0 {
+		f.surveyErr = errors.New("ilst atom missing")
+		return f.surveyErr
+	}
 	f.ilst = ilstBoxes[0]
+	f.freeform = make(map[string]*mp4.Data)
+
+	// ----.name precedes ----.data
+	var freeformName string
 
 	_, err = mp4.ReadBoxStructureFromInternal(f.file, f.ilst, func(handle *mp4.ReadHandle) (interface{}, error) {
 		boxInfo := handle.BoxInfo
 
+		if boxInfo.Type == mp4.StrToBoxType("----") {
+			// lest a nameless ---- atom take its predecessor's name
+			freeformName = ""
+		}
+
 		if !boxInfo.IsSupportedType() {
 			return nil, nil
 		}
@@ -67,6 +117,10 @@ func (f *File) survey() error {
 				if err != nil {
 					return nil, err
 				}
+				if name, ok := box.(*mp4.StringData); ok && boxInfo.Type == mp4.StrToBoxType("name") {
+					// name is a full box, but go-mp4 leaves its version and flags in Data
+					freeformName = strings.TrimLeft(string(name.Data), "\x00")
+				}
 				if data, ok := box.(*mp4.Data); ok {
 					switch parent {
 					case mp4.StrToBoxType("desc"):
@@ -110,7 +164,9 @@ func (f *File) survey() error {
 					case mp4.BoxType{0xA9, 'a', 'l', 'b'}:
 						// depends on kind
 					case mp4.StrToBoxType("----"):
-						// free
+						if freeformName != "" {
+							f.freeform[freeformName] = data
+						}
 					}
 				}
 			}
@@ -119,7 +175,8 @@ func (f *File) survey() error {
 		return handle.Expand()
 	})
 
-	return nil
+	// metadata read thus far remains available
+	return err
 }
 
 func (f *File) readBox(bi mp4.BoxInfo) (mp4.IBox, error) {
@@ -143,7 +200,7 @@ func (f *File) readData(bi mp4.BoxInfo) (*mp4.Data, error) {
 
 	data, ok := box.(*mp4.Data)
 	if !ok {
-		errors.New("coercion error")
+		return nil, errors.New("coercion error")
 	}
 
 	return data, nil
@@ -209,11 +266,31 @@ func (f *File) Description() (string, error) {
 	return string(f.desc.Data), nil
 }
 
+// FastStart reports whether moov precedes mdat, permitting playback
+// before download completes.  Untagged files are surveyed nonetheless.
+func (f *File) FastStart() bool {
+	f.survey()
+
+	return f.fastStart
+}
+
+func (f *File) HasCoverArt() bool {
+	if err := f.survey(); err != nil {
+		return false
+	}
+
+	return f.covr.Size != 0
+}
+
 func (f *File) CoverArt() ([]byte, error) {
 	if err := f.survey(); err != nil {
 		return nil, err
 	}
 
+	if f.covr.Size == 0 {
+		return nil, errors.New("covr atom missing")
+	}
+
 	data, err := f.readData(f.covr)
 	if err != nil {
 		return nil, err
@@ -309,3 +386,72 @@ func (f *File) TVEpisodeID() (int, error) {
 
 	return int(episode), nil
 }
+
+// Freeform returns the text of a ---- atom, by name (e.g., "iTunEXTC").
+func (f *File) Freeform(name string) (string, error) {
+	if err := f.survey(); err != nil {
+		return "", err
+	}
+
+	data, ok := f.freeform[name]
+	if !ok {
+		return "", fmt.Errorf("---- atom %q missing", name)
+	}
+
+	return string(data.Data), nil
+}
+
+// ContentRating returns the advisory rating (e.g., "PG-13"), per the
+// iTunEXTC ---- atom, e.g., "mpaa|PG-13|300|".
+func (f *File) ContentRating() (string, error) {
+	extc, err := f.Freeform("iTunEXTC")
+	if err != nil {
+		return "", err
+	}
+
+	fields := strings.Split(extc, "|")
+	if len(fields) < 2 || fields[1] == "" {
+		return "", fmt.Errorf("malformed iTunEXTC %q", extc)
+	}
+
+	return fields[1], nil
+}
+
+// Franchise returns the franchise name, per the FRANCHISE ---- atom.
+func (f *File) Franchise() (string, error) {
+	return f.Freeform("FRANCHISE")
+}
+
+// FranchiseOrder returns the in-universe position within the
+// franchise, per the FRANCHISE ORDER ---- atom.
+func (f *File) FranchiseOrder() (int, error) {
+	s, err := f.Freeform("FRANCHISE ORDER")
+	if err != nil {
+		return 0, err
+	}
+
+	return strconv.Atoi(strings.TrimSpace(s))
+}
+
+// Duration returns the length of the movie, per the moov.mvhd atom.
+func (f *File) Duration() (time.Duration, error) {
+	boxes, err := mp4.ExtractBoxWithPayload(f.file, nil, mp4.BoxPath{mp4.BoxTypeMoov(), mp4.BoxTypeMvhd()})
+	if err != nil {
+		return 0, err
+	}
+	if len(boxes) == 0 {
+		return 0, errors.New("mvhd atom missing")
+	}
+
+	mvhd, ok := boxes[0].Payload.(*mp4.Mvhd)
+	if !ok || mvhd.Timescale == 0 {
+		return 0, errors.New("mvhd atom malformed")
+	}
+
+	duration := uint64(mvhd.DurationV0)
+	if mvhd.GetVersion() == 1 {
+		duration = mvhd.DurationV1
+	}
+
+	return time.Duration(float64(duration) / float64(mvhd.Timescale) * float64(time.Second)), nil
+}
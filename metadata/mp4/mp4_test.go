@@ -0,0 +1,90 @@
+package mp4
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/idiomatic/tvql/metadata/mp4/mp4test"
+)
+
+// countingReader counts reads, i.e., walks of the file.
+type countingReader struct {
+	io.ReadSeeker
+	reads int
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	r.reads++
+	return r.ReadSeeker.Read(p)
+}
+
+func open(t *testing.T, spec mp4test.Spec) *countingReader {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "fixture.m4v")
+	if err := mp4test.WriteFile(path, spec); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &countingReader{ReadSeeker: bytes.NewReader(data)}
+}
+
+func TestUntagged(t *testing.T) {
+	for _, moovLast := range []bool{false, true} {
+		r := open(t, mp4test.Spec{Untagged: true, MoovLast: moovLast})
+		f := NewFile(r)
+
+		if err := f.Parse(); err == nil {
+			t.Error("untagged file parsed")
+		}
+		if got := f.FastStart(); got != !moovLast {
+			t.Errorf("moovLast %v: FastStart = %v", moovLast, got)
+		}
+
+		reads := r.reads
+		if _, err := f.Title(); err == nil {
+			t.Error("untagged file has a title")
+		}
+		f.HasCoverArt()
+		f.FastStart()
+		if r.reads != reads {
+			t.Errorf("accessors re-walked the file: %d reads", r.reads-reads)
+		}
+	}
+}
+
+func TestTagged(t *testing.T) {
+	r := open(t, mp4test.Spec{Title: "Alien", Day: "1979", Rating: "R", Franchise: "Alien", Order: 1, Nameless: "anonymous"})
+	f := NewFile(r)
+
+	if err := f.Parse(); err != nil {
+		t.Fatal(err)
+	}
+	if !f.FastStart() {
+		t.Error("FastStart = false")
+	}
+	if title, err := f.Title(); err != nil || title != "Alien" {
+		t.Errorf("Title = %q, %v", title, err)
+	}
+	if rating, err := f.ContentRating(); err != nil || rating != "R" {
+		t.Errorf("ContentRating = %q, %v", rating, err)
+	}
+	if order, err := f.FranchiseOrder(); err != nil || order != 1 {
+		t.Errorf("FranchiseOrder = %d, %v", order, err)
+	}
+	if _, err := f.Freeform(""); err == nil {
+		t.Error("nameless ---- atom recorded")
+	}
+
+	reads := r.reads
+	f.Title()
+	f.Franchise()
+	if r.reads != reads {
+		t.Errorf("accessors re-walked the file: %d reads", r.reads-reads)
+	}
+}
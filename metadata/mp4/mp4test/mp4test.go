@@ -0,0 +1,315 @@
+// Package mp4test writes small, synthetic mp4 files (one video track,
+// eventually iTunes metadata) for tests.
+package mp4test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"os"
+	"path/filepath"
+
+	mp4 "github.com/abema/go-mp4"
+)
+
+// Spec describes a file.  Zero values are omitted.
+type Spec struct {
+	Title, Day, Show string
+	Season, Episode  int
+	// "", "jpeg", or "png"
+	Cover string
+	// mdat precedes moov
+	MoovLast bool
+	// default 300, i.e., 10 seconds at 29.97fps
+	Samples   int
+	Franchise string
+	Rating    string
+	Order     int
+	// without udta (i.e., iTunes metadata) altogether
+	Untagged bool
+	// text of a trailing ---- atom lacking a name
+	Nameless string
+}
+
+// Samples are 1001 ticks of a 30000 timescale, with a keyframe every
+// 30, chunked by 10.
+const (
+	Timescale   = 30000
+	SampleDelta = 1001
+	PerChunk    = 10
+	KeyInterval = 30
+)
+
+// WriteFile writes a file (and its directory) per spec.
+func WriteFile(path string, spec Spec) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := Write(f, spec); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// fixtureError carries failures out of the nested box writers.
+type fixtureError struct{ err error }
+
+func must(err error) {
+	if err != nil {
+		panic(fixtureError{err})
+	}
+}
+
+func box(w *mp4.Writer, b mp4.IBox, ctx mp4.Context, children func()) {
+	_, err := w.StartBox(&mp4.BoxInfo{Type: b.GetType(), Context: ctx})
+	must(err)
+	_, err = mp4.Marshal(w, b, ctx)
+	must(err)
+	if children != nil {
+		children()
+	}
+	_, err = w.EndBox()
+	must(err)
+}
+
+func anyBox(w *mp4.Writer, t mp4.BoxType, ctx mp4.Context, children func()) {
+	_, err := w.StartBox(&mp4.BoxInfo{Type: t, Context: ctx})
+	must(err)
+	children()
+	_, err = w.EndBox()
+	must(err)
+}
+
+func u32(n int) []byte {
+	b := make([]byte, 4)
+	binary.BigEndian.PutUint32(b, uint32(n))
+	return b
+}
+
+// Write writes a file per spec.
+func Write(ws io.WriteSeeker, spec Spec) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			failure, ok := r.(fixtureError)
+			if !ok {
+				panic(r)
+			}
+			err = failure.err
+		}
+	}()
+
+	if spec.Samples == 0 {
+		spec.Samples = 300
+	}
+
+	w := mp4.NewWriter(ws)
+	ctx := mp4.Context{}
+
+	box(w, &mp4.Ftyp{MajorBrand: [4]byte{'M', '4', 'V', ' '}, CompatibleBrands: []mp4.CompatibleBrandElem{{CompatibleBrand: [4]byte{'i', 's', 'o', 'm'}}}}, ctx, nil)
+
+	// keyframes are larger; each sample is a length-prefixed NAL unit
+	sampleSizes := make([]uint32, spec.Samples)
+	for i := range sampleSizes {
+		sampleSizes[i] = uint32(1000 + i%7*10)
+		if i%KeyInterval == 0 {
+			sampleSizes[i] = 5000
+		}
+	}
+	nchunks := (spec.Samples + PerChunk - 1) / PerChunk
+
+	var mdatPayload bytes.Buffer
+	for i, sz := range sampleSizes {
+		b := make([]byte, sz)
+		binary.BigEndian.PutUint32(b, sz-4)
+		b[4] = byte(i)
+		mdatPayload.Write(b)
+	}
+
+	writeMdat := func() int64 {
+		bi, err := w.StartBox(&mp4.BoxInfo{Type: mp4.BoxTypeMdat()})
+		must(err)
+		start := int64(bi.Offset + bi.HeaderSize)
+		_, err = w.Write(mdatPayload.Bytes())
+		must(err)
+		_, err = w.EndBox()
+		must(err)
+		return start
+	}
+
+	writeMoov := func(mdatStart int64) {
+		offsets := make([]uint32, nchunks)
+		off := mdatStart
+		for i, sz := range sampleSizes {
+			if i%PerChunk == 0 {
+				offsets[i/PerChunk] = uint32(off)
+			}
+			off += int64(sz)
+		}
+		var stss []uint32
+		for i := 0; i < spec.Samples; i += KeyInterval {
+			stss = append(stss, uint32(i+1))
+		}
+		dur := uint32(spec.Samples * SampleDelta)
+		anyBox(w, mp4.BoxTypeMoov(), ctx, func() {
+			box(w, &mp4.Mvhd{Timescale: Timescale, DurationV0: dur, Rate: 0x10000, Volume: 0x100, Matrix: [9]int32{0x10000, 0, 0, 0, 0x10000, 0, 0, 0, 0x40000000}, NextTrackID: 2}, ctx, nil)
+			anyBox(w, mp4.BoxTypeTrak(), ctx, func() {
+				tkhd := &mp4.Tkhd{TrackID: 1, DurationV0: dur, Matrix: [9]int32{0x10000, 0, 0, 0, 0x10000, 0, 0, 0, 0x40000000}, Width: 640 << 16, Height: 360 << 16}
+				tkhd.SetFlags(3)
+				box(w, tkhd, ctx, nil)
+				anyBox(w, mp4.BoxTypeMdia(), ctx, func() {
+					box(w, &mp4.Mdhd{Timescale: Timescale, DurationV0: dur, Language: [3]byte{'u' - 0x60, 'n' - 0x60, 'd' - 0x60}}, ctx, nil)
+					box(w, &mp4.Hdlr{HandlerType: [4]byte{'v', 'i', 'd', 'e'}, Name: "VideoHandler"}, ctx, nil)
+					anyBox(w, mp4.BoxTypeMinf(), ctx, func() {
+						vmhd := &mp4.Vmhd{}
+						vmhd.SetFlags(1)
+						box(w, vmhd, ctx, nil)
+						anyBox(w, mp4.BoxTypeDinf(), ctx, func() {
+							box(w, &mp4.Dref{EntryCount: 1}, ctx, func() {
+								url := &mp4.Url{}
+								url.SetFlags(1)
+								box(w, url, ctx, nil)
+							})
+						})
+						anyBox(w, mp4.BoxTypeStbl(), ctx, func() {
+							box(w, &mp4.Stsd{EntryCount: 1}, ctx, func() {
+								avc1 := &mp4.VisualSampleEntry{
+									SampleEntry:     mp4.SampleEntry{AnyTypeBox: mp4.AnyTypeBox{Type: mp4.BoxTypeAvc1()}, DataReferenceIndex: 1},
+									Width:           640,
+									Height:          360,
+									Horizresolution: 0x480000,
+									Vertresolution:  0x480000,
+									FrameCount:      1,
+									Depth:           0x18,
+									PreDefined3:     -1,
+								}
+								box(w, avc1, ctx, func() {
+									box(w, &mp4.AVCDecoderConfiguration{
+										AnyTypeBox:                 mp4.AnyTypeBox{Type: mp4.BoxTypeAvcC()},
+										ConfigurationVersion:       1,
+										Profile:                    0x64,
+										ProfileCompatibility:       0,
+										Level:                      0x1e,
+										LengthSizeMinusOne:         3,
+										NumOfSequenceParameterSets: 1,
+										SequenceParameterSets:      []mp4.AVCParameterSet{{Length: 4, NALUnit: []byte{0x67, 0x64, 0x00, 0x1e}}},
+										NumOfPictureParameterSets:  1,
+										PictureParameterSets:       []mp4.AVCParameterSet{{Length: 4, NALUnit: []byte{0x68, 0xee, 0x3c, 0x80}}},
+									}, ctx, nil)
+								})
+							})
+							box(w, &mp4.Stts{EntryCount: 1, Entries: []mp4.SttsEntry{{SampleCount: uint32(spec.Samples), SampleDelta: SampleDelta}}}, ctx, nil)
+							box(w, &mp4.Stss{EntryCount: uint32(len(stss)), SampleNumber: stss}, ctx, nil)
+							stsc := []mp4.StscEntry{{FirstChunk: 1, SamplesPerChunk: PerChunk, SampleDescriptionIndex: 1}}
+							if spec.Samples%PerChunk != 0 {
+								stsc = append(stsc, mp4.StscEntry{FirstChunk: uint32(nchunks), SamplesPerChunk: uint32(spec.Samples % PerChunk), SampleDescriptionIndex: 1})
+							}
+							box(w, &mp4.Stsc{EntryCount: uint32(len(stsc)), Entries: stsc}, ctx, nil)
+							box(w, &mp4.Stsz{SampleCount: uint32(spec.Samples), EntrySize: sampleSizes}, ctx, nil)
+							box(w, &mp4.Stco{EntryCount: uint32(nchunks), ChunkOffset: offsets}, ctx, nil)
+						})
+					})
+				})
+			})
+			if spec.Untagged {
+				return
+			}
+			anyBox(w, mp4.BoxTypeUdta(), ctx, func() {
+				box(w, &mp4.Meta{}, ctx, func() {
+					box(w, &mp4.Hdlr{HandlerType: [4]byte{'m', 'd', 'i', 'r'}, Name: ""}, ctx, nil)
+					ilstCtx := mp4.Context{UnderIlst: true}
+					metaCtx := mp4.Context{UnderIlst: true, UnderIlstMeta: true}
+					anyBox(w, mp4.BoxTypeIlst(), ctx, func() {
+						item := func(t mp4.BoxType, dtype uint32, data []byte) {
+							anyBox(w, t, ilstCtx, func() {
+								box(w, &mp4.Data{DataType: dtype, Data: data}, metaCtx, nil)
+							})
+						}
+						if spec.Title != "" {
+							item(mp4.BoxType{0xA9, 'n', 'a', 'm'}, 1, []byte(spec.Title))
+						}
+						if spec.Day != "" {
+							item(mp4.BoxType{0xA9, 'd', 'a', 'y'}, 1, []byte(spec.Day))
+						}
+						if spec.Show != "" {
+							item(mp4.StrToBoxType("tvsh"), 1, []byte(spec.Show))
+							item(mp4.StrToBoxType("tvsn"), 21, u32(spec.Season))
+							item(mp4.StrToBoxType("tves"), 21, u32(spec.Episode))
+							item(mp4.StrToBoxType("stik"), 21, []byte{10})
+						} else {
+							item(mp4.StrToBoxType("stik"), 21, []byte{9})
+						}
+						item(mp4.StrToBoxType("desc"), 1, []byte("A description of "+spec.Title))
+						item(mp4.BoxType{0xA9, 'g', 'e', 'n'}, 1, []byte("Drama"))
+						freeform := func(name, value string) {
+							anyBox(w, mp4.StrToBoxType("----"), ilstCtx, func() {
+								freeCtx := mp4.Context{UnderIlst: true, UnderIlstMeta: true, UnderIlstFreeMeta: true}
+								mean := &mp4.StringData{AnyTypeBox: mp4.AnyTypeBox{Type: mp4.StrToBoxType("mean")}, Data: append([]byte{0, 0, 0, 0}, "com.apple.iTunes"...)}
+								box(w, mean, freeCtx, nil)
+								if name != "" {
+									nm := &mp4.StringData{AnyTypeBox: mp4.AnyTypeBox{Type: mp4.StrToBoxType("name")}, Data: append([]byte{0, 0, 0, 0}, name...)}
+									box(w, nm, freeCtx, nil)
+								}
+								box(w, &mp4.Data{DataType: 1, Data: []byte(value)}, freeCtx, nil)
+							})
+						}
+						if spec.Rating != "" {
+							freeform("iTunEXTC", "mpaa|"+spec.Rating+"|300|")
+						}
+						if spec.Franchise != "" {
+							freeform("FRANCHISE", spec.Franchise)
+						}
+						if spec.Order != 0 {
+							freeform("FRANCHISE ORDER", fmt.Sprint(spec.Order))
+						}
+						if spec.Nameless != "" {
+							freeform("", spec.Nameless)
+						}
+						if spec.Cover != "" {
+							img := image.NewRGBA(image.Rect(0, 0, 300, 450))
+							for y := 0; y < 450; y++ {
+								for x := 0; x < 300; x++ {
+									img.Set(x, y, color.RGBA{uint8(x), uint8(y), 128, 255})
+								}
+							}
+							var buf bytes.Buffer
+							dtype := uint32(13)
+							if spec.Cover == "png" {
+								must(png.Encode(&buf, img))
+								dtype = 14
+							} else {
+								must(jpeg.Encode(&buf, img, nil))
+							}
+							item(mp4.StrToBoxType("covr"), dtype, buf.Bytes())
+						}
+					})
+				})
+			})
+		})
+	}
+
+	if spec.MoovLast {
+		start := writeMdat()
+		writeMoov(start)
+	} else {
+		// write moov with placeholder offsets, then mdat, then rewrite moov
+		moovStart, _ := ws.Seek(0, io.SeekCurrent)
+		writeMoov(0)
+		start := writeMdat()
+		end, _ := ws.Seek(0, io.SeekCurrent)
+		_, _ = ws.Seek(moovStart, io.SeekStart)
+		writeMoov(start)
+		_, _ = ws.Seek(end, io.SeekStart)
+	}
+
+	return nil
+}
@@ -0,0 +1,108 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+var (
+	graphqlOperations = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "graphql_operations_total",
+		Help:      "GraphQL operations, by type, name, and status.",
+	}, []string{"type", "operation", "status"})
+
+	graphqlDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "graphql_operation_duration_seconds",
+		Help:      "GraphQL operation latency, by type and name.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"type", "operation"})
+
+	graphqlFieldDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "graphql_field_duration_seconds",
+		Help:      "GraphQL resolver latency, by field (e.g., Query.videos).",
+		Buckets:   prometheus.ExponentialBuckets(0.0001, 4, 10),
+	}, []string{"field", "status"})
+)
+
+// GraphQL counts and times operations, and times resolvers (but not
+// plain struct fields).  Subscriptions are exempt.
+//
+// Operation names are up to clients, so only those of known queries,
+// or listed, label metrics; others are "other".
+type GraphQL struct {
+	// Known, if set, reports whether a query is persisted (i.e., not
+	// sent by a client).
+	Known func(query string) bool
+	// Operations lists operation names to label metrics by regardless.
+	Operations map[string]bool
+}
+
+var _ interface {
+	graphql.HandlerExtension
+	graphql.ResponseInterceptor
+	graphql.FieldInterceptor
+} = GraphQL{}
+
+func (GraphQL) ExtensionName() string {
+	return "Metrics"
+}
+
+func (GraphQL) Validate(schema graphql.ExecutableSchema) error {
+	return nil
+}
+
+func (g GraphQL) InterceptResponse(ctx context.Context, next graphql.ResponseHandler) *graphql.Response {
+	if !graphql.HasOperationContext(ctx) {
+		return next(ctx)
+	}
+	rc := graphql.GetOperationContext(ctx)
+	if rc.Operation == nil || rc.Operation.Operation == ast.Subscription {
+		return next(ctx)
+	}
+
+	start := time.Now()
+	response := next(ctx)
+
+	operation := "other"
+	if name := rc.Operation.Name; name == "" {
+		operation = "anonymous"
+	} else if g.Operations[name] || (g.Known != nil && g.Known(rc.RawQuery)) {
+		operation = name
+	}
+	kind := string(rc.Operation.Operation)
+
+	status := "ok"
+	if response == nil || len(response.Errors) > 0 {
+		status = "error"
+	}
+	graphqlOperations.WithLabelValues(kind, operation, status).Inc()
+	graphqlDuration.WithLabelValues(kind, operation).Observe(time.Since(start).Seconds())
+
+	return response
+}
+
+func (GraphQL) InterceptField(ctx context.Context, next graphql.Resolver) (interface{}, error) {
+	fc := graphql.GetFieldContext(ctx)
+	if fc == nil || !fc.IsResolver {
+		return next(ctx)
+	}
+
+	start := time.Now()
+	result, err := next(ctx)
+
+	status := "ok"
+	if err != nil {
+		status = "error"
+	}
+	graphqlFieldDuration.WithLabelValues(fc.Object+"."+fc.Field.Name, status).Observe(time.Since(start).Seconds())
+
+	return result, err
+}
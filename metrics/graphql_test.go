@@ -0,0 +1,46 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+func TestGraphQLOperationLabels(t *testing.T) {
+	const persisted = "query Titles { videos { title } }"
+	g := GraphQL{
+		Known:      func(query string) bool { return query == persisted },
+		Operations: map[string]bool{"Listed": true},
+	}
+
+	for _, test := range []struct {
+		name, query, want string
+	}{
+		{"Titles", persisted, "Titles"},
+		{"Listed", "query Listed { series { name } }", "Listed"},
+		{"", "{ videos { id } }", "anonymous"},
+		{"Whatever1234", "query Whatever1234 { videos { id } }", "other"},
+		{"Titles", "query Titles { videos { id } }", "other"},
+	} {
+		ctx := graphql.WithOperationContext(context.Background(), &graphql.OperationContext{
+			RawQuery:  test.query,
+			Operation: &ast.OperationDefinition{Name: test.name, Operation: ast.Query},
+		})
+
+		counter := graphqlOperations.WithLabelValues("query", test.want, "ok")
+		before := testutil.ToFloat64(counter)
+		g.InterceptResponse(ctx, func(ctx context.Context) *graphql.Response {
+			return &graphql.Response{}
+		})
+		if after := testutil.ToFloat64(counter); after != before+1 {
+			t.Errorf("%q: not counted as %q", test.query, test.want)
+		}
+	}
+
+	if n := testutil.CollectAndCount(graphqlOperations); n != 4 {
+		t.Errorf("%d label sets, want 4", n)
+	}
+}
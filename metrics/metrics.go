@@ -0,0 +1,170 @@
+// Package metrics exposes Prometheus metrics of HTTP requests, GraphQL
+// operations and resolvers, artwork, surveys, and the library.
+package metrics
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "tvql"
+
+var (
+	httpRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_total",
+		Help:      "HTTP requests, by handler, method, and status code.",
+	}, []string{"handler", "method", "code"})
+
+	httpDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "HTTP request latency, by handler.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"handler"})
+
+	videoBytes = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "video_bytes_served_total",
+		Help:      "Bytes of video served.",
+	})
+
+	// ArtworkCache counts artwork lookups, by result ("memory",
+	// "disk", or "miss").
+	ArtworkCache = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "artwork_cache_requests_total",
+		Help:      "Artwork lookups, by cache result.",
+	}, []string{"result"})
+
+	// ArtworkResize observes how long artwork takes to resize.
+	ArtworkResize = promauto.NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "artwork_resize_duration_seconds",
+		Help:      "Artwork decode, resize, and encode time, upon cache misses.",
+		Buckets:   prometheus.ExponentialBuckets(0.001, 2, 12),
+	})
+
+	surveyDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "survey_duration_seconds",
+		Help:      "Library survey time, by outcome.",
+		Buckets:   prometheus.ExponentialBuckets(0.1, 2, 14),
+	}, []string{"outcome"})
+
+	surveyFiles = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "survey_files_scanned",
+		Help:      "Video files scanned by the latest survey.",
+	})
+
+	surveyLast = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "survey_last_completion_timestamp_seconds",
+		Help:      "When the latest survey completed.",
+	})
+)
+
+// Handler serves metrics in the Prometheus exposition format.
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
+
+// Instrument counts and times requests of a handler.
+func Instrument(handler string, h http.Handler) http.Handler {
+	labels := prometheus.Labels{"handler": handler}
+	// preserves http.Hijacker and such, e.g., for websockets
+	return promhttp.InstrumentHandlerCounter(httpRequests.MustCurryWith(labels),
+		promhttp.InstrumentHandlerDuration(httpDuration.MustCurryWith(labels), h))
+}
+
+// CountVideoBytes counts bytes served by a video handler.
+func CountVideoBytes(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h.ServeHTTP(&countingWriter{ResponseWriter: w}, r)
+	})
+}
+
+// countingWriter counts bytes written, keeping io.ReaderFrom (i.e.,
+// sendfile) usable.
+type countingWriter struct {
+	http.ResponseWriter
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	n, err := w.ResponseWriter.Write(p)
+	videoBytes.Add(float64(n))
+	return n, err
+}
+
+func (w *countingWriter) ReadFrom(r io.Reader) (int64, error) {
+	var n int64
+	var err error
+	if readerFrom, ok := w.ResponseWriter.(io.ReaderFrom); ok {
+		n, err = readerFrom.ReadFrom(r)
+	} else {
+		n, err = io.Copy(w.ResponseWriter, r)
+	}
+	videoBytes.Add(float64(n))
+	return n, err
+}
+
+// ObserveSurvey records a survey's duration and files scanned.
+func ObserveSurvey(duration time.Duration, filesScanned int, err error) {
+	outcome := "done"
+	if errors.Is(err, context.Canceled) {
+		outcome = "canceled"
+	} else if err != nil {
+		outcome = "failed"
+	}
+	surveyDuration.WithLabelValues(outcome).Observe(duration.Seconds())
+	if err == nil {
+		surveyFiles.Set(float64(filesScanned))
+		surveyLast.SetToCurrentTime()
+	}
+}
+
+// LibraryStats sizes the library.
+type LibraryStats struct {
+	Videos     int
+	Series     int
+	Renditions int
+	Bytes      int64
+}
+
+var (
+	libraryVideosDesc     = prometheus.NewDesc(namespace+"_library_videos", "Videos in the library.", nil, nil)
+	librarySeriesDesc     = prometheus.NewDesc(namespace+"_library_series", "Series in the library.", nil, nil)
+	libraryRenditionsDesc = prometheus.NewDesc(namespace+"_library_renditions", "Renditions in the library.", nil, nil)
+	libraryBytesDesc      = prometheus.NewDesc(namespace+"_library_bytes", "Total size of renditions.", nil, nil)
+)
+
+// libraryCollector sizes the library upon each scrape.
+type libraryCollector func() LibraryStats
+
+func (c libraryCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- libraryVideosDesc
+	ch <- librarySeriesDesc
+	ch <- libraryRenditionsDesc
+	ch <- libraryBytesDesc
+}
+
+func (c libraryCollector) Collect(ch chan<- prometheus.Metric) {
+	stats := c()
+	ch <- prometheus.MustNewConstMetric(libraryVideosDesc, prometheus.GaugeValue, float64(stats.Videos))
+	ch <- prometheus.MustNewConstMetric(librarySeriesDesc, prometheus.GaugeValue, float64(stats.Series))
+	ch <- prometheus.MustNewConstMetric(libraryRenditionsDesc, prometheus.GaugeValue, float64(stats.Renditions))
+	ch <- prometheus.MustNewConstMetric(libraryBytesDesc, prometheus.GaugeValue, float64(stats.Bytes))
+}
+
+// RegisterLibrary gauges the library size, per stats.
+func RegisterLibrary(stats func() LibraryStats) {
+	prometheus.MustRegister(libraryCollector(stats))
+}
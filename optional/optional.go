@@ -0,0 +1,14 @@
+// Package optional parses optional settings and parameters, which are
+// nil if absent or malformed.
+package optional
+
+import "strconv"
+
+// Atoi returns a pointer to an int parsed from a string, else nil.
+func Atoi(s string) *int {
+	value, err := strconv.Atoi(s)
+	if err != nil {
+		return nil
+	}
+	return &value
+}
@@ -0,0 +1,227 @@
+// Package proxy derives the public base URL and client address of
+// requests, believing forwarding headers only from trusted reverse
+// proxies.
+package proxy
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Forwarding interprets requests arriving via reverse proxies.
+type Forwarding struct {
+	// public base URL, overriding derivation (optional)
+	BaseURL *url.URL
+	// where the app is mounted, e.g., "/tvql/"
+	MountPath string
+	// proxies whose forwarding headers are believed
+	Trusted []*net.IPNet
+}
+
+// forwarded is what a proxy reported of the original request.
+type forwarded struct {
+	proto, host, prefix, client string
+}
+
+// ParseCIDRs parses whitespace- or comma-separated CIDRs, or bare IP
+// addresses.
+func ParseCIDRs(s string) ([]*net.IPNet, error) {
+	var networks []*net.IPNet
+	for _, field := range strings.FieldsFunc(s, func(r rune) bool { return r == ',' || r == ' ' }) {
+		if !strings.Contains(field, "/") {
+			if ip := net.ParseIP(field); ip != nil && ip.To4() != nil {
+				field += "/32"
+			} else {
+				field += "/128"
+			}
+		}
+		_, network, err := net.ParseCIDR(field)
+		if err != nil {
+			return nil, err
+		}
+		networks = append(networks, network)
+	}
+	return networks, nil
+}
+
+// trusts reports whether a request came directly from a trusted proxy.
+func (f *Forwarding) trusts(r *http.Request) bool {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	return f.trustsAddr(host)
+}
+
+// trustsAddr reports whether an IP address is of a trusted proxy.
+func (f *Forwarding) trustsAddr(addr string) bool {
+	ip := net.ParseIP(addr)
+	if ip == nil {
+		return false
+	}
+	for _, network := range f.Trusted {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// Handler carries the public base URL in the request context, and
+// replaces the remote address with the forwarded client's, if any.
+func (f *Forwarding) Handler(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var fwd forwarded
+		if f.trusts(r) {
+			fwd = f.parseForwarded(r.Header)
+		}
+
+		base := f.BaseURL
+		if base == nil {
+			base = &url.URL{Scheme: "http", Host: r.Host}
+			if r.TLS != nil {
+				base.Scheme = "https"
+			}
+			if fwd.proto != "" {
+				base.Scheme = fwd.proto
+			}
+			if fwd.host != "" {
+				base.Host = fwd.host
+			}
+			base.Path = joinPath(fwd.prefix, f.MountPath)
+		}
+
+		if fwd.client != "" {
+			r2 := new(http.Request)
+			*r2 = *r
+			r2.RemoteAddr = net.JoinHostPort(fwd.client, "0")
+			r = r2
+		}
+
+		h.ServeHTTP(w, r.WithContext(WithBaseURL(r.Context(), base)))
+	})
+}
+
+// parseForwarded reads RFC 7239 Forwarded, else X-Forwarded-*
+// headers.  Proxies append to these, so only the rightmost entries are
+// vouched for: of several proxies, the list is walked right to left,
+// past trusted proxies, and the first untrusted address (i.e., nearest
+// the client to be believed) is the client.
+func (f *Forwarding) parseForwarded(header http.Header) forwarded {
+	var fwd forwarded
+
+	if value := strings.Join(header.Values("Forwarded"), ","); value != "" {
+		elements := strings.Split(value, ",")
+		for i := len(elements) - 1; i >= 0; i-- {
+			fwd = forwarded{}
+			for _, pair := range strings.Split(elements[i], ";") {
+				kv := strings.SplitN(strings.TrimSpace(pair), "=", 2)
+				if len(kv) != 2 {
+					continue
+				}
+				v := strings.Trim(kv[1], `"`)
+				switch strings.ToLower(kv[0]) {
+				case "proto":
+					fwd.proto = strings.ToLower(v)
+				case "host":
+					fwd.host = v
+				case "for":
+					fwd.client = forwardedNode(v)
+				}
+			}
+			if !f.trustsAddr(fwd.client) {
+				break
+			}
+		}
+	} else {
+		clients := values(header, "X-Forwarded-For")
+		// hops is how many trusted proxies forwarded the request, or,
+		// absent X-Forwarded-For, none but the peer
+		hops := 0
+		if len(clients) > 0 {
+			hops = len(clients) - 1
+		}
+		for i := len(clients) - 1; i >= 0; i-- {
+			if !f.trustsAddr(clients[i]) {
+				hops = len(clients) - 1 - i
+				break
+			}
+		}
+		fwd.client = fromRight(clients, hops)
+		// each proxy may append its own, else overwrite the only one
+		fwd.proto = strings.ToLower(fromRight(values(header, "X-Forwarded-Proto"), hops))
+		fwd.host = fromRight(values(header, "X-Forwarded-Host"), hops)
+	}
+	// not covered by RFC 7239
+	fwd.prefix = fromRight(values(header, "X-Forwarded-Prefix"), 0)
+
+	if fwd.proto != "http" && fwd.proto != "https" {
+		fwd.proto = ""
+	}
+	if net.ParseIP(fwd.client) == nil {
+		fwd.client = ""
+	}
+	return fwd
+}
+
+// forwardedNode strips the port and brackets from a Forwarded node,
+// e.g., "[2001:db8::1]:4711".
+func forwardedNode(node string) string {
+	if host, _, err := net.SplitHostPort(node); err == nil {
+		return host
+	}
+	return strings.Trim(node, "[]")
+}
+
+// values splits the comma-separated values of a header, across
+// repeated header lines.
+func values(header http.Header, key string) []string {
+	var vs []string
+	for _, line := range header.Values(key) {
+		for _, v := range strings.Split(line, ",") {
+			vs = append(vs, strings.TrimSpace(v))
+		}
+	}
+	return vs
+}
+
+// fromRight returns the nth value from the right, else the leftmost.
+func fromRight(vs []string, n int) string {
+	if len(vs) == 0 {
+		return ""
+	}
+	if n >= len(vs) {
+		n = len(vs) - 1
+	}
+	return vs[len(vs)-1-n]
+}
+
+// joinPath joins path prefixes into a directory path, e.g., "/a/b/".
+func joinPath(prefixes ...string) string {
+	path := "/"
+	for _, prefix := range prefixes {
+		if prefix = strings.Trim(prefix, "/"); prefix != "" {
+			path += prefix + "/"
+		}
+	}
+	return path
+}
+
+type baseURLContextKey struct{}
+
+// WithBaseURL carries a public base URL in a context.
+func WithBaseURL(ctx context.Context, base *url.URL) context.Context {
+	return context.WithValue(ctx, baseURLContextKey{}, base)
+}
+
+// BaseURL returns the public base URL carried by a context, else
+// "http://localhost/".
+func BaseURL(ctx context.Context) *url.URL {
+	if base, ok := ctx.Value(baseURLContextKey{}).(*url.URL); ok {
+		return base
+	}
+	return &url.URL{Scheme: "http", Host: "localhost", Path: "/"}
+}
@@ -0,0 +1,115 @@
+package proxy
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestParseForwarded(t *testing.T) {
+	trusted, err := ParseCIDRs("127.0.0.1, 10.0.0.0/8")
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := &Forwarding{Trusted: trusted}
+
+	tests := []struct {
+		name   string
+		header http.Header
+		want   forwarded
+	}{
+		{
+			name:   "X-Forwarded-For single",
+			header: http.Header{"X-Forwarded-For": {"203.0.113.7"}, "X-Forwarded-Proto": {"HTTPS"}, "X-Forwarded-Host": {"tv.example.com"}},
+			want:   forwarded{proto: "https", host: "tv.example.com", client: "203.0.113.7"},
+		},
+		{
+			name:   "X-Forwarded-For spoofed by the client",
+			header: http.Header{"X-Forwarded-For": {"198.51.100.1, 203.0.113.7"}},
+			want:   forwarded{client: "203.0.113.7"},
+		},
+		{
+			name:   "X-Forwarded-For past trusted proxies",
+			header: http.Header{"X-Forwarded-For": {"198.51.100.1, 203.0.113.7", "10.1.2.3"}, "X-Forwarded-Proto": {"http, https, http"}},
+			want:   forwarded{proto: "https", client: "203.0.113.7"},
+		},
+		{
+			name:   "X-Forwarded-For all trusted",
+			header: http.Header{"X-Forwarded-For": {"10.1.2.3, 10.4.5.6"}},
+			want:   forwarded{client: "10.1.2.3"},
+		},
+		{
+			name:   "X-Forwarded-For garbage",
+			header: http.Header{"X-Forwarded-For": {"198.51.100.1, not-an-address"}},
+			want:   forwarded{},
+		},
+		{
+			name:   "X-Forwarded-Proto and -Host alone",
+			header: http.Header{"X-Forwarded-Proto": {"https"}, "X-Forwarded-Host": {"tv.example.com"}},
+			want:   forwarded{proto: "https", host: "tv.example.com"},
+		},
+		{
+			name:   "Forwarded spoofed by the client",
+			header: http.Header{"Forwarded": {`for=198.51.100.1;host=evil.example, for=203.0.113.7;proto=https;host=tv.example.com`}},
+			want:   forwarded{proto: "https", host: "tv.example.com", client: "203.0.113.7"},
+		},
+		{
+			name:   "Forwarded past trusted proxies",
+			header: http.Header{"Forwarded": {`for="[2001:db8::1]:4711";proto=https`, `for=10.1.2.3;proto=http`}},
+			want:   forwarded{proto: "https", client: "2001:db8::1"},
+		},
+		{
+			name:   "Forwarded obfuscated",
+			header: http.Header{"Forwarded": {`for=198.51.100.1, for=_hidden;proto=https`}},
+			want:   forwarded{proto: "https"},
+		},
+		{
+			name:   "Forwarded over X-Forwarded-For",
+			header: http.Header{"Forwarded": {`for=203.0.113.7`}, "X-Forwarded-For": {"198.51.100.1"}, "X-Forwarded-Prefix": {"/spoofed, /tvql"}},
+			want:   forwarded{client: "203.0.113.7", prefix: "/tvql"},
+		},
+	}
+	for _, test := range tests {
+		if got := f.parseForwarded(test.header); got != test.want {
+			t.Errorf("%s: %+v, want %+v", test.name, got, test.want)
+		}
+	}
+}
+
+func TestHandler(t *testing.T) {
+	trusted, err := ParseCIDRs("127.0.0.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := &Forwarding{Trusted: trusted, MountPath: "/app/"}
+
+	var base, remote string
+	h := f.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		base, remote = BaseURL(r.Context()).String(), r.RemoteAddr
+	}))
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.RemoteAddr = "127.0.0.1:5000"
+	r.Header.Set("X-Forwarded-For", "198.51.100.1, 203.0.113.7")
+	r.Header.Set("X-Forwarded-Host", "tv.example.com")
+	h.ServeHTTP(httptest.NewRecorder(), r)
+	if base != "http://tv.example.com/app/" || remote != "203.0.113.7:0" {
+		t.Errorf("via trusted proxy: base %s, remote %s", base, remote)
+	}
+
+	r = httptest.NewRequest(http.MethodGet, "/", nil)
+	r.RemoteAddr = "127.0.0.1:5000"
+	r.Header.Set("X-Forwarded-Proto", "https")
+	r.Header.Set("X-Forwarded-Host", "tv.example.com")
+	h.ServeHTTP(httptest.NewRecorder(), r)
+	if base != "https://tv.example.com/app/" || remote != "127.0.0.1:5000" {
+		t.Errorf("via trusted proxy, sans X-Forwarded-For: base %s, remote %s", base, remote)
+	}
+
+	r.Header.Set("X-Forwarded-For", "198.51.100.1, 203.0.113.7")
+	r.RemoteAddr = "192.0.2.9:5000"
+	h.ServeHTTP(httptest.NewRecorder(), r)
+	if base != "http://example.com/app/" || remote != "192.0.2.9:5000" {
+		t.Errorf("via untrusted peer: base %s, remote %s", base, remote)
+	}
+}
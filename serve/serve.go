@@ -0,0 +1,210 @@
+// Package serve runs the HTTP server, optionally over TLS (and thus
+// HTTP/2), redirecting plain HTTP to HTTPS, along with background
+// tasks, until shut down.
+package serve
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/idiomatic/tvql/logging"
+)
+
+// Config describes how to serve.
+type Config struct {
+	// e.g., ":8080"
+	Addr string
+	// TLS certificate and key (optional), reloaded upon change
+	CertFile string
+	KeyFile  string
+	// serve TLS with a generated certificate, saved to CertFile and
+	// KeyFile if set and absent
+	SelfSigned bool
+	// plain HTTP address redirecting to HTTPS (optional), e.g., ":80"
+	RedirectAddr string
+	// how long to drain connections upon shutdown
+	ShutdownTimeout time.Duration
+}
+
+// TLS reports whether the config calls for TLS.
+func (c Config) TLS() bool {
+	return c.SelfSigned || (c.CertFile != "" && c.KeyFile != "")
+}
+
+// Server serves a handler per its config, and runs background tasks,
+// until its context is done.
+type Server struct {
+	config   Config
+	server   *http.Server
+	redirect *http.Server
+
+	// requests (e.g., subscriptions on hijacked connections) outlive
+	// draining unless canceled
+	cancel context.CancelFunc
+
+	tasks      []func(ctx context.Context) error
+	onShutdown []func(ctx context.Context) error
+}
+
+func New(config Config, h http.Handler) (*Server, error) {
+	base, cancel := context.WithCancel(context.Background())
+	s := &Server{
+		config: config,
+		server: &http.Server{
+			Addr:        config.Addr,
+			Handler:     h,
+			BaseContext: func(net.Listener) context.Context { return base },
+		},
+		cancel: cancel,
+	}
+
+	if config.TLS() {
+		certificates, err := newCertificates(config)
+		if err != nil {
+			return nil, err
+		}
+		// HTTP/2 is negotiated by net/http, absent TLSNextProto
+		s.server.TLSConfig = &tls.Config{
+			MinVersion:     tls.VersionTLS12,
+			GetCertificate: certificates.get,
+		}
+
+		if config.RedirectAddr != "" {
+			_, port, err := net.SplitHostPort(config.Addr)
+			if err != nil {
+				return nil, err
+			}
+			s.redirect = &http.Server{
+				Addr:    config.RedirectAddr,
+				Handler: redirectHandler(port),
+			}
+		}
+	} else if config.RedirectAddr != "" {
+		return nil, fmt.Errorf("redirecting to HTTPS without TLS")
+	}
+
+	return s, nil
+}
+
+// Go runs a background task (e.g., a library survey) alongside the
+// server.  Its context is done upon shutdown, which awaits it.  Other
+// than cancellation, its failure shuts down the server.
+func (s *Server) Go(task func(ctx context.Context) error) {
+	s.tasks = append(s.tasks, task)
+}
+
+// OnShutdown registers a function (e.g., flushing a cache) to call
+// once connections are drained and tasks are done.
+func (s *Server) OnShutdown(fn func(ctx context.Context) error) {
+	s.onShutdown = append(s.onShutdown, fn)
+}
+
+// Run serves until ctx is done, then shuts down gracefully: draining
+// connections (for at most the shutdown timeout), canceling tasks, and
+// calling shutdown functions.  Returns nil if all went cleanly.
+func (s *Server) Run(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	errs := make(chan error, 2+len(s.tasks))
+	listen := func(server *http.Server, tls bool) {
+		var err error
+		if tls {
+			// certificates come from TLSConfig
+			err = server.ListenAndServeTLS("", "")
+		} else {
+			err = server.ListenAndServe()
+		}
+		if !errors.Is(err, http.ErrServerClosed) {
+			errs <- err
+		}
+	}
+	go listen(s.server, s.config.TLS())
+	if s.redirect != nil {
+		go listen(s.redirect, false)
+	}
+
+	var tasks sync.WaitGroup
+	for _, task := range s.tasks {
+		tasks.Add(1)
+		go func(task func(context.Context) error) {
+			defer tasks.Done()
+			if err := task(ctx); err != nil && ctx.Err() == nil {
+				errs <- err
+			}
+		}(task)
+	}
+
+	var err error
+	select {
+	case <-ctx.Done():
+		logging.Default().Info("shutting down")
+	case err = <-errs:
+		logging.Default().Error("shutting down", "error", err)
+	}
+	cancel()
+
+	if shutdownErr := s.shutdown(); err == nil {
+		err = shutdownErr
+	}
+	tasks.Wait()
+
+	// regardless of draining
+	flush, cancelFlush := context.WithTimeout(context.Background(), s.config.ShutdownTimeout)
+	defer cancelFlush()
+	for _, fn := range s.onShutdown {
+		if flushErr := fn(flush); err == nil {
+			err = flushErr
+		}
+	}
+
+	return err
+}
+
+// shutdown drains connections, closing those remaining at timeout.
+func (s *Server) shutdown() error {
+	drain, cancel := context.WithTimeout(context.Background(), s.config.ShutdownTimeout)
+	defer cancel()
+
+	servers := []*http.Server{s.server}
+	if s.redirect != nil {
+		servers = append(servers, s.redirect)
+	}
+
+	var err error
+	for _, server := range servers {
+		if shutdownErr := server.Shutdown(drain); shutdownErr != nil {
+			// e.g., downloads outlasting the timeout
+			server.Close()
+			if err == nil {
+				err = fmt.Errorf("draining connections: %w", shutdownErr)
+			}
+		}
+	}
+
+	// e.g., websocket subscriptions
+	s.cancel()
+	return err
+}
+
+// redirectHandler redirects to the same URL via HTTPS on port.
+func redirectHandler(port string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host := r.Host
+		if h, _, err := net.SplitHostPort(r.Host); err == nil {
+			host = h
+		}
+		if port != "443" && port != "" {
+			host = net.JoinHostPort(host, port)
+		}
+
+		target := "https://" + host + r.URL.RequestURI()
+		http.Redirect(w, r, target, http.StatusPermanentRedirect)
+	})
+}
@@ -0,0 +1,103 @@
+package serve
+
+import (
+	"context"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// freeAddr returns a loopback address likely free to listen on.
+func freeAddr(t *testing.T) string {
+	t.Helper()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	return l.Addr().String()
+}
+
+func TestRunDrains(t *testing.T) {
+	addr := freeAddr(t)
+	started, release := make(chan struct{}), make(chan struct{})
+	s, err := New(Config{Addr: addr, ShutdownTimeout: 5 * time.Second}, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-release
+		w.Write([]byte("drained"))
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var order []string
+	s.Go(func(ctx context.Context) error {
+		<-ctx.Done()
+		order = append(order, "task")
+		return ctx.Err()
+	})
+	s.OnShutdown(func(ctx context.Context) error {
+		order = append(order, "flush")
+		return ctx.Err()
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ran := make(chan error, 1)
+	go func() { ran <- s.Run(ctx) }()
+
+	type response struct {
+		body string
+		err  error
+	}
+	responses := make(chan response, 1)
+	go func() {
+		for {
+			resp, err := http.Get("http://" + addr + "/")
+			if err != nil {
+				// not yet listening
+				time.Sleep(10 * time.Millisecond)
+				continue
+			}
+			body, err := ioutil.ReadAll(resp.Body)
+			resp.Body.Close()
+			responses <- response{string(body), err}
+			return
+		}
+	}()
+
+	select {
+	case <-started:
+	case <-time.After(5 * time.Second):
+		t.Fatal("request never arrived")
+	}
+	cancel()
+
+	select {
+	case err := <-ran:
+		t.Fatalf("returned before draining: %v", err)
+	case <-time.After(100 * time.Millisecond):
+	}
+	close(release)
+
+	select {
+	case resp := <-responses:
+		if resp.err != nil || resp.body != "drained" {
+			t.Errorf("in-flight request: %q, %v", resp.body, resp.err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("in-flight request never completed")
+	}
+	select {
+	case err := <-ran:
+		if err != nil {
+			t.Errorf("Run: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Run never returned")
+	}
+	if len(order) != 2 || order[0] != "task" || order[1] != "flush" {
+		t.Errorf("shut down in order %v, want task then flush", order)
+	}
+}
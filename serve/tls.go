@@ -0,0 +1,199 @@
+package serve
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/idiomatic/tvql/logging"
+)
+
+const (
+	// how often to check certificate files for change
+	reloadInterval = 10 * time.Second
+	// lifetime of generated certificates
+	selfSignedTTL = 365 * 24 * time.Hour
+)
+
+// certificates loads a certificate, reloading it whenever its files
+// change (e.g., upon renewal).
+type certificates struct {
+	certFile, keyFile string
+
+	mutex       sync.Mutex
+	certificate *tls.Certificate
+	modTime     time.Time
+	checked     time.Time
+	now         func() time.Time
+}
+
+func newCertificates(config Config) (*certificates, error) {
+	c := &certificates{certFile: config.CertFile, keyFile: config.KeyFile, now: time.Now}
+
+	if config.SelfSigned {
+		if c.certFile == "" || c.keyFile == "" {
+			// XXX clients cannot pin a certificate regenerated upon restart
+			certificate, err := selfSigned()
+			if err != nil {
+				return nil, err
+			}
+			c.certificate = certificate
+			return c, nil
+		}
+
+		_, err := os.Stat(c.certFile)
+		if errors.Is(err, os.ErrNotExist) {
+			if err := c.saveSelfSigned(); err != nil {
+				return nil, err
+			}
+		} else if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := c.load(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// latest is the latest modification time of the files.
+func (c *certificates) latest() (time.Time, error) {
+	var latest time.Time
+	for _, path := range []string{c.certFile, c.keyFile} {
+		info, err := os.Stat(path)
+		if err != nil {
+			return latest, err
+		}
+		if info.ModTime().After(latest) {
+			latest = info.ModTime()
+		}
+	}
+	return latest, nil
+}
+
+// load reads the certificate and key files, if changed.
+func (c *certificates) load() error {
+	modTime, err := c.latest()
+	if err != nil {
+		return err
+	}
+	if c.certificate != nil && modTime.Equal(c.modTime) {
+		return nil
+	}
+
+	certificate, err := tls.LoadX509KeyPair(c.certFile, c.keyFile)
+	if err != nil {
+		return err
+	}
+	if c.certificate != nil {
+		logging.Default().Info("reloaded TLS certificate", "path", c.certFile)
+	}
+	c.certificate = &certificate
+	c.modTime = modTime
+	return nil
+}
+
+// get is a tls.Config GetCertificate, reloading at most every
+// reloadInterval.  Upon failure, the prior certificate is kept.
+func (c *certificates) get(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if now := c.now(); c.certFile != "" && now.Sub(c.checked) >= reloadInterval {
+		c.checked = now
+		if err := c.load(); err != nil {
+			// e.g., mid-renewal
+			logging.Default().Error("TLS certificate reload failed", "path", c.certFile, "error", err)
+		}
+	}
+	return c.certificate, nil
+}
+
+// saveSelfSigned writes a generated certificate and key.
+func (c *certificates) saveSelfSigned() error {
+	certificate, err := selfSigned()
+	if err != nil {
+		return err
+	}
+
+	key, err := x509.MarshalPKCS8PrivateKey(certificate.PrivateKey)
+	if err != nil {
+		return err
+	}
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: key})
+	if err := ioutil.WriteFile(c.keyFile, keyPEM, 0600); err != nil {
+		return err
+	}
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certificate.Certificate[0]})
+	if err := ioutil.WriteFile(c.certFile, certPEM, 0644); err != nil {
+		return err
+	}
+
+	logging.Default().Info("generated self-signed TLS certificate", "path", c.certFile)
+	return nil
+}
+
+// selfSigned generates a certificate for this host's names and
+// addresses, for LAN use.
+func selfSigned() (*tls.Certificate, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, err
+	}
+
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "localhost"
+	}
+
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{Organization: []string{"tvql"}, CommonName: hostname},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(selfSignedTTL),
+		KeyUsage:              x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		DNSNames:              []string{hostname, "localhost"},
+	}
+	if hostname != "localhost" {
+		template.DNSNames = append(template.DNSNames, hostname+".local")
+	}
+
+	addrs, err := net.InterfaceAddrs()
+	if err != nil {
+		return nil, err
+	}
+	for _, addr := range addrs {
+		if ipnet, ok := addr.(*net.IPNet); ok {
+			template.IPAddresses = append(template.IPAddresses, ipnet.IP)
+		}
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return nil, fmt.Errorf("self-signed certificate: %w", err)
+	}
+
+	return &tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}, nil
+}
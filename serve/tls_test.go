@@ -0,0 +1,175 @@
+package serve
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// certified returns the DER certificate served.
+func certified(t *testing.T, c *certificates) []byte {
+	t.Helper()
+	certificate, err := c.get(&tls.ClientHelloInfo{})
+	if err != nil || certificate == nil {
+		t.Fatalf("no certificate: %v", err)
+	}
+	return certificate.Certificate[0]
+}
+
+// touch sets the modification time of files, lest a rewrite within
+// the file system's timestamp granularity go unnoticed.
+func touch(t *testing.T, modTime time.Time, paths ...string) {
+	t.Helper()
+	for _, path := range paths {
+		if err := os.Chtimes(path, modTime, modTime); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestSelfSigned(t *testing.T) {
+	dir := t.TempDir()
+	config := Config{
+		CertFile:   filepath.Join(dir, "cert.pem"),
+		KeyFile:    filepath.Join(dir, "key.pem"),
+		SelfSigned: true,
+	}
+
+	c, err := newCertificates(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der := certified(t, c)
+	leaf, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if leaf.NotAfter.Before(time.Now().Add(selfSignedTTL - time.Hour)) {
+		t.Errorf("expires %v", leaf.NotAfter)
+	}
+	if err := leaf.VerifyHostname("localhost"); err != nil {
+		t.Error(err)
+	}
+
+	if info, err := os.Stat(config.KeyFile); err != nil || info.Mode().Perm() != 0600 {
+		t.Errorf("key file: %v, %v", info, err)
+	}
+	if _, err := tls.LoadX509KeyPair(config.CertFile, config.KeyFile); err != nil {
+		t.Errorf("saved pair: %v", err)
+	}
+
+	// once saved, reused
+	again, err := newCertificates(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(certified(t, again), der) {
+		t.Error("regenerated despite saved certificate")
+	}
+
+	// without files, generated anew
+	ephemeral, err := newCertificates(Config{SelfSigned: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Equal(certified(t, ephemeral), der) {
+		t.Error("ephemeral certificate reused")
+	}
+}
+
+func TestCertificateReload(t *testing.T) {
+	dir := t.TempDir()
+	config := Config{CertFile: filepath.Join(dir, "cert.pem"), KeyFile: filepath.Join(dir, "key.pem")}
+	renewer := &certificates{certFile: config.CertFile, keyFile: config.KeyFile}
+	if err := renewer.saveSelfSigned(); err != nil {
+		t.Fatal(err)
+	}
+	modTime := time.Now().Add(-time.Hour)
+	touch(t, modTime, config.CertFile, config.KeyFile)
+
+	c, err := newCertificates(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	c.now = func() time.Time { return now }
+	original := certified(t, c)
+
+	// renewed
+	if err := renewer.saveSelfSigned(); err != nil {
+		t.Fatal(err)
+	}
+	touch(t, modTime.Add(time.Minute), config.CertFile, config.KeyFile)
+
+	if !bytes.Equal(certified(t, c), original) {
+		t.Error("reloaded within the reload interval")
+	}
+	now = now.Add(reloadInterval)
+	renewed := certified(t, c)
+	if bytes.Equal(renewed, original) {
+		t.Error("renewed certificate not reloaded")
+	}
+
+	// mid-renewal, e.g., the key not yet written
+	if err := ioutil.WriteFile(config.KeyFile, []byte("garbage"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	touch(t, modTime.Add(2*time.Minute), config.KeyFile)
+	now = now.Add(reloadInterval)
+	if !bytes.Equal(certified(t, c), renewed) {
+		t.Error("prior certificate not kept upon failed reload")
+	}
+
+	if _, err := newCertificates(Config{CertFile: filepath.Join(dir, "missing.pem"), KeyFile: config.KeyFile}); err == nil {
+		t.Error("loaded missing certificate")
+	}
+}
+
+func TestRedirectHandler(t *testing.T) {
+	for _, test := range []struct {
+		port, host, target, want string
+	}{
+		{"8443", "tv.example.com:8080", "/graphql?query=%7B%7D&x=1", "https://tv.example.com:8443/graphql?query=%7B%7D&x=1"},
+		{"443", "tv.example.com", "/a/b/", "https://tv.example.com/a/b/"},
+		{"443", "tv.example.com:80", "/", "https://tv.example.com/"},
+		{"8443", "[::1]:8080", "/x?y", "https://[::1]:8443/x?y"},
+		{"", "192.0.2.1:80", "/", "https://192.0.2.1/"},
+	} {
+		r := httptest.NewRequest(http.MethodPost, test.target, nil)
+		r.Host = test.host
+		w := httptest.NewRecorder()
+		redirectHandler(test.port).ServeHTTP(w, r)
+
+		if w.Code != http.StatusPermanentRedirect {
+			t.Errorf("%s%s: status %d", test.host, test.target, w.Code)
+		}
+		if location := w.Header().Get("Location"); location != test.want {
+			t.Errorf("%s%s: redirected to %s, want %s", test.host, test.target, location, test.want)
+		}
+	}
+}
+
+func TestNewRedirect(t *testing.T) {
+	if _, err := New(Config{Addr: ":8080", RedirectAddr: ":8081"}, http.NotFoundHandler()); err == nil {
+		t.Error("redirecting to HTTPS without TLS")
+	}
+
+	s, err := New(Config{Addr: ":8443", RedirectAddr: ":8080", SelfSigned: true}, http.NotFoundHandler())
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := httptest.NewRequest(http.MethodGet, "/x?y=z", nil)
+	r.Host = "tv.example.com:8080"
+	w := httptest.NewRecorder()
+	s.redirect.Handler.ServeHTTP(w, r)
+	if location := w.Header().Get("Location"); location != "https://tv.example.com:8443/x?y=z" {
+		t.Errorf("redirected to %s", location)
+	}
+}
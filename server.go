@@ -1,28 +1,68 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"io"
 	"log"
 	"net/http"
 	"net/url"
 	"os"
-	"strconv"
+	"os/signal"
 	"strings"
+	"syscall"
+	"time"
 
 	"github.com/99designs/gqlgen/graphql/handler"
+	"github.com/99designs/gqlgen/graphql/handler/extension"
+	"github.com/99designs/gqlgen/graphql/handler/lru"
+	"github.com/99designs/gqlgen/graphql/handler/transport"
 	"github.com/99designs/gqlgen/graphql/playground"
+	"github.com/idiomatic/tvql/artcache"
+	"github.com/idiomatic/tvql/auth"
 	"github.com/idiomatic/tvql/graph"
 	"github.com/idiomatic/tvql/graph/generated"
+	"github.com/idiomatic/tvql/graph/loaders"
 	"github.com/idiomatic/tvql/graph/model"
+	"github.com/idiomatic/tvql/health"
+	"github.com/idiomatic/tvql/logging"
+	"github.com/idiomatic/tvql/metrics"
+	"github.com/idiomatic/tvql/optional"
+	"github.com/idiomatic/tvql/proxy"
+	"github.com/idiomatic/tvql/serve"
+	"github.com/idiomatic/tvql/signing"
+	"github.com/idiomatic/tvql/stream"
 )
 
 const (
-	defaultBase = "http://localhost/"
-	defaultPort = "8080"
-	defaultRoot = "/Users/brian/Review/Video"
+	defaultTrustedProxies   = "127.0.0.0/8 ::1"
+	defaultPort             = "8080"
+	defaultRoot             = "/Users/brian/Review/Video"
+	defaultState            = "tvql-profiles.json"
+	defaultCollections      = "tvql-collections.json"
+	defaultMaxComplexity    = 5000
+	defaultMaxDepth         = 12
+	defaultQueryTimeout     = 10 * time.Second
+	defaultCacheMaxAge      = 60
+	defaultSignedURLTTL     = 6 * time.Hour
+	defaultSessionTTL       = 30 * 24 * time.Hour
+	defaultShutdownTimeout  = 30 * time.Second
+	defaultArtworkCache     = "tvql-artwork"
+	defaultArtworkCacheSize = 512
+	defaultArtworkMaxAge    = 24 * 60 * 60
 )
 
 func main() {
+	logger, err := newLogger()
+	if err != nil {
+		fatal(err)
+	}
+	logging.SetDefault(logger)
+	// e.g., net/http errors
+	log.SetFlags(0)
+	log.SetOutput(logger.Writer(logging.LevelWarn))
+
 	port := os.Getenv("PORT")
 	if port == "" {
 		port = defaultPort
@@ -33,80 +73,414 @@ func main() {
 		root = defaultRoot
 	}
 
-	base, err := url.Parse(defaultBase)
+	// public base URLs are derived per request, unless configured
+	forwarding := &proxy.Forwarding{
+		MountPath: os.Getenv("BASE_PATH"),
+	}
+	if s := os.Getenv("BASE_URL"); s != "" {
+		forwarding.BaseURL, err = url.Parse(s)
+		if err != nil {
+			fatal(err)
+		}
+		if !strings.HasSuffix(forwarding.BaseURL.Path, "/") {
+			forwarding.BaseURL.Path += "/"
+		}
+	}
+
+	trustedProxies, ok := os.LookupEnv("TRUSTED_PROXIES")
+	if !ok {
+		trustedProxies = defaultTrustedProxies
+	}
+	forwarding.Trusted, err = proxy.ParseCIDRs(trustedProxies)
 	if err != nil {
-		log.Panic(err)
+		fatal(err)
 	}
-	if idx := strings.Index(base.Host, ":"); idx != -1 {
-		base.Host = base.Host[:idx]
+
+	// relative to the public base URL
+	videoBase := &url.URL{Path: "video/"}
+	artworkBase := &url.URL{Path: "artwork/"}
+	streamBase := &url.URL{Path: "stream/"}
+
+	var rescan time.Duration
+	if s := os.Getenv("RESCAN"); s != "" {
+		rescan, err = time.ParseDuration(s)
+		if err != nil {
+			fatal(err)
+		}
 	}
-	base.Host = fmt.Sprintf("%s:%s", base.Host, port)
-	videoBase := base.ResolveReference(&url.URL{Path: "/video/"})
-	artworkBase := base.ResolveReference(&url.URL{Path: "/artwork/"})
 
 	library := model.NewLibrary()
-	go func() {
-		err := library.Survey(root)
+	library.OnSurveyed = func(result model.SurveyResult) {
+		metrics.ObserveSurvey(result.Duration, result.Files, result.Err)
+
+		kv := []interface{}{"root", result.Root, "files", result.Files, "videos_added", result.VideosAdded, "duration", result.Duration}
+		if result.Err != nil {
+			logger.Warn("survey stopped", append(kv, "error", result.Err)...)
+		} else {
+			logger.Info("survey done", kv...)
+		}
+	}
+	library.OnFileSurveyed = func(result model.FileSurveyResult) {
+		kv := []interface{}{"root", result.Root, "path", result.Path, "outcome", result.Outcome, "duration", result.Duration}
+		switch {
+		case result.Err != nil:
+			logger.Error("video survey failed", append(kv, "error", result.Err)...)
+		case result.MetadataErr != nil:
+			// listed nonetheless, by file name
+			logger.Warn("video metadata unreadable", append(kv, "error", result.MetadataErr)...)
+		default:
+			logger.Debug("video surveyed", kv...)
+		}
+	}
+
+	// newest first, for rotation
+	signingKeys, err := signing.ParseKeys(os.Getenv("URL_SIGNING_KEYS"))
+	if err != nil {
+		fatal(err)
+	}
+
+	signedURLTTL := defaultSignedURLTTL
+	if s := os.Getenv("SIGNED_URL_TTL"); s != "" {
+		signedURLTTL, err = time.ParseDuration(s)
 		if err != nil {
-			log.Panic(err)
+			fatal(err)
 		}
+	}
+
+	signer, err := signing.New(signingKeys, signedURLTTL, os.Getenv("SIGNED_URL_BIND_CLIENT") != "")
+	if err != nil {
+		fatal(err)
+	}
+
+	sessionTTL := defaultSessionTTL
+	if s := os.Getenv("SESSION_TTL"); s != "" {
+		sessionTTL, err = time.ParseDuration(s)
+		if err != nil {
+			fatal(err)
+		}
+	}
+
+	// without $AUTH, everyone is an administrator
+	authenticator, err := auth.Open(os.Getenv("AUTH"), sessionTTL)
+	if err != nil {
+		fatal(err)
+	}
+	if authenticator.Open() {
+		logger.Warn("AUTH unset; admitting everyone as administrator")
+	}
+	http.Handle("/login", authenticator.Login())
+	http.Handle("/logout", authenticator.Logout())
+
+	streamHandler := stream.NewHandler(library)
+
+	http.Handle("/"+videoBase.Path, metrics.Instrument("video",
+		signer.Require(
+			http.StripPrefix("/"+videoBase.Path,
+				metrics.CountVideoBytes(
+					streamHandler.FastStart(
+						InternalRedirect(func(u *url.URL) {
+							// map rendition.id to relative path
+							metarendition, ok := library.Metarendition(u.Path)
+							if ok {
+								u.Path = strings.TrimPrefix(metarendition.Path, root)
+							}
+						}, http.FileServer(http.Dir(root)))))))))
+
+	state := os.Getenv("STATE")
+	if state == "" {
+		state = defaultState
+	}
+
+	profiles, err := model.OpenProfiles(state)
+	if err != nil {
+		fatal(err)
+	}
+
+	collectionsPath := os.Getenv("COLLECTIONS")
+	if collectionsPath == "" {
+		collectionsPath = defaultCollections
+	}
+
+	collections, err := model.OpenCollections(collectionsPath)
+	if err != nil {
+		fatal(err)
+	}
+
+	// playlists sign what they list, for the profile they were issued to
+	streamHandler.Permits = func(r *http.Request, videoID string) bool {
+		profile, ok := profiles.Get(r.URL.Query().Get(graph.ProfileParam))
+		if !ok {
+			return false
+		}
+		node, ok := library.Node(videoID)
+		if !ok {
+			return false
+		}
+		video, ok := node.(*model.Video)
+		return ok && library.Permits(profile, video)
+	}
+	streamHandler.Sign = func(r *http.Request, path string) string {
+		return signer.Derive(r, &url.URL{Path: streamBase.Path + path}).RawQuery
+	}
+	http.Handle("/"+streamBase.Path, metrics.Instrument("stream",
+		signer.Require(http.StripPrefix("/"+streamBase.Path, streamHandler))))
+
+	artworkCacheDir, ok := os.LookupEnv("ARTWORK_CACHE")
+	if !ok {
+		artworkCacheDir = defaultArtworkCache
+	}
+	artworkCacheSize := defaultArtworkCacheSize
+	if n := optional.Atoi(os.Getenv("ARTWORK_CACHE_SIZE")); n != nil {
+		artworkCacheSize = *n
+	}
+	artworks, err := artcache.New(library, artworkCacheSize, artworkCacheDir)
+	if err != nil {
+		fatal(err)
+	}
+
+	resolver := graph.NewResolver(library, profiles, collections, videoBase, artworkBase, streamBase, signer, artworks)
+
+	artworkMaxAge := defaultArtworkMaxAge
+	if n := optional.Atoi(os.Getenv("ARTWORK_MAX_AGE")); n != nil {
+		artworkMaxAge = *n
+	}
+	http.Handle("/"+artworkBase.Path, metrics.Instrument("artwork",
+		signer.Require(http.StripPrefix("/"+artworkBase.Path,
+			artworks.Handler(artworkMaxAge, signer.Bound())))))
+
+	maxComplexity := defaultMaxComplexity
+	if n := optional.Atoi(os.Getenv("MAX_COMPLEXITY")); n != nil {
+		maxComplexity = *n
+	}
+
+	maxDepth := defaultMaxDepth
+	if n := optional.Atoi(os.Getenv("MAX_DEPTH")); n != nil {
+		maxDepth = *n
+	}
+
+	queryTimeout := defaultQueryTimeout
+	if s := os.Getenv("QUERY_TIMEOUT"); s != "" {
+		queryTimeout, err = time.ParseDuration(s)
+		if err != nil {
+			fatal(err)
+		}
+	}
+
+	config := generated.Config{Resolvers: resolver}
+	config.Directives.Admin = graph.Admin
+	graph.Complexity(&config.Complexity)
+
+	persistedQueries, err := graph.NewPersistedQueries(
+		os.Getenv("PERSISTED_ONLY") != "",
+		strings.Fields(os.Getenv("PERSISTED_QUERIES"))...)
+	if err != nil {
+		fatal(err)
+	}
+
+	// besides those of persisted queries, operation names to measure
+	metricsOperations := make(map[string]bool)
+	for _, name := range strings.Fields(os.Getenv("METRICS_OPERATIONS")) {
+		metricsOperations[name] = true
+	}
+
+	cacheMaxAge := defaultCacheMaxAge
+	if n := optional.Atoi(os.Getenv("CACHE_MAX_AGE")); n != nil {
+		cacheMaxAge = *n
+	}
+
+	// like handler.NewDefaultServer, but with persisted queries
+	srv := handler.New(generated.NewExecutableSchema(config))
+	srv.AddTransport(transport.Websocket{
+		KeepAlivePingInterval: 10 * time.Second,
+	})
+	srv.AddTransport(transport.Options{})
+	srv.AddTransport(transport.GET{})
+	srv.AddTransport(transport.POST{})
+	srv.AddTransport(transport.MultipartForm{})
+	srv.SetQueryCache(lru.New(1000))
+	srv.Use(extension.Introspection{})
+	srv.Use(extension.AutomaticPersistedQuery{Cache: persistedQueries})
+	srv.Use(persistedQueries)
+	srv.AroundOperations(loaders.Around(library))
+	srv.Use(extension.FixedComplexityLimit(maxComplexity))
+	srv.Use(graph.DepthLimit{Depth: maxDepth})
+	srv.Use(graph.Timeout{Duration: queryTimeout})
+	srv.Use(metrics.GraphQL{Known: persistedQueries.Known, Operations: metricsOperations})
+	srv.Use(logging.GraphQL{})
+	srv.SetErrorPresenter(graph.ErrorPresenter)
+	http.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		// wherever mounted
+		endpoint := proxy.BaseURL(r.Context()).Path + "query"
+		playground.Handler("GraphQL playground", endpoint).ServeHTTP(w, r)
+	})
+	version := func(r *http.Request) string {
+		// responses embed signed URLs, relative to the public base URL
+		version := fmt.Sprintf("%d.%d.%d.%d.%s", library.Snapshot().Version, profiles.Version(graph.SelectedProfile(r.Context())), collections.Version(), signer.Epoch(), proxy.BaseURL(r.Context()))
+		if signer.Bound() {
+			version += "." + signing.ClientAddr(r)
+		}
+		// responses vary by principal
+		if principal, ok := auth.PrincipalFrom(r.Context()); ok && !authenticator.Open() {
+			version += "." + principal.Name
+		}
+		return version
+	}
+	private := signer.Bound() || !authenticator.Open()
+	var query http.Handler = authenticator.Require(signing.WithClient(graph.WithProfileHeader(graph.CacheableGET(version, cacheMaxAge, private, srv))))
+	// rather than answer from a partial library
+	if os.Getenv("AWAIT_SURVEY") != "" {
+		query = graph.AwaitSurvey(library, query)
+	}
+	http.Handle("/query", metrics.Instrument("query", query))
+
+	// administrators only, unless on a listener of its own
+	metricsAddr := os.Getenv("METRICS_ADDR")
+	if metricsAddr == "" {
+		http.Handle("/metrics", authenticator.RequireAdmin(metrics.Handler()))
+	}
+	metrics.RegisterLibrary(func() metrics.LibraryStats {
+		snapshot := library.Snapshot()
+		return metrics.LibraryStats{
+			Videos:     len(snapshot.Metavideos),
+			Series:     len(snapshot.Series),
+			Renditions: len(snapshot.Metarenditions),
+			Bytes:      snapshot.RenditionBytes(),
+		}
+	})
+
+	var h http.Handler = http.DefaultServeMux
+	if mountPath := strings.TrimSuffix(forwarding.MountPath, "/"); mountPath != "" {
+		h = http.StripPrefix(mountPath, h)
+	}
+
+	shutdownTimeout := defaultShutdownTimeout
+	if s := os.Getenv("SHUTDOWN_TIMEOUT"); s != "" {
+		shutdownTimeout, err = time.ParseDuration(s)
+		if err != nil {
+			fatal(err)
+		}
+	}
+
+	// TLS (and HTTP/2) when given a certificate, or told to generate one
+	serveConfig := serve.Config{
+		Addr:            ":" + port,
+		CertFile:        os.Getenv("TLS_CERT"),
+		KeyFile:         os.Getenv("TLS_KEY"),
+		SelfSigned:      os.Getenv("TLS_SELF_SIGNED") != "",
+		ShutdownTimeout: shutdownTimeout,
+	}
+	if redirectPort := os.Getenv("HTTP_REDIRECT_PORT"); redirectPort != "" {
+		serveConfig.RedirectAddr = ":" + redirectPort
+	}
+
+	// probes are unlogged, and at the root regardless of $BASE_PATH
+	top := http.NewServeMux()
+	top.Handle("/healthz", health.Live())
+	top.Handle("/readyz", health.Ready(
+		health.Check{Name: "survey", Check: func() error {
+			if err := library.SurveyErr(); err != nil {
+				return err
+			}
+			if !library.Surveyed() {
+				return fmt.Errorf("initial survey incomplete")
+			}
+			return nil
+		}},
+		health.Check{Name: "root", Check: func() error {
+			return health.Dir(root)
+		}},
+	))
+	top.Handle("/", forwarding.Handler(logger.Requests(h)))
+
+	server, err := serve.New(serveConfig, top)
+	if err != nil {
+		fatal(err)
+	}
+
+	if metricsAddr != "" {
+		metricsServer := &http.Server{Addr: metricsAddr, Handler: metrics.Handler()}
+		server.Go(func(ctx context.Context) error {
+			go func() {
+				<-ctx.Done()
+				metricsServer.Close()
+			}()
+			if err := metricsServer.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+				return err
+			}
+			return nil
+		})
+	}
+
+	// a failed survey is logged, and reported by /readyz, not fatal
+	server.Go(func(ctx context.Context) error {
+		library.Survey(ctx, root)
+		if rescan > 0 {
+			return library.Watch(ctx, root, rescan)
+		}
+		return nil
+	})
+
+	// collection state is written upon each mutation, so draining
+	// suffices; playback positions are written lazily, so flush them
+	server.OnShutdown(func(ctx context.Context) error {
+		if err := profiles.Flush(); err != nil {
+			return fmt.Errorf("playback positions unsaved: %w", err)
+		}
+		return nil
+	})
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		// a second signal is fatal
+		<-ctx.Done()
+		stop()
 	}()
 
-	http.Handle(videoBase.Path,
-		http.StripPrefix(videoBase.Path,
-			InternalRedirect(func(u *url.URL) {
-				// map base64(video.id) to relative path
-				library.Mutex.Lock()
-				defer library.Mutex.Unlock()
-				metarendition, ok := library.Metarenditions[u.Path]
-				if ok {
-					u.Path = strings.TrimPrefix(metarendition.Path, root)
-				}
-			}, http.FileServer(http.Dir(root)))))
-
-	resolver := graph.NewResolver(library, videoBase, artworkBase)
-
-	http.Handle("/artwork/",
-		http.StripPrefix("/artwork/",
-			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-				id := r.URL.Path
-				q := r.URL.Query()
-
-				geometry := model.GeometryFilter{
-					Width:  Atoiptr(q.Get("width")),
-					Height: Atoiptr(q.Get("height")),
-				}
-
-				artwork, err := library.GetArtwork(id)
-				if err != nil {
-					http.Error(w, "artwork retrieval failed", http.StatusInternalServerError)
-				}
-
-				artwork, err = model.ResizeArtwork(artwork, &geometry)
-				if err != nil {
-					http.Error(w, "artwork resize failed", http.StatusInternalServerError)
-				}
-
-				w.Header().Add("Content-type", "image/jpeg")
-				w.Write(artwork)
-			})))
-
-	srv := handler.NewDefaultServer(
-		generated.NewExecutableSchema(generated.Config{Resolvers: resolver}))
-	http.Handle("/", playground.Handler("GraphQL playground", "/query"))
-	http.Handle("/query", srv)
-
-	log.Printf("connect to %s for GraphQL playground", base.String())
-	log.Fatal(http.ListenAndServe(":"+port, nil))
+	scheme := "http"
+	if serveConfig.TLS() {
+		scheme = "https"
+	}
+	logger.Info("connect for GraphQL playground", "scheme", scheme, "port", port, "path", forwarding.MountPath)
+	if err := server.Run(ctx); err != nil {
+		logger.Error("shut down uncleanly", "error", err)
+		os.Exit(1)
+	}
+	logger.Info("shut down")
 }
 
-// Atoiptr returns a pointer to an int parsed from a string, else nil.
-func Atoiptr(s string) *int {
-	value, err := strconv.Atoi(s)
+// newLogger logs at $LOG_LEVEL (default info) in $LOG_FORMAT (logfmt
+// or json) to $LOG_OUTPUT (a file, else stderr).
+func newLogger() (*logging.Logger, error) {
+	level, err := logging.ParseLevel(os.Getenv("LOG_LEVEL"))
 	if err != nil {
-		return nil
+		return nil, err
+	}
+	format, err := logging.ParseFormat(os.Getenv("LOG_FORMAT"))
+	if err != nil {
+		return nil, err
 	}
-	return &value
+
+	var w io.Writer = os.Stderr
+	switch path := os.Getenv("LOG_OUTPUT"); path {
+	case "", "stderr":
+	case "stdout":
+		w = os.Stdout
+	default:
+		// XXX not reopened upon rotation
+		w, err = os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return logging.New(w, level, format), nil
+}
+
+// fatal logs a startup failure and exits.
+func fatal(err error) {
+	logging.Default().Error("startup failed", "error", err)
+	os.Exit(1)
 }
 
 // InternalRedirect is like StripPrefix except it allows full
@@ -0,0 +1,188 @@
+// Package signing issues and verifies expiring, HMAC-signed URLs.
+package signing
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	expiresParam   = "expires"
+	signatureParam = "signature"
+)
+
+// Signer signs URLs with its first key, and verifies them with any,
+// so keys may be rotated without breaking outstanding URLs.
+type Signer struct {
+	keys [][]byte
+	ttl  time.Duration
+	// bind URLs to the address of the client they were issued to
+	bind bool
+	now  func() time.Time
+}
+
+func New(keys [][]byte, ttl time.Duration, bind bool) (*Signer, error) {
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("no signing keys")
+	}
+	if ttl <= 0 {
+		return nil, fmt.Errorf("nonpositive signed URL lifetime")
+	}
+	return &Signer{keys: keys, ttl: ttl, bind: bind, now: time.Now}, nil
+}
+
+// ParseKeys splits comma-separated keys, newest first.  If none,
+// returns a random key, so URLs do not survive restarts.
+func ParseKeys(s string) ([][]byte, error) {
+	var keys [][]byte
+	for _, key := range strings.Split(s, ",") {
+		if key = strings.TrimSpace(key); key != "" {
+			keys = append(keys, []byte(key))
+		}
+	}
+	if len(keys) == 0 {
+		key := make([]byte, sha256.Size)
+		if _, err := rand.Read(key); err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// Bound reports whether URLs are bound to clients.
+func (s *Signer) Bound() bool {
+	return s.bind
+}
+
+// window is the granularity of expiry times.  URLs signed within a
+// window are identical, so responses embedding them stay cacheable.
+func (s *Signer) window() time.Duration {
+	return s.ttl / 2
+}
+
+// Epoch changes whenever freshly signed URLs would.
+func (s *Signer) Epoch() int64 {
+	return s.now().UnixNano() / int64(s.window())
+}
+
+// Sign adds expiry and signature parameters to a URL, good for at
+// least the signer's lifetime.
+func (s *Signer) Sign(u *url.URL, client string) *url.URL {
+	expires := s.now().Truncate(s.window()).Add(s.ttl + s.window())
+
+	values := u.Query()
+	values.Del(signatureParam)
+	values.Set(expiresParam, strconv.FormatInt(expires.Unix(), 10))
+	values.Set(signatureParam, s.signature(s.keys[0], u.Path, values, client))
+
+	signed := *u
+	signed.RawQuery = values.Encode()
+	return &signed
+}
+
+// Derive signs a URL referenced by a verified request (e.g., a
+// playlist's segments), carrying the request's parameters and expiry.
+func (s *Signer) Derive(r *http.Request, u *url.URL) *url.URL {
+	values := u.Query()
+	for k, v := range r.URL.Query() {
+		values[k] = v
+	}
+	values.Set(signatureParam, s.signature(s.keys[0], u.Path, values, ClientAddr(r)))
+
+	signed := *u
+	signed.RawQuery = values.Encode()
+	return &signed
+}
+
+// signature covers the app-relative path, every parameter (but the
+// signature), and, if bound, the client.
+func (s *Signer) signature(key []byte, path string, values url.Values, client string) string {
+	unsigned := make(url.Values, len(values))
+	for k, v := range values {
+		if k != signatureParam {
+			unsigned[k] = v
+		}
+	}
+	if !s.bind {
+		client = ""
+	}
+
+	// relative to wherever the app is mounted
+	path = strings.TrimPrefix(path, "/")
+
+	mac := hmac.New(sha256.New, key)
+	fmt.Fprintf(mac, "%s?%s\n%s", path, unsigned.Encode(), client)
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks that a request bears an unexpired signature, by any
+// key, for its URL and client.
+func (s *Signer) Verify(r *http.Request) error {
+	values := r.URL.Query()
+
+	expires, err := strconv.ParseInt(values.Get(expiresParam), 10, 64)
+	if err != nil {
+		return fmt.Errorf("unsigned URL")
+	}
+	if s.now().Unix() > expires {
+		return fmt.Errorf("expired URL")
+	}
+
+	signature := values.Get(signatureParam)
+	client := ClientAddr(r)
+	for _, key := range s.keys {
+		expected := s.signature(key, r.URL.Path, values, client)
+		if hmac.Equal([]byte(signature), []byte(expected)) {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid URL signature")
+}
+
+// Require refuses requests lacking valid signatures.
+func (s *Signer) Require(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := s.Verify(r); err != nil {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+		h.ServeHTTP(w, r)
+	})
+}
+
+// ClientAddr is the IP address of a request's client.
+func ClientAddr(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+type clientContextKey struct{}
+
+// WithClient carries the client address in the request context, to
+// bind URLs signed while resolving.
+func WithClient(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r = r.WithContext(context.WithValue(r.Context(), clientContextKey{}, ClientAddr(r)))
+		h.ServeHTTP(w, r)
+	})
+}
+
+// Client returns the client address carried by a context, if any.
+func Client(ctx context.Context) string {
+	client, _ := ctx.Value(clientContextKey{}).(string)
+	return client
+}
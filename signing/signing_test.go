@@ -0,0 +1,185 @@
+package signing
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+var epoch = time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+
+func newSigner(t *testing.T, bind bool, keys ...string) (*Signer, *time.Time) {
+	t.Helper()
+	var bytes [][]byte
+	for _, key := range keys {
+		bytes = append(bytes, []byte(key))
+	}
+	s, err := New(bytes, time.Hour, bind)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := epoch
+	s.now = func() time.Time { return now }
+	return s, &now
+}
+
+// request is for u as if the app were mounted at the root.
+func request(u *url.URL, client string) *http.Request {
+	rooted := *u
+	rooted.Path = "/" + strings.TrimPrefix(u.Path, "/")
+	r := httptest.NewRequest(http.MethodGet, rooted.String(), nil)
+	r.RemoteAddr = client + ":5000"
+	return r
+}
+
+func TestSignExpiry(t *testing.T) {
+	s, now := newSigner(t, false, "key")
+	signed := s.Sign(&url.URL{Path: "video/abc"}, "")
+
+	for _, test := range []struct {
+		after time.Duration
+		valid bool
+	}{
+		{0, true},
+		{time.Hour, true},
+		{2 * time.Hour, false},
+	} {
+		*now = epoch.Add(test.after)
+		if err := s.Verify(request(signed, "192.0.2.1")); (err == nil) != test.valid {
+			t.Errorf("after %v: %v", test.after, err)
+		}
+	}
+}
+
+func TestSignWindow(t *testing.T) {
+	s, now := newSigner(t, false, "key")
+	u := &url.URL{Path: "video/abc"}
+
+	first, windowEpoch := s.Sign(u, "").String(), s.Epoch()
+	*now = now.Add(time.Second)
+	if second := s.Sign(u, "").String(); second != first || s.Epoch() != windowEpoch {
+		t.Errorf("resigned within a window: %s, %s", first, second)
+	}
+	*now = now.Add(s.window())
+	if third := s.Sign(u, "").String(); third == first || s.Epoch() == windowEpoch {
+		t.Errorf("not resigned in the next window: %s", third)
+	}
+}
+
+func TestSignTampering(t *testing.T) {
+	s, _ := newSigner(t, false, "key")
+	signed := s.Sign(&url.URL{Path: "/video/abc", RawQuery: "profile=p1"}, "")
+
+	if err := s.Verify(request(signed, "192.0.2.1")); err != nil {
+		t.Fatal(err)
+	}
+
+	tamper := map[string]func(values url.Values){
+		"profile":   func(values url.Values) { values.Set("profile", "p2") },
+		"added":     func(values url.Values) { values.Set("geometry", "x") },
+		"expires":   func(values url.Values) { values.Set(expiresParam, "99999999999") },
+		"signature": func(values url.Values) { values.Set(signatureParam, "AAAA") },
+		"unsigned":  func(values url.Values) { values.Del(signatureParam) },
+		"no expiry": func(values url.Values) { values.Del(expiresParam) },
+	}
+	for name, f := range tamper {
+		tampered := *signed
+		values := tampered.Query()
+		f(values)
+		tampered.RawQuery = values.Encode()
+		if err := s.Verify(request(&tampered, "192.0.2.1")); err == nil {
+			t.Errorf("%s: verified", name)
+		}
+	}
+
+	moved := *signed
+	moved.Path = "/video/xyz"
+	if err := s.Verify(request(&moved, "192.0.2.1")); err == nil {
+		t.Error("path: verified")
+	}
+
+	other, _ := newSigner(t, false, "other")
+	if err := other.Verify(request(signed, "192.0.2.1")); err == nil {
+		t.Error("other key: verified")
+	}
+}
+
+func TestSignClientBinding(t *testing.T) {
+	for _, bind := range []bool{false, true} {
+		s, _ := newSigner(t, bind, "key")
+		signed := s.Sign(&url.URL{Path: "video/abc"}, "192.0.2.1")
+
+		if err := s.Verify(request(signed, "192.0.2.1")); err != nil {
+			t.Errorf("bind %v, same client: %v", bind, err)
+		}
+		if err := s.Verify(request(signed, "198.51.100.1")); (err == nil) == bind {
+			t.Errorf("bind %v, other client: %v", bind, err)
+		}
+	}
+}
+
+func TestSignKeyRotation(t *testing.T) {
+	old, _ := newSigner(t, false, "old")
+	signed := old.Sign(&url.URL{Path: "video/abc"}, "")
+
+	rotated, _ := newSigner(t, false, "new", "old")
+	if err := rotated.Verify(request(signed, "192.0.2.1")); err != nil {
+		t.Errorf("rotated: %v", err)
+	}
+	if resigned := rotated.Sign(&url.URL{Path: "video/abc"}, ""); resigned.String() == signed.String() {
+		t.Error("rotated: signed with the old key")
+	}
+
+	retired, _ := newSigner(t, false, "new")
+	if err := retired.Verify(request(signed, "192.0.2.1")); err == nil {
+		t.Error("retired: verified")
+	}
+}
+
+func TestDerive(t *testing.T) {
+	s, now := newSigner(t, true, "key")
+	playlist := s.Sign(&url.URL{Path: "stream/r1/index.m3u8", RawQuery: "profile=p1"}, "192.0.2.1")
+	r := request(playlist, "192.0.2.1")
+	if err := s.Verify(r); err != nil {
+		t.Fatal(err)
+	}
+
+	segment := s.Derive(r, &url.URL{Path: "stream/r1/0.m4s"})
+	values := segment.Query()
+	if values.Get("profile") != "p1" || values.Get(expiresParam) != playlist.Query().Get(expiresParam) {
+		t.Errorf("derived %s lacks the playlist's parameters", segment)
+	}
+	if err := s.Verify(request(segment, "192.0.2.1")); err != nil {
+		t.Errorf("derived: %v", err)
+	}
+	if err := s.Verify(request(segment, "198.51.100.1")); err == nil {
+		t.Error("derived, other client: verified")
+	}
+
+	*now = epoch.Add(2 * time.Hour)
+	if err := s.Verify(request(segment, "192.0.2.1")); err == nil {
+		t.Error("derived, past the playlist's expiry: verified")
+	}
+}
+
+func TestRequire(t *testing.T) {
+	s, _ := newSigner(t, false, "key")
+	h := s.Require(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	for _, test := range []struct {
+		u      *url.URL
+		status int
+	}{
+		{s.Sign(&url.URL{Path: "/video/abc"}, ""), http.StatusOK},
+		{&url.URL{Path: "/video/abc"}, http.StatusForbidden},
+	} {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, request(test.u, "192.0.2.1"))
+		if w.Code != test.status {
+			t.Errorf("%s: %d, want %d", test.u, w.Code, test.status)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package stream
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+
+	mp4 "github.com/abema/go-mp4"
+)
+
+// rawBox is an undecoded box within a buffer.
+type rawBox struct {
+	typ     string
+	box     []byte
+	payload []byte
+}
+
+// rawBoxes splits a buffer into its boxes.
+func rawBoxes(buf []byte) ([]rawBox, error) {
+	var boxes []rawBox
+	for len(buf) > 0 {
+		if len(buf) < 8 {
+			return nil, fmt.Errorf("truncated box header")
+		}
+
+		size := uint64(binary.BigEndian.Uint32(buf))
+		typ := string(buf[4:8])
+		header := uint64(8)
+		switch size {
+		case 0:
+			size = uint64(len(buf))
+		case 1:
+			if len(buf) < 16 {
+				return nil, fmt.Errorf("truncated %s box header", typ)
+			}
+			size = binary.BigEndian.Uint64(buf[8:])
+			header = 16
+		}
+		if size < header || size > uint64(len(buf)) {
+			return nil, fmt.Errorf("malformed %s box size", typ)
+		}
+
+		boxes = append(boxes, rawBox{
+			typ:     typ,
+			box:     buf[:size],
+			payload: buf[header:size],
+		})
+		buf = buf[size:]
+	}
+	return boxes, nil
+}
+
+// findRawBox returns the first box of a type.
+func findRawBox(boxes []rawBox, typ string) (rawBox, bool) {
+	for _, box := range boxes {
+		if box.typ == typ {
+			return box, true
+		}
+	}
+	return rawBox{}, false
+}
+
+// containerBox wraps children in a box.
+func containerBox(typ string, children ...[]byte) []byte {
+	size := 8
+	for _, child := range children {
+		size += len(child)
+	}
+
+	buf := make([]byte, 8, size)
+	binary.BigEndian.PutUint32(buf, uint32(size))
+	copy(buf[4:], typ)
+	for _, child := range children {
+		buf = append(buf, child...)
+	}
+	return buf
+}
+
+// marshalBox encodes a box, with its header.
+func marshalBox(box mp4.IBox) ([]byte, error) {
+	var payload bytes.Buffer
+	if _, err := mp4.Marshal(&payload, box, mp4.Context{}); err != nil {
+		return nil, err
+	}
+
+	typ := box.GetType()
+	return containerBox(string(typ[:]), payload.Bytes()), nil
+}
@@ -0,0 +1,189 @@
+package stream
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+)
+
+const (
+	dashNamespace = "urn:mpeg:dash:schema:mpd:2011"
+	// segments are listed, each with its own (signed) URL
+	dashProfile = "urn:mpeg:dash:profile:isoff-main:2011"
+)
+
+// Representation is one rendition listed in an MPD.
+type Representation struct {
+	ID    string
+	Movie *Movie
+	// relative to the MPD
+	InitURI    string
+	SegmentURI func(index int) string
+}
+
+type mpd struct {
+	XMLName                   xml.Name `xml:"MPD"`
+	Namespace                 string   `xml:"xmlns,attr"`
+	Profiles                  string   `xml:"profiles,attr"`
+	Type                      string   `xml:"type,attr"`
+	MediaPresentationDuration string   `xml:"mediaPresentationDuration,attr"`
+	MinBufferTime             string   `xml:"minBufferTime,attr"`
+	Period                    mpdPeriod
+}
+
+type mpdPeriod struct {
+	XMLName       xml.Name `xml:"Period"`
+	ID            string   `xml:"id,attr"`
+	Start         string   `xml:"start,attr"`
+	AdaptationSet mpdAdaptationSet
+}
+
+type mpdAdaptationSet struct {
+	XMLName          xml.Name `xml:"AdaptationSet"`
+	MimeType         string   `xml:"mimeType,attr"`
+	SegmentAlignment bool     `xml:"segmentAlignment,attr"`
+	StartWithSAP     int      `xml:"startWithSAP,attr"`
+	Representations  []mpdRepresentation
+}
+
+type mpdRepresentation struct {
+	XMLName     xml.Name `xml:"Representation"`
+	ID          string   `xml:"id,attr"`
+	Bandwidth   int      `xml:"bandwidth,attr"`
+	Codecs      string   `xml:"codecs,attr"`
+	Width       int      `xml:"width,attr,omitempty"`
+	Height      int      `xml:"height,attr,omitempty"`
+	SegmentList mpdSegmentList
+}
+
+type mpdSegmentList struct {
+	XMLName        xml.Name `xml:"SegmentList"`
+	Timescale      uint32   `xml:"timescale,attr"`
+	Initialization struct {
+		SourceURL string `xml:"sourceURL,attr"`
+	}
+	Timeline    []mpdS          `xml:"SegmentTimeline>S"`
+	SegmentURLs []mpdSegmentURL `xml:"SegmentURL"`
+}
+
+type mpdSegmentURL struct {
+	Media string `xml:"media,attr"`
+}
+
+// mpdS is a run of r+1 segments, each of duration d.
+type mpdS struct {
+	T uint64 `xml:"t,attr,omitempty"`
+	D uint64 `xml:"d,attr"`
+	R int    `xml:"r,attr,omitempty"`
+}
+
+// WriteMPD lists the representations in one adaptation set, by
+// descending bandwidth.  Each has its own segment timeline, as
+// renditions need not share keyframes.
+func WriteMPD(w io.Writer, representations []Representation) error {
+	sort.SliceStable(representations, func(i, j int) bool {
+		a, _ := representations[i].Movie.Bandwidth()
+		b, _ := representations[j].Movie.Bandwidth()
+		return a > b
+	})
+
+	var duration float64
+	set := mpdAdaptationSet{
+		MimeType:         "video/mp4",
+		SegmentAlignment: false,
+		StartWithSAP:     1,
+	}
+	for _, representation := range representations {
+		movie := representation.Movie
+		if movie.Duration > duration {
+			duration = movie.Duration
+		}
+
+		list := mpdSegmentList{
+			Timescale: movie.Tracks[0].Timescale,
+			Timeline:  movie.timeline(),
+		}
+		list.Initialization.SourceURL = representation.InitURI
+		for index, segment := range movie.Segments {
+			if segment.ranges[0][0] == segment.ranges[0][1] {
+				// as omitted from the timeline
+				continue
+			}
+			list.SegmentURLs = append(list.SegmentURLs, mpdSegmentURL{Media: representation.SegmentURI(index)})
+		}
+
+		peak, _ := movie.Bandwidth()
+		width, height := movie.Resolution()
+		set.Representations = append(set.Representations, mpdRepresentation{
+			ID:          representation.ID,
+			Bandwidth:   peak,
+			Codecs:      movie.Codecs(),
+			Width:       width,
+			Height:      height,
+			SegmentList: list,
+		})
+	}
+
+	doc := mpd{
+		Namespace:                 dashNamespace,
+		Profiles:                  dashProfile,
+		Type:                      "static",
+		MediaPresentationDuration: dashDuration(duration),
+		MinBufferTime:             dashDuration(segmentDuration),
+		Period: mpdPeriod{
+			ID:            "0",
+			Start:         "PT0S",
+			AdaptationSet: set,
+		},
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(doc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// timeline is the segment timeline, in the reference track's
+// timescale, with repeated durations run-length encoded.
+func (m *Movie) timeline() []mpdS {
+	reference := m.Tracks[0]
+
+	var timeline []mpdS
+	var next uint64
+	for _, segment := range m.Segments {
+		samples := reference.Samples[segment.ranges[0][0]:segment.ranges[0][1]]
+		if len(samples) == 0 {
+			continue
+		}
+		start := samples[0].DTS
+		var duration uint64
+		for _, sample := range samples {
+			duration += uint64(sample.Duration)
+		}
+
+		if n := len(timeline); n > 0 && start == next && timeline[n-1].D == duration {
+			timeline[n-1].R++
+		} else {
+			s := mpdS{T: start, D: duration}
+			if n > 0 && start == next {
+				// implicitly continues
+				s.T = 0
+			}
+			timeline = append(timeline, s)
+		}
+		next = start + duration
+	}
+	return timeline
+}
+
+// dashDuration formats seconds as an xs:duration.
+func dashDuration(seconds float64) string {
+	return fmt.Sprintf("PT%.3fS", seconds)
+}
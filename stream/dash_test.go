@@ -0,0 +1,332 @@
+package stream
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	mp4 "github.com/abema/go-mp4"
+	"github.com/idiomatic/tvql/metadata/mp4/mp4test"
+)
+
+// fixture is a synthetic mp4, and its sample tables as written.
+type fixture struct {
+	data  []byte
+	movie *Movie
+	// per sample
+	sizes []uint32
+	// DTS of keyframes
+	keyframes map[uint64]bool
+	// of the mdat payload
+	mdatOffset int64
+}
+
+func newFixture(t *testing.T, spec mp4test.Spec) *fixture {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "fixture.m4v")
+	if err := mp4test.WriteFile(path, spec); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f := &fixture{data: data, keyframes: make(map[uint64]bool)}
+	if f.movie, err = ReadMovie(bytes.NewReader(data), int64(len(data))); err != nil {
+		t.Fatal(err)
+	}
+
+	stbl := mp4.BoxPath{mp4.BoxTypeMoov(), mp4.BoxTypeTrak(), mp4.BoxTypeMdia(), mp4.BoxTypeMinf(), mp4.BoxTypeStbl()}
+	boxes, err := mp4.ExtractBoxesWithPayload(bytes.NewReader(data), nil, []mp4.BoxPath{
+		append(stbl, mp4.BoxTypeStsz()),
+		append(stbl, mp4.BoxTypeStss()),
+		append(stbl, mp4.BoxTypeStts()),
+		{mp4.BoxTypeMdat()},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var delta uint32
+	for _, box := range boxes {
+		switch payload := box.Payload.(type) {
+		case *mp4.Stsz:
+			f.sizes = payload.EntrySize
+		case *mp4.Stts:
+			delta = payload.Entries[0].SampleDelta
+		case *mp4.Mdat:
+			f.mdatOffset = int64(box.Info.Offset + box.Info.HeaderSize)
+		}
+	}
+	for _, box := range boxes {
+		if stss, ok := box.Payload.(*mp4.Stss); ok {
+			for _, n := range stss.SampleNumber {
+				f.keyframes[uint64(n-1)*uint64(delta)] = true
+			}
+		}
+	}
+	return f
+}
+
+// samples returns the data of samples [first, last).
+func (f *fixture) samples(first, last int) []byte {
+	offset := f.mdatOffset
+	for _, size := range f.sizes[:first] {
+		offset += int64(size)
+	}
+	end := offset
+	for _, size := range f.sizes[first:last] {
+		end += int64(size)
+	}
+	return f.data[offset:end]
+}
+
+type testMPD struct {
+	Duration string `xml:"mediaPresentationDuration,attr"`
+	Periods  []struct {
+		AdaptationSets []struct {
+			Representations []struct {
+				ID          string `xml:"id,attr"`
+				Bandwidth   int    `xml:"bandwidth,attr"`
+				Width       int    `xml:"width,attr"`
+				SegmentList struct {
+					Timescale      uint64 `xml:"timescale,attr"`
+					Initialization struct {
+						SourceURL string `xml:"sourceURL,attr"`
+					}
+					S []struct {
+						T *uint64 `xml:"t,attr"`
+						D uint64  `xml:"d,attr"`
+						R int     `xml:"r,attr"`
+					} `xml:"SegmentTimeline>S"`
+					SegmentURLs []struct {
+						Media string `xml:"media,attr"`
+					} `xml:"SegmentURL"`
+				}
+			} `xml:"Representation"`
+		} `xml:"AdaptationSet"`
+	} `xml:"Period"`
+}
+
+func TestWriteMPD(t *testing.T) {
+	fixtures := map[string]*fixture{
+		"short": newFixture(t, mp4test.Spec{Untagged: true, Samples: 300}),
+		"long":  newFixture(t, mp4test.Spec{Untagged: true, Samples: 450, MoovLast: true}),
+	}
+	var representations []Representation
+	for id, f := range fixtures {
+		id := id
+		representations = append(representations, Representation{
+			ID:      id,
+			Movie:   f.movie,
+			InitURI: id + "/init.mp4?signed",
+			SegmentURI: func(index int) string {
+				return fmt.Sprintf("%s/%d.m4s?signed", id, index)
+			},
+		})
+	}
+
+	var buf bytes.Buffer
+	if err := WriteMPD(&buf, representations); err != nil {
+		t.Fatal(err)
+	}
+	var doc testMPD
+	if err := xml.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("%v\n%s", err, buf.String())
+	}
+
+	// the longest rendition, 450 samples of 1001/30000 seconds
+	if doc.Duration != "PT15.015S" {
+		t.Errorf("mediaPresentationDuration = %s, want PT15.015S", doc.Duration)
+	}
+	if len(doc.Periods) != 1 || len(doc.Periods[0].AdaptationSets) != 1 {
+		t.Fatalf("want one period of one adaptation set:\n%s", buf.String())
+	}
+
+	seen := make(map[string]bool)
+	for i, representation := range doc.Periods[0].AdaptationSets[0].Representations {
+		f, ok := fixtures[representation.ID]
+		if !ok || seen[representation.ID] {
+			t.Errorf("unexpected representation %q", representation.ID)
+			continue
+		}
+		seen[representation.ID] = true
+
+		list := representation.SegmentList
+		if list.Initialization.SourceURL != representation.ID+"/init.mp4?signed" {
+			t.Errorf("%s: initialization %q", representation.ID, list.Initialization.SourceURL)
+		}
+		if list.Timescale != mp4test.Timescale {
+			t.Errorf("%s: timescale %d", representation.ID, list.Timescale)
+		}
+		if representation.Width != 640 {
+			t.Errorf("%s: width %d", representation.ID, representation.Width)
+		}
+		if i > 0 && representation.Bandwidth > doc.Periods[0].AdaptationSets[0].Representations[i-1].Bandwidth {
+			t.Errorf("%s: not by descending bandwidth", representation.ID)
+		}
+
+		// segments start at keyframes, and span every sample
+		var next uint64
+		segments := 0
+		for _, s := range list.S {
+			if s.T != nil {
+				next = *s.T
+			}
+			for r := 0; r <= s.R; r++ {
+				if !f.keyframes[next] {
+					t.Errorf("%s: segment %d starts at %d, not a keyframe", representation.ID, segments, next)
+				}
+				next += s.D
+				segments++
+			}
+		}
+		if want := uint64(len(f.sizes)) * mp4test.SampleDelta; next != want {
+			t.Errorf("%s: segments end at %d, want %d", representation.ID, next, want)
+		}
+		if segments != len(f.movie.Segments) {
+			t.Errorf("%s: %d segments timed, have %d", representation.ID, segments, len(f.movie.Segments))
+		}
+		if len(list.SegmentURLs) != len(f.movie.Segments) {
+			t.Fatalf("%s: %d segments listed, have %d", representation.ID, len(list.SegmentURLs), len(f.movie.Segments))
+		}
+		for index, segment := range list.SegmentURLs {
+			if want := fmt.Sprintf("%s/%d.m4s?signed", representation.ID, index); segment.Media != want {
+				t.Errorf("%s: segment %d media %q, want %q", representation.ID, index, segment.Media, want)
+			}
+		}
+	}
+	if len(seen) != len(fixtures) {
+		t.Errorf("representations %v, want one per rendition", seen)
+	}
+}
+
+func TestWriteInit(t *testing.T) {
+	f := newFixture(t, mp4test.Spec{Untagged: true})
+
+	var buf bytes.Buffer
+	if err := f.movie.WriteInit(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	var paths []string
+	_, err := mp4.ReadBoxStructure(bytes.NewReader(buf.Bytes()), func(h *mp4.ReadHandle) (interface{}, error) {
+		paths = append(paths, fmt.Sprint(h.Path))
+		if h.BoxInfo.IsSupportedType() {
+			if _, _, err := h.ReadPayload(); err != nil {
+				return nil, err
+			}
+		}
+		return h.Expand()
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]bool{"[ftyp]": false, "[moov mvhd]": false, "[moov trak mdia minf stbl stsd avc1 avcC]": false, "[moov mvex trex]": false}
+	for _, path := range paths {
+		if _, ok := want[path]; ok {
+			want[path] = true
+		}
+		if path == "[moov trak mdia minf stbl stss]" || path == "[mdat]" {
+			t.Errorf("init segment has %s", path)
+		}
+	}
+	for path, found := range want {
+		if !found {
+			t.Errorf("init segment lacks %s", path)
+		}
+	}
+
+	stsz, err := mp4.ExtractBoxWithPayload(bytes.NewReader(buf.Bytes()), nil, mp4.BoxPath{
+		mp4.BoxTypeMoov(), mp4.BoxTypeTrak(), mp4.BoxTypeMdia(), mp4.BoxTypeMinf(), mp4.BoxTypeStbl(), mp4.BoxTypeStsz(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stsz) != 1 || stsz[0].Payload.(*mp4.Stsz).SampleCount != 0 {
+		t.Error("init segment sample tables not emptied")
+	}
+}
+
+func TestWriteSegment(t *testing.T) {
+	f := newFixture(t, mp4test.Spec{Untagged: true, Samples: 450})
+	if len(f.movie.Segments) < 2 {
+		t.Fatalf("%d segments, want several", len(f.movie.Segments))
+	}
+
+	first := 0
+	for index := range f.movie.Segments {
+		var buf bytes.Buffer
+		if err := f.movie.WriteSegment(&buf, bytes.NewReader(f.data), index); err != nil {
+			t.Fatal(err)
+		}
+		r := bytes.NewReader(buf.Bytes())
+
+		boxes, err := mp4.ExtractBoxesWithPayload(r, nil, []mp4.BoxPath{
+			{mp4.BoxTypeMoof(), mp4.BoxTypeMfhd()},
+			{mp4.BoxTypeMoof(), mp4.BoxTypeTraf(), mp4.BoxTypeTfdt()},
+			{mp4.BoxTypeMoof(), mp4.BoxTypeTraf(), mp4.BoxTypeTrun()},
+			{mp4.BoxTypeMdat()},
+		})
+		if err != nil {
+			t.Fatalf("segment %d: %v", index, err)
+		}
+
+		var trun *mp4.Trun
+		var dts uint64
+		var sequence uint32
+		var mdat *mp4.BoxInfoWithPayload
+		for i, box := range boxes {
+			switch payload := box.Payload.(type) {
+			case *mp4.Mfhd:
+				sequence = payload.SequenceNumber
+			case *mp4.Tfdt:
+				dts = payload.BaseMediaDecodeTimeV1
+			case *mp4.Trun:
+				trun = payload
+			case *mp4.Mdat:
+				mdat = boxes[i]
+			}
+		}
+		if trun == nil || mdat == nil {
+			t.Fatalf("segment %d lacks trun or mdat", index)
+		}
+
+		last := first + int(trun.SampleCount)
+		if sequence != uint32(index+1) {
+			t.Errorf("segment %d: sequence %d", index, sequence)
+		}
+		if want := uint64(first) * mp4test.SampleDelta; dts != want || !f.keyframes[dts] {
+			t.Errorf("segment %d: decode time %d, want keyframe %d", index, dts, want)
+		}
+		for i, entry := range trun.Entries {
+			if entry.SampleSize != f.sizes[first+i] {
+				t.Errorf("segment %d: sample %d size %d, want %d", index, first+i, entry.SampleSize, f.sizes[first+i])
+			}
+			if sync := entry.SampleFlags == syncSampleFlags; sync != f.keyframes[uint64(first+i)*mp4test.SampleDelta] {
+				t.Errorf("segment %d: sample %d sync %v", index, first+i, sync)
+			}
+		}
+
+		// default-base-is-moof, so the data offset is relative to the moof
+		payload := int64(mdat.Info.Offset + mdat.Info.HeaderSize)
+		if int64(trun.DataOffset) != payload {
+			t.Errorf("segment %d: data offset %d, want %d", index, trun.DataOffset, payload)
+		}
+		if !bytes.Equal(buf.Bytes()[payload:], f.samples(first, last)) {
+			t.Errorf("segment %d: mdat differs from samples %d-%d", index, first, last)
+		}
+		first = last
+	}
+	if first != len(f.sizes) {
+		t.Errorf("segments hold %d samples, want %d", first, len(f.sizes))
+	}
+
+	if err := f.movie.WriteSegment(&bytes.Buffer{}, bytes.NewReader(f.data), len(f.movie.Segments)); err == nil {
+		t.Error("out of range segment written")
+	}
+}
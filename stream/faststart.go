@@ -0,0 +1,181 @@
+package stream
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+)
+
+// Relocation lays out a file with its moov moved ahead of its mdat,
+// and chunk offsets patched to suit, without rewriting the file.
+type Relocation struct {
+	Size  int64
+	parts []relocatedPart
+}
+
+// relocatedPart is either a span of the original file, or buf.
+type relocatedPart struct {
+	// within the relocated file
+	offset int64
+	size   int64
+	// within the original file
+	source int64
+	buf    []byte
+}
+
+// Relocate plans a faststart layout of a file, else returns nil if
+// its moov already precedes its mdat.
+func Relocate(r io.ReaderAt, fileSize int64) (*Relocation, error) {
+	locations, err := topLevelBoxes(r, fileSize)
+	if err != nil {
+		return nil, err
+	}
+
+	var mdat, moov *boxLocation
+	for i := range locations {
+		switch location := &locations[i]; location.typ {
+		case "mdat":
+			if mdat == nil {
+				mdat = location
+			}
+		case "moov":
+			moov = location
+		}
+	}
+	if moov == nil {
+		return nil, fmt.Errorf("moov atom missing")
+	}
+	if mdat == nil || moov.offset < mdat.offset {
+		return nil, nil
+	}
+
+	buf := make([]byte, moov.size)
+	if _, err := r.ReadAt(buf, moov.offset); err != nil {
+		return nil, err
+	}
+
+	// media between the first mdat and moov moves later by moov's size
+	err = patchChunkOffsets(buf, func(offset uint64) uint64 {
+		if offset >= uint64(mdat.offset) && offset < uint64(moov.offset) {
+			return offset + uint64(moov.size)
+		}
+		return offset
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	relocation := &Relocation{Size: fileSize}
+	relocation.append(relocatedPart{size: mdat.offset, source: 0})
+	relocation.append(relocatedPart{size: moov.size, buf: buf})
+	relocation.append(relocatedPart{size: moov.offset - mdat.offset, source: mdat.offset})
+	relocation.append(relocatedPart{size: fileSize - moov.offset - moov.size, source: moov.offset + moov.size})
+	return relocation, nil
+}
+
+func (rl *Relocation) append(part relocatedPart) {
+	if part.size == 0 {
+		return
+	}
+	if n := len(rl.parts); n > 0 {
+		part.offset = rl.parts[n-1].offset + rl.parts[n-1].size
+	}
+	rl.parts = append(rl.parts, part)
+}
+
+// patchChunkOffsets maps the stco and co64 entries of every track.
+func patchChunkOffsets(moov []byte, fn func(offset uint64) uint64) error {
+	boxes, err := rawBoxes(moov)
+	if err != nil {
+		return err
+	}
+
+	for _, box := range boxes {
+		switch box.typ {
+		case "moov", "trak", "mdia", "minf", "stbl":
+			if err := patchChunkOffsets(box.payload, fn); err != nil {
+				return err
+			}
+		case "stco", "co64":
+			if len(box.payload) < 8 {
+				return fmt.Errorf("truncated %s atom", box.typ)
+			}
+			width := 4
+			if box.typ == "co64" {
+				width = 8
+			}
+			count := int(binary.BigEndian.Uint32(box.payload[4:]))
+			entries := box.payload[8:]
+			if count > len(entries)/width {
+				return fmt.Errorf("truncated %s atom", box.typ)
+			}
+
+			for i := 0; i < count; i++ {
+				entry := entries[i*width:]
+				if width == 4 {
+					offset := fn(uint64(binary.BigEndian.Uint32(entry)))
+					if offset > math.MaxUint32 {
+						// XXX would need promoting to co64, resizing moov
+						return fmt.Errorf("relocated chunk offset overflows stco")
+					}
+					binary.BigEndian.PutUint32(entry, uint32(offset))
+				} else {
+					binary.BigEndian.PutUint64(entry, fn(binary.BigEndian.Uint64(entry)))
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// View presents the original file, r, as relocated.
+func (rl *Relocation) View(r io.ReaderAt) *io.SectionReader {
+	return io.NewSectionReader(&relocatedReader{rl, r}, 0, rl.Size)
+}
+
+type relocatedReader struct {
+	*Relocation
+	r io.ReaderAt
+}
+
+func (rr *relocatedReader) ReadAt(p []byte, off int64) (int, error) {
+	var n int
+	for _, part := range rr.parts {
+		if len(p) == 0 {
+			break
+		}
+		if off >= part.offset+part.size {
+			continue
+		}
+
+		within := off - part.offset
+		chunk := p
+		if remaining := part.size - within; int64(len(chunk)) > remaining {
+			chunk = chunk[:remaining]
+		}
+
+		var m int
+		var err error
+		if part.buf != nil {
+			m, err = bytes.NewReader(part.buf).ReadAt(chunk, within)
+		} else {
+			m, err = rr.r.ReadAt(chunk, part.source+within)
+		}
+		n += m
+		if m < len(chunk) {
+			if err == nil || err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			return n, err
+		}
+
+		p = p[m:]
+		off += int64(m)
+	}
+	if len(p) > 0 {
+		return n, io.EOF
+	}
+	return n, nil
+}
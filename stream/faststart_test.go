@@ -0,0 +1,244 @@
+package stream
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/idiomatic/tvql/metadata/mp4/mp4test"
+)
+
+// chunkTable is an stco or co64 box of offsets.
+func chunkTable(typ string, offsets ...uint64) []byte {
+	width := 4
+	if typ == "co64" {
+		width = 8
+	}
+	payload := make([]byte, 8+width*len(offsets))
+	binary.BigEndian.PutUint32(payload[4:], uint32(len(offsets)))
+	for i, offset := range offsets {
+		if width == 4 {
+			binary.BigEndian.PutUint32(payload[8+i*4:], uint32(offset))
+		} else {
+			binary.BigEndian.PutUint64(payload[8+i*8:], offset)
+		}
+	}
+	return containerBox(typ, payload)
+}
+
+// chunkMoov nests a chunk offset table as in a track.
+func chunkMoov(table []byte) []byte {
+	return containerBox("moov", containerBox("trak", containerBox("mdia", containerBox("minf", containerBox("stbl", table)))))
+}
+
+// chunkOffsets lists the stco or co64 entries of a moov.
+func chunkOffsets(t *testing.T, moov []byte) []uint64 {
+	t.Helper()
+	var offsets []uint64
+	err := patchChunkOffsets(moov, func(offset uint64) uint64 {
+		offsets = append(offsets, offset)
+		return offset
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return offsets
+}
+
+// relocated reads a relocated view whole, checking its layout.
+func relocated(t *testing.T, data []byte, types ...string) []byte {
+	t.Helper()
+	rl, err := Relocate(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rl == nil || rl.Size != int64(len(data)) {
+		t.Fatalf("relocation %+v", rl)
+	}
+	view, err := ioutil.ReadAll(rl.View(bytes.NewReader(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	boxes, err := rawBoxes(view)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var layout []string
+	for _, box := range boxes {
+		layout = append(layout, box.typ)
+	}
+	if strings.Join(layout, " ") != strings.Join(types, " ") {
+		t.Fatalf("layout %v, want %v", layout, types)
+	}
+	return view
+}
+
+// moovOf returns the moov box of a file.
+func moovOf(t *testing.T, data []byte) []byte {
+	t.Helper()
+	boxes, err := rawBoxes(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	moov, ok := findRawBox(boxes, "moov")
+	if !ok {
+		t.Fatal("moov missing")
+	}
+	return moov.box
+}
+
+// checkChunks checks that chunks hold the same bytes once relocated.
+func checkChunks(t *testing.T, data, view []byte, size int) {
+	t.Helper()
+	before, after := chunkOffsets(t, moovOf(t, data)), chunkOffsets(t, moovOf(t, view))
+	if len(before) == 0 || len(before) != len(after) {
+		t.Fatalf("chunk offsets %v, then %v", before, after)
+	}
+	for i := range before {
+		if !bytes.Equal(view[after[i]:after[i]+uint64(size)], data[before[i]:before[i]+uint64(size)]) {
+			t.Errorf("chunk %d moved from %d to %d, yet reads differently", i, before[i], after[i])
+		}
+	}
+}
+
+func TestRelocate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "fixture.m4v")
+	if err := mp4test.WriteFile(path, mp4test.Spec{Title: "Heat", Day: "1995", Samples: 60, MoovLast: true}); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	view := relocated(t, data, "ftyp", "moov", "mdat")
+	// each chunk begins with a length-prefixed, numbered sample
+	checkChunks(t, data, view, 5)
+	if _, err := ReadMovie(bytes.NewReader(view), int64(len(view))); err != nil {
+		t.Errorf("relocated movie: %v", err)
+	}
+
+	// already faststart
+	if rl, err := Relocate(bytes.NewReader(view), int64(len(view))); rl != nil || err != nil {
+		t.Errorf("relocated faststart file: %+v, %v", rl, err)
+	}
+}
+
+func TestRelocateChunkTables(t *testing.T) {
+	ftyp := containerBox("ftyp", []byte("M4V isom"))
+	var media bytes.Buffer
+	for i := 0; i < 256; i++ {
+		media.WriteByte(byte(i))
+	}
+	mdat := containerBox("mdat", media.Bytes())
+	start := uint64(len(ftyp) + 8)
+
+	for _, typ := range []string{"stco", "co64"} {
+		// trailing boxes stay put
+		data := bytes.Join([][]byte{ftyp, mdat, chunkMoov(chunkTable(typ, start, start+100, start+255)), containerBox("free", []byte("trailer"))}, nil)
+		view := relocated(t, data, "ftyp", "moov", "mdat", "free")
+		checkChunks(t, data, view, 1)
+		if !bytes.Equal(view[len(view)-15:], data[len(data)-15:]) {
+			t.Errorf("%s: trailer moved", typ)
+		}
+	}
+}
+
+func TestRelocatedReadAt(t *testing.T) {
+	ftyp := containerBox("ftyp", []byte("M4V isom"))
+	mdat := containerBox("mdat", bytes.Repeat([]byte("0123456789"), 10))
+	moov := chunkMoov(chunkTable("stco", uint64(len(ftyp)+8)))
+	free := containerBox("free", []byte("trailer"))
+	data := bytes.Join([][]byte{ftyp, mdat, moov, free}, nil)
+
+	rl, err := Relocate(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	view := rl.View(bytes.NewReader(data))
+
+	// the media moves later by moov's size
+	patched := chunkMoov(chunkTable("stco", uint64(len(ftyp)+len(moov)+8)))
+	want := bytes.Join([][]byte{ftyp, patched, mdat, free}, nil)
+
+	// part boundaries
+	moovStart := int64(len(ftyp))
+	mdatStart := moovStart + int64(len(moov))
+	freeStart := mdatStart + int64(len(mdat))
+	size := int64(len(want))
+	for _, r := range []struct{ off, n int64 }{
+		{0, size},
+		{0, 1},
+		{moovStart - 3, 6},
+		{moovStart, int64(len(moov))},
+		{mdatStart - 1, 2},
+		// across three parts
+		{moovStart - 2, int64(len(moov)) + 4},
+		{freeStart - 5, 10},
+		{size - 1, 1},
+	} {
+		p := make([]byte, r.n)
+		n, err := view.ReadAt(p, r.off)
+		if err != nil || int64(n) != r.n || !bytes.Equal(p, want[r.off:r.off+r.n]) {
+			t.Errorf("ReadAt(%d, %d) = %d, %v: %q, want %q", r.off, r.n, n, err, p[:n], want[r.off:r.off+r.n])
+		}
+	}
+
+	// past the end
+	p := make([]byte, 8)
+	if n, err := view.ReadAt(p, size-3); n != 3 || err != io.EOF || !bytes.Equal(p[:n], want[size-3:]) {
+		t.Errorf("ReadAt past the end = %d, %v", n, err)
+	}
+	if n, err := view.ReadAt(p, size); n != 0 || err != io.EOF {
+		t.Errorf("ReadAt at the end = %d, %v", n, err)
+	}
+
+	// truncated underlying file
+	short := rl.View(bytes.NewReader(data[:len(data)-4]))
+	if n, err := short.ReadAt(make([]byte, 8), size-8); n != 4 || err != io.ErrUnexpectedEOF {
+		t.Errorf("ReadAt of truncated file = %d, %v", n, err)
+	}
+}
+
+func TestPatchChunkOffsets(t *testing.T) {
+	shift := func(offset uint64) uint64 { return offset + 1<<32 }
+
+	co64 := chunkMoov(chunkTable("co64", 8, math.MaxUint32))
+	if err := patchChunkOffsets(co64, shift); err != nil {
+		t.Errorf("co64: %v", err)
+	}
+	if got := chunkOffsets(t, co64); got[0] != 8+1<<32 || got[1] != math.MaxUint32+1<<32 {
+		t.Errorf("co64 offsets %v", got)
+	}
+
+	stco := chunkMoov(chunkTable("stco", 8, 16))
+	if err := patchChunkOffsets(stco, shift); err == nil || !strings.Contains(err.Error(), "overflows") {
+		t.Errorf("stco overflow: %v", err)
+	}
+	if err := patchChunkOffsets(stco, func(offset uint64) uint64 { return offset + math.MaxUint32 - 16 }); err != nil {
+		t.Errorf("stco at its limit: %v", err)
+	}
+
+	truncated := chunkTable("stco", 8, 16)
+	binary.BigEndian.PutUint32(truncated[12:], 3)
+	if err := patchChunkOffsets(chunkMoov(truncated), shift); err == nil {
+		t.Error("truncated stco patched")
+	}
+	if err := patchChunkOffsets(chunkMoov(containerBox("stco", []byte{0, 0, 0})), shift); err == nil {
+		t.Error("headless stco patched")
+	}
+}
+
+func TestRelocateMoovMissing(t *testing.T) {
+	data := bytes.Join([][]byte{containerBox("ftyp", []byte("M4V isom")), containerBox("mdat", []byte("media"))}, nil)
+	if _, err := Relocate(bytes.NewReader(data), int64(len(data))); err == nil {
+		t.Error("relocated without moov")
+	}
+}
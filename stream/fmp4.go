@@ -0,0 +1,285 @@
+package stream
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+
+	mp4 "github.com/abema/go-mp4"
+)
+
+const (
+	// sample_depends_on=2, i.e., a keyframe
+	syncSampleFlags = 0x02000000
+	// sample_depends_on=1, sample_is_non_sync_sample=1
+	nonSyncSampleFlags = 0x01010000
+)
+
+// emptyTables stand in for the sample tables of fragmented tracks.
+var emptyTables = [][]byte{
+	containerBox("stts", make([]byte, 8)),
+	containerBox("stsc", make([]byte, 8)),
+	containerBox("stsz", make([]byte, 12)),
+	containerBox("stco", make([]byte, 8)),
+}
+
+// ftyp brands fragments as CMAF.
+func ftyp() ([]byte, error) {
+	return marshalBox(&mp4.Ftyp{
+		MajorBrand: [4]byte{'i', 's', 'o', '6'},
+		CompatibleBrands: []mp4.CompatibleBrandElem{
+			{CompatibleBrand: [4]byte{'i', 's', 'o', '6'}},
+			{CompatibleBrand: [4]byte{'c', 'm', 'f', 'c'}},
+			{CompatibleBrand: [4]byte{'m', 'p', '4', '1'}},
+		},
+	})
+}
+
+// WriteInit writes an initialization segment for the given tracks
+// (else all): the moov, sans sample tables.
+func (m *Movie) WriteInit(w io.Writer, tracks ...int) error {
+	tracks = m.trackIndices(tracks)
+
+	header, err := ftyp()
+	if err != nil {
+		return err
+	}
+
+	moov := [][]byte{m.mvhd}
+	var trexes [][]byte
+	for _, t := range tracks {
+		track := m.Tracks[t]
+
+		trak, err := track.fragmentedTrak()
+		if err != nil {
+			return err
+		}
+		moov = append(moov, trak)
+
+		trex, err := marshalBox(&mp4.Trex{
+			TrackID:                       track.ID,
+			DefaultSampleDescriptionIndex: 1,
+		})
+		if err != nil {
+			return err
+		}
+		trexes = append(trexes, trex)
+	}
+	moov = append(moov, containerBox("mvex", trexes...))
+
+	if _, err := w.Write(header); err != nil {
+		return err
+	}
+	_, err = w.Write(containerBox("moov", moov...))
+	return err
+}
+
+// fragmentedTrak copies the trak, emptying its sample tables and
+// dropping the likes of udta.
+func (t *Track) fragmentedTrak() ([]byte, error) {
+	trak, err := rawBoxes(t.trak)
+	if err != nil {
+		return nil, err
+	}
+
+	var trakChildren [][]byte
+	children, err := rawBoxes(trak[0].payload)
+	if err != nil {
+		return nil, err
+	}
+	for _, child := range children {
+		switch child.typ {
+		case "tkhd", "edts":
+			trakChildren = append(trakChildren, child.box)
+		case "mdia":
+			mdia, err := fragmentedContainer(child, func(box rawBox) ([]byte, error) {
+				switch box.typ {
+				case "mdhd", "hdlr":
+					return box.box, nil
+				case "minf":
+					return fragmentedContainer(box, func(box rawBox) ([]byte, error) {
+						if box.typ != "stbl" {
+							return box.box, nil
+						}
+						stsd, ok := findStsd(box)
+						if !ok {
+							return nil, fmt.Errorf("stsd atom missing")
+						}
+						return containerBox("stbl", append([][]byte{stsd}, emptyTables...)...), nil
+					})
+				}
+				return nil, nil
+			})
+			if err != nil {
+				return nil, err
+			}
+			trakChildren = append(trakChildren, mdia)
+		}
+	}
+
+	return containerBox("trak", trakChildren...), nil
+}
+
+func findStsd(stbl rawBox) ([]byte, bool) {
+	children, err := rawBoxes(stbl.payload)
+	if err != nil {
+		return nil, false
+	}
+	stsd, ok := findRawBox(children, "stsd")
+	return stsd.box, ok
+}
+
+// fragmentedContainer rebuilds a container box from its mapped
+// children, omitting those mapped to nil.
+func fragmentedContainer(container rawBox, fn func(box rawBox) ([]byte, error)) ([]byte, error) {
+	children, err := rawBoxes(container.payload)
+	if err != nil {
+		return nil, err
+	}
+
+	var mapped [][]byte
+	for _, child := range children {
+		box, err := fn(child)
+		if err != nil {
+			return nil, err
+		}
+		if box != nil {
+			mapped = append(mapped, box)
+		}
+	}
+	return containerBox(container.typ, mapped...), nil
+}
+
+// WriteSegment writes a media segment (moof and mdat) for the given
+// tracks (else all), reading samples from the original file.
+func (m *Movie) WriteSegment(w io.Writer, r io.ReaderAt, index int, tracks ...int) error {
+	if index < 0 || index >= len(m.Segments) {
+		return fmt.Errorf("segment %d out of range", index)
+	}
+	segment := m.Segments[index]
+	tracks = m.trackIndices(tracks)
+
+	// first pass sizes the moof, second fills in data offsets
+	var moof []byte
+	for pass := 0; pass < 2; pass++ {
+		dataOffset := len(moof) + 8
+
+		mfhd, err := marshalBox(&mp4.Mfhd{SequenceNumber: uint32(index + 1)})
+		if err != nil {
+			return err
+		}
+		children := [][]byte{mfhd}
+
+		for _, t := range tracks {
+			track := m.Tracks[t]
+			samples := track.Samples[segment.ranges[t][0]:segment.ranges[t][1]]
+			if len(samples) == 0 {
+				continue
+			}
+
+			traf, err := track.traf(samples, dataOffset)
+			if err != nil {
+				return err
+			}
+			children = append(children, traf)
+
+			for _, sample := range samples {
+				dataOffset += int(sample.Size)
+			}
+		}
+
+		moof = containerBox("moof", children...)
+	}
+
+	var size int64
+	for _, t := range tracks {
+		for _, sample := range m.Tracks[t].Samples[segment.ranges[t][0]:segment.ranges[t][1]] {
+			size += int64(sample.Size)
+		}
+	}
+
+	if _, err := w.Write(moof); err != nil {
+		return err
+	}
+	if size+8 > math.MaxUint32 {
+		return fmt.Errorf("segment %d too large", index)
+	}
+	mdat := containerBox("mdat")
+	binary.BigEndian.PutUint32(mdat, uint32(size+8))
+	if _, err := w.Write(mdat); err != nil {
+		return err
+	}
+
+	for _, t := range tracks {
+		samples := m.Tracks[t].Samples[segment.ranges[t][0]:segment.ranges[t][1]]
+
+		// coalesce contiguous samples into fewer reads
+		for i := 0; i < len(samples); {
+			start := samples[i].Offset
+			end := start + int64(samples[i].Size)
+			for i++; i < len(samples) && samples[i].Offset == end; i++ {
+				end += int64(samples[i].Size)
+			}
+			if _, err := io.Copy(w, io.NewSectionReader(r, start, end-start)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// traf describes samples whose data begins dataOffset bytes from the
+// start of the moof.
+func (t *Track) traf(samples []Sample, dataOffset int) ([]byte, error) {
+	tfhd := &mp4.Tfhd{TrackID: t.ID}
+	tfhd.SetFlags(0x020000) // default-base-is-moof
+
+	tfdt := &mp4.Tfdt{BaseMediaDecodeTimeV1: samples[0].DTS}
+	tfdt.SetVersion(1)
+
+	trun := &mp4.Trun{
+		SampleCount: uint32(len(samples)),
+		DataOffset:  int32(dataOffset),
+		Entries:     make([]mp4.TrunEntry, len(samples)),
+	}
+	// signed composition offsets
+	trun.SetVersion(1)
+	// data offset, and per-sample duration, size, flags, and composition offset
+	trun.SetFlags(0x000001 | 0x000100 | 0x000200 | 0x000400 | 0x000800)
+	for i, sample := range samples {
+		flags := uint32(nonSyncSampleFlags)
+		if sample.Sync {
+			flags = syncSampleFlags
+		}
+		trun.Entries[i] = mp4.TrunEntry{
+			SampleDuration:                sample.Duration,
+			SampleSize:                    sample.Size,
+			SampleFlags:                   flags,
+			SampleCompositionTimeOffsetV1: sample.CTO,
+		}
+	}
+
+	var children [][]byte
+	for _, box := range []mp4.IBox{tfhd, tfdt, trun} {
+		buf, err := marshalBox(box)
+		if err != nil {
+			return nil, err
+		}
+		children = append(children, buf)
+	}
+	return containerBox("traf", children...), nil
+}
+
+// trackIndices defaults to all tracks.
+func (m *Movie) trackIndices(tracks []int) []int {
+	if len(tracks) != 0 {
+		return tracks
+	}
+	tracks = make([]int, len(m.Tracks))
+	for i := range tracks {
+		tracks[i] = i
+	}
+	return tracks
+}
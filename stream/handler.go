@@ -0,0 +1,325 @@
+package stream
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru"
+)
+
+// movieCacheSize is how many parsed movies to retain.  Sample tables
+// of a feature film are tens of megabytes.
+const movieCacheSize = 8
+
+// Catalog locates the files of renditions.
+type Catalog interface {
+	// RenditionIDs lists the renditions of a video.
+	RenditionIDs(videoID string) ([]string, bool)
+	// RenditionPath locates the file of a rendition.
+	RenditionPath(renditionID string) (string, bool)
+	// RenditionVideoID names the video of a rendition.
+	RenditionVideoID(renditionID string) (string, bool)
+}
+
+// Handler remuxes renditions into fragments for adaptive streaming.
+// Paths, relative to where it is mounted:
+//
+//	<video id>.m3u8            HLS master playlist
+//	<video id>.mpd             DASH manifest
+//	<rendition id>/index.m3u8  HLS media playlist
+//	<rendition id>/init.mp4    initialization segment
+//	<rendition id>/<n>.m4s     media segment
+type Handler struct {
+	// Permits, if set, reports whether a request may stream a video.
+	Permits func(r *http.Request, videoID string) bool
+	// Sign, if set, returns the query string authorizing a path,
+	// relative to the handler, referenced by a request's response.
+	Sign func(r *http.Request, path string) string
+
+	catalog     Catalog
+	movies      *lru.Cache
+	relocations *lru.Cache
+}
+
+type cachedMovie struct {
+	movie   *Movie
+	modTime time.Time
+	size    int64
+}
+
+type cachedRelocation struct {
+	// nil if already faststart
+	relocation *Relocation
+	modTime    time.Time
+	size       int64
+}
+
+func NewHandler(catalog Catalog) *Handler {
+	movies, err := lru.New(movieCacheSize)
+	if err != nil {
+		panic(err)
+	}
+	relocations, err := lru.New(movieCacheSize)
+	if err != nil {
+		panic(err)
+	}
+	return &Handler{catalog: catalog, movies: movies, relocations: relocations}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	dir, file := path.Split(r.URL.Path)
+	dir = strings.TrimSuffix(dir, "/")
+
+	videoID := dir
+	if dir == "" {
+		videoID = strings.TrimSuffix(file, path.Ext(file))
+	} else if id, ok := h.catalog.RenditionVideoID(dir); ok {
+		videoID = id
+	}
+	if h.Permits != nil && !h.Permits(r, videoID) {
+		http.NotFound(w, r)
+		return
+	}
+
+	switch {
+	case dir == "" && path.Ext(file) == ".m3u8":
+		h.serveMasterPlaylist(w, r, videoID)
+	case dir == "" && path.Ext(file) == ".mpd":
+		h.serveMPD(w, r, videoID)
+	case dir != "" && file == "index.m3u8":
+		h.serveMediaPlaylist(w, r, dir)
+	case dir != "" && file == "init.mp4":
+		h.serveInit(w, dir)
+	case dir != "" && path.Ext(file) == ".m4s":
+		index, err := strconv.Atoi(strings.TrimSuffix(file, ".m4s"))
+		if err != nil {
+			http.NotFound(w, r)
+			return
+		}
+		h.serveSegment(w, dir, index)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// uri names a path, relative to the handler, as referenced from the
+// response to a request, signing it if need be.
+func (h *Handler) uri(r *http.Request, relative, path string) string {
+	if h.Sign == nil {
+		return relative
+	}
+	return relative + "?" + h.Sign(r, path)
+}
+
+// movie parses a rendition, else recalls it if unchanged.
+func (h *Handler) movie(renditionID string) (*Movie, *os.File, error) {
+	path, ok := h.catalog.RenditionPath(renditionID)
+	if !ok {
+		return nil, nil, os.ErrNotExist
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, nil, err
+	}
+
+	if cached, ok := h.movies.Get(path); ok {
+		cached := cached.(*cachedMovie)
+		if cached.modTime.Equal(info.ModTime()) && cached.size == info.Size() {
+			return cached.movie, file, nil
+		}
+	}
+
+	movie, err := ReadMovie(file, info.Size())
+	if err != nil {
+		file.Close()
+		return nil, nil, fmt.Errorf("%s: %w", path, err)
+	}
+	h.movies.Add(path, &cachedMovie{movie, info.ModTime(), info.Size()})
+
+	return movie, file, nil
+}
+
+// renditions parses the streamable renditions of a video.
+func (h *Handler) renditions(w http.ResponseWriter, videoID string) (map[string]*Movie, []string, bool) {
+	renditionIDs, ok := h.catalog.RenditionIDs(videoID)
+	if !ok {
+		http.Error(w, "video not found", http.StatusNotFound)
+		return nil, nil, false
+	}
+
+	movies := make(map[string]*Movie)
+	var streamable []string
+	for _, renditionID := range renditionIDs {
+		movie, file, err := h.movie(renditionID)
+		if err != nil {
+			// XXX skip unstreamable renditions
+			continue
+		}
+		file.Close()
+
+		movies[renditionID] = movie
+		streamable = append(streamable, renditionID)
+	}
+	if len(streamable) == 0 {
+		http.Error(w, "no streamable renditions", http.StatusNotFound)
+		return nil, nil, false
+	}
+	return movies, streamable, true
+}
+
+func (h *Handler) serveMasterPlaylist(w http.ResponseWriter, r *http.Request, videoID string) {
+	movies, renditionIDs, ok := h.renditions(w, videoID)
+	if !ok {
+		return
+	}
+
+	var variants []Variant
+	for _, renditionID := range renditionIDs {
+		variants = append(variants, Variant{
+			URI:   h.uri(r, renditionID+"/index.m3u8", renditionID+"/index.m3u8"),
+			Movie: movies[renditionID],
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/vnd.apple.mpegurl")
+	WriteMasterPlaylist(w, variants)
+}
+
+func (h *Handler) serveMPD(w http.ResponseWriter, r *http.Request, videoID string) {
+	movies, renditionIDs, ok := h.renditions(w, videoID)
+	if !ok {
+		return
+	}
+
+	var representations []Representation
+	for _, renditionID := range renditionIDs {
+		renditionID := renditionID
+		representations = append(representations, Representation{
+			ID:      renditionID,
+			Movie:   movies[renditionID],
+			InitURI: h.uri(r, renditionID+"/init.mp4", renditionID+"/init.mp4"),
+			SegmentURI: func(index int) string {
+				segment := fmt.Sprintf("%s/%d.m4s", renditionID, index)
+				return h.uri(r, segment, segment)
+			},
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/dash+xml")
+	WriteMPD(w, representations)
+}
+
+func (h *Handler) serveMediaPlaylist(w http.ResponseWriter, r *http.Request, renditionID string) {
+	movie, file, err := h.movie(renditionID)
+	if err != nil {
+		httpError(w, err)
+		return
+	}
+	file.Close()
+
+	w.Header().Set("Content-Type", "application/vnd.apple.mpegurl")
+	movie.WriteMediaPlaylist(w, h.uri(r, "init.mp4", renditionID+"/init.mp4"), func(index int) string {
+		segment := fmt.Sprintf("%d.m4s", index)
+		return h.uri(r, segment, renditionID+"/"+segment)
+	})
+}
+
+func (h *Handler) serveInit(w http.ResponseWriter, renditionID string) {
+	movie, file, err := h.movie(renditionID)
+	if err != nil {
+		httpError(w, err)
+		return
+	}
+	file.Close()
+
+	w.Header().Set("Content-Type", "video/mp4")
+	movie.WriteInit(w)
+}
+
+func (h *Handler) serveSegment(w http.ResponseWriter, renditionID string, index int) {
+	movie, file, err := h.movie(renditionID)
+	if err != nil {
+		httpError(w, err)
+		return
+	}
+	defer file.Close()
+
+	if index < 0 || index >= len(movie.Segments) {
+		http.Error(w, "segment not found", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "video/iso.segment")
+	movie.WriteSegment(w, file, index)
+}
+
+// FastStart serves renditions, named by path, with their moov ahead
+// of their mdat, deferring those already so (and other paths) to next.
+func (h *Handler) FastStart(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path, ok := h.catalog.RenditionPath(r.URL.Path)
+		if !ok {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		file, err := os.Open(path)
+		if err != nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+		defer file.Close()
+		info, err := file.Stat()
+		if err != nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		relocation := h.relocation(path, file, info)
+		if relocation == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		http.ServeContent(w, r, filepath.Base(path), info.ModTime(), relocation.View(file))
+	})
+}
+
+// relocation plans a faststart layout, else recalls it if unchanged.
+// Nil if already faststart, or not relocatable.
+func (h *Handler) relocation(path string, file *os.File, info os.FileInfo) *Relocation {
+	if cached, ok := h.relocations.Get(path); ok {
+		cached := cached.(*cachedRelocation)
+		if cached.modTime.Equal(info.ModTime()) && cached.size == info.Size() {
+			return cached.relocation
+		}
+	}
+
+	relocation, err := Relocate(file, info.Size())
+	if err != nil {
+		// XXX serve as is
+		relocation = nil
+	}
+	h.relocations.Add(path, &cachedRelocation{relocation, info.ModTime(), info.Size()})
+	return relocation
+}
+
+func httpError(w http.ResponseWriter, err error) {
+	if os.IsNotExist(err) {
+		http.Error(w, "rendition not found", http.StatusNotFound)
+		return
+	}
+	http.Error(w, "rendition unreadable", http.StatusInternalServerError)
+}
@@ -0,0 +1,59 @@
+package stream
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+)
+
+// hlsVersion 7 permits fMP4 segments via EXT-X-MAP.
+const hlsVersion = 7
+
+// Variant is one rendition listed in a master playlist.
+type Variant struct {
+	URI   string
+	Movie *Movie
+}
+
+// WriteMasterPlaylist lists each variant, by descending bandwidth.
+func WriteMasterPlaylist(w io.Writer, variants []Variant) error {
+	sort.SliceStable(variants, func(i, j int) bool {
+		a, _ := variants[i].Movie.Bandwidth()
+		b, _ := variants[j].Movie.Bandwidth()
+		return a > b
+	})
+
+	b := bufio.NewWriter(w)
+	fmt.Fprintf(b, "#EXTM3U\n#EXT-X-VERSION:%d\n#EXT-X-INDEPENDENT-SEGMENTS\n", hlsVersion)
+	for _, variant := range variants {
+		peak, average := variant.Movie.Bandwidth()
+		fmt.Fprintf(b, "#EXT-X-STREAM-INF:BANDWIDTH=%d,AVERAGE-BANDWIDTH=%d,CODECS=%q", peak, average, variant.Movie.Codecs())
+		if width, height := variant.Movie.Resolution(); width != 0 && height != 0 {
+			fmt.Fprintf(b, ",RESOLUTION=%dx%d", width, height)
+		}
+		fmt.Fprintf(b, "\n%s\n", variant.URI)
+	}
+	return b.Flush()
+}
+
+// WriteMediaPlaylist lists the segments of a movie, as named by
+// initURI and segmentURI.
+func (m *Movie) WriteMediaPlaylist(w io.Writer, initURI string, segmentURI func(index int) string) error {
+	var target float64
+	for _, segment := range m.Segments {
+		target = math.Max(target, segment.Duration)
+	}
+
+	b := bufio.NewWriter(w)
+	fmt.Fprintf(b, "#EXTM3U\n#EXT-X-VERSION:%d\n", hlsVersion)
+	fmt.Fprintf(b, "#EXT-X-TARGETDURATION:%d\n", int(math.Ceil(target)))
+	fmt.Fprintf(b, "#EXT-X-MEDIA-SEQUENCE:0\n#EXT-X-PLAYLIST-TYPE:VOD\n#EXT-X-INDEPENDENT-SEGMENTS\n")
+	fmt.Fprintf(b, "#EXT-X-MAP:URI=%q\n", initURI)
+	for i, segment := range m.Segments {
+		fmt.Fprintf(b, "#EXTINF:%.6f,\n%s\n", segment.Duration, segmentURI(i))
+	}
+	fmt.Fprintf(b, "#EXT-X-ENDLIST\n")
+	return b.Flush()
+}
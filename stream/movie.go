@@ -0,0 +1,654 @@
+package stream
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"strings"
+
+	mp4 "github.com/abema/go-mp4"
+)
+
+// segmentDuration is the target duration of media segments, in
+// seconds.  Segments begin at video keyframes, so may run longer.
+const segmentDuration = 6
+
+// Movie is the sample layout of a progressive (i.e., unfragmented)
+// mp4 file, sufficient to remux it into fragments.
+type Movie struct {
+	// Does moov precede mdat?
+	FastStart bool
+	// in seconds
+	Duration float64
+	// first video track, then first audio track, if any
+	Tracks   []*Track
+	Segments []*Segment
+
+	mvhd []byte
+}
+
+// Track is an audio or video track.
+type Track struct {
+	ID uint32
+	// "vide" or "soun"
+	Handler   string
+	Timescale uint32
+	// per RFC 6381, e.g., "avc1.64001f"
+	Codec   string
+	Width   int
+	Height  int
+	Samples []Sample
+
+	enabled bool
+	trak    []byte
+}
+
+// Sample locates a frame of audio or video.
+type Sample struct {
+	Offset   int64
+	DTS      uint64
+	Size     uint32
+	Duration uint32
+	CTO      int32
+	Sync     bool
+}
+
+// Segment spans a few seconds of every track.
+type Segment struct {
+	// in seconds
+	Start    float64
+	Duration float64
+	Size     int64
+	// per track, [first, last) sample indices
+	ranges [][2]int
+}
+
+// boxLocation is a top-level box within a file.
+type boxLocation struct {
+	typ    string
+	offset int64
+	size   int64
+}
+
+// topLevelBoxes locates the boxes of a file, without reading them.
+func topLevelBoxes(r io.ReaderAt, fileSize int64) ([]boxLocation, error) {
+	var boxes []boxLocation
+	var header [16]byte
+	for offset := int64(0); offset+8 <= fileSize; {
+		if _, err := r.ReadAt(header[:8], offset); err != nil {
+			return nil, err
+		}
+
+		size := int64(binary.BigEndian.Uint32(header[:]))
+		typ := string(header[4:8])
+		switch size {
+		case 0:
+			size = fileSize - offset
+		case 1:
+			if _, err := r.ReadAt(header[8:16], offset+8); err != nil {
+				return nil, err
+			}
+			size = int64(binary.BigEndian.Uint64(header[8:]))
+		}
+		if size < 8 || offset+size > fileSize {
+			return nil, fmt.Errorf("malformed %s box at %d", typ, offset)
+		}
+
+		boxes = append(boxes, boxLocation{typ, offset, size})
+		offset += size
+	}
+	return boxes, nil
+}
+
+// ReadMovie parses the moov of an mp4 file.
+func ReadMovie(r io.ReaderAt, fileSize int64) (*Movie, error) {
+	locations, err := topLevelBoxes(r, fileSize)
+	if err != nil {
+		return nil, err
+	}
+
+	movie := &Movie{}
+	var moov []byte
+	mdatSeen := false
+	for _, location := range locations {
+		switch location.typ {
+		case "mdat":
+			mdatSeen = true
+		case "moov":
+			movie.FastStart = !mdatSeen
+			moov = make([]byte, location.size)
+			if _, err := r.ReadAt(moov, location.offset); err != nil {
+				return nil, err
+			}
+		case "moof":
+			return nil, fmt.Errorf("already fragmented")
+		}
+	}
+	if moov == nil {
+		return nil, fmt.Errorf("moov atom missing")
+	}
+
+	boxes, err := rawBoxes(moov)
+	if err != nil {
+		return nil, err
+	}
+	children, err := rawBoxes(boxes[0].payload)
+	if err != nil {
+		return nil, err
+	}
+
+	mvhd, ok := findRawBox(children, "mvhd")
+	if !ok {
+		return nil, fmt.Errorf("mvhd atom missing")
+	}
+	movie.mvhd = mvhd.box
+
+	var video, audio *Track
+	for _, child := range children {
+		if child.typ != "trak" {
+			continue
+		}
+
+		track, err := readTrack(child)
+		if err != nil {
+			return nil, err
+		}
+		if track == nil || len(track.Samples) == 0 {
+			continue
+		}
+
+		// prefer enabled tracks
+		switch track.Handler {
+		case "vide":
+			if video == nil || (track.enabled && !video.enabled) {
+				video = track
+			}
+		case "soun":
+			if audio == nil || (track.enabled && !audio.enabled) {
+				audio = track
+			}
+		}
+	}
+	for _, track := range []*Track{video, audio} {
+		if track != nil {
+			movie.Tracks = append(movie.Tracks, track)
+		}
+	}
+	if len(movie.Tracks) == 0 {
+		return nil, fmt.Errorf("no audio or video tracks")
+	}
+
+	movie.segment()
+
+	return movie, nil
+}
+
+// readTrack parses a trak, returning nil for other than audio and
+// video tracks.
+func readTrack(trak rawBox) (*Track, error) {
+	track := &Track{trak: trak.box}
+
+	children, err := rawBoxes(trak.payload)
+	if err != nil {
+		return nil, err
+	}
+
+	tkhdBox, ok := findRawBox(children, "tkhd")
+	if !ok {
+		return nil, fmt.Errorf("tkhd atom missing")
+	}
+	var tkhd mp4.Tkhd
+	if err := unmarshalBox(tkhdBox, &tkhd); err != nil {
+		return nil, err
+	}
+	track.ID = tkhd.TrackID
+	track.enabled = tkhd.GetFlags()&1 != 0
+
+	mdia, ok := findRawBox(children, "mdia")
+	if !ok {
+		return nil, fmt.Errorf("mdia atom missing")
+	}
+	mdiaChildren, err := rawBoxes(mdia.payload)
+	if err != nil {
+		return nil, err
+	}
+
+	hdlrBox, ok := findRawBox(mdiaChildren, "hdlr")
+	if !ok {
+		return nil, fmt.Errorf("hdlr atom missing")
+	}
+	var hdlr mp4.Hdlr
+	if err := unmarshalBox(hdlrBox, &hdlr); err != nil {
+		return nil, err
+	}
+	track.Handler = string(hdlr.HandlerType[:])
+	if track.Handler != "vide" && track.Handler != "soun" {
+		return nil, nil
+	}
+
+	mdhdBox, ok := findRawBox(mdiaChildren, "mdhd")
+	if !ok {
+		return nil, fmt.Errorf("mdhd atom missing")
+	}
+	var mdhd mp4.Mdhd
+	if err := unmarshalBox(mdhdBox, &mdhd); err != nil {
+		return nil, err
+	}
+	track.Timescale = mdhd.Timescale
+
+	minf, ok := findRawBox(mdiaChildren, "minf")
+	if !ok {
+		return nil, fmt.Errorf("minf atom missing")
+	}
+	minfChildren, err := rawBoxes(minf.payload)
+	if err != nil {
+		return nil, err
+	}
+	stbl, ok := findRawBox(minfChildren, "stbl")
+	if !ok {
+		return nil, fmt.Errorf("stbl atom missing")
+	}
+	stblChildren, err := rawBoxes(stbl.payload)
+	if err != nil {
+		return nil, err
+	}
+
+	if stsd, ok := findRawBox(stblChildren, "stsd"); ok {
+		track.readSampleDescription(stsd)
+	}
+
+	if err := track.readSampleTables(stblChildren); err != nil {
+		return nil, err
+	}
+
+	return track, nil
+}
+
+func unmarshalBox(raw rawBox, box mp4.IBox) error {
+	_, err := mp4.Unmarshal(bytes.NewReader(raw.payload), uint64(len(raw.payload)), box, mp4.Context{})
+	if err != nil {
+		return fmt.Errorf("%s: %w", raw.typ, err)
+	}
+	return nil
+}
+
+// readSampleTables flattens the sample tables into one Sample each.
+func (t *Track) readSampleTables(stbl []rawBox) error {
+	var (
+		stts mp4.Stts
+		stsc mp4.Stsc
+		stsz mp4.Stsz
+	)
+	for _, table := range []struct {
+		typ string
+		box mp4.IBox
+	}{{"stts", &stts}, {"stsc", &stsc}, {"stsz", &stsz}} {
+		raw, ok := findRawBox(stbl, table.typ)
+		if !ok {
+			return fmt.Errorf("%s atom missing", table.typ)
+		}
+		if err := unmarshalBox(raw, table.box); err != nil {
+			return err
+		}
+	}
+
+	var chunkOffsets []uint64
+	if raw, ok := findRawBox(stbl, "stco"); ok {
+		var stco mp4.Stco
+		if err := unmarshalBox(raw, &stco); err != nil {
+			return err
+		}
+		for _, offset := range stco.ChunkOffset {
+			chunkOffsets = append(chunkOffsets, uint64(offset))
+		}
+	} else if raw, ok := findRawBox(stbl, "co64"); ok {
+		var co64 mp4.Co64
+		if err := unmarshalBox(raw, &co64); err != nil {
+			return err
+		}
+		chunkOffsets = co64.ChunkOffset
+	} else {
+		return fmt.Errorf("stco atom missing")
+	}
+
+	count := int(stsz.SampleCount)
+	samples := make([]Sample, count)
+
+	for i := range samples {
+		samples[i].Size = stsz.SampleSize
+		if stsz.SampleSize == 0 && i < len(stsz.EntrySize) {
+			samples[i].Size = stsz.EntrySize[i]
+		}
+	}
+
+	i := 0
+	var dts uint64
+	for _, entry := range stts.Entries {
+		for n := uint32(0); n < entry.SampleCount && i < count; n++ {
+			samples[i].DTS = dts
+			samples[i].Duration = entry.SampleDelta
+			dts += uint64(entry.SampleDelta)
+			i++
+		}
+	}
+
+	if raw, ok := findRawBox(stbl, "ctts"); ok {
+		var ctts mp4.Ctts
+		if err := unmarshalBox(raw, &ctts); err != nil {
+			return err
+		}
+		i := 0
+		for _, entry := range ctts.Entries {
+			offset := int32(entry.SampleOffsetV0)
+			if ctts.GetVersion() != 0 {
+				offset = entry.SampleOffsetV1
+			}
+			for n := uint32(0); n < entry.SampleCount && i < count; n++ {
+				samples[i].CTO = offset
+				i++
+			}
+		}
+	}
+
+	if raw, ok := findRawBox(stbl, "stss"); ok {
+		var stss mp4.Stss
+		if err := unmarshalBox(raw, &stss); err != nil {
+			return err
+		}
+		for _, number := range stss.SampleNumber {
+			if number >= 1 && int(number) <= count {
+				samples[number-1].Sync = true
+			}
+		}
+	} else {
+		// all samples are sync samples
+		for i := range samples {
+			samples[i].Sync = true
+		}
+	}
+
+	i = 0
+	for e, entry := range stsc.Entries {
+		last := uint32(len(chunkOffsets))
+		if e+1 < len(stsc.Entries) && stsc.Entries[e+1].FirstChunk-1 < last {
+			last = stsc.Entries[e+1].FirstChunk - 1
+		}
+		for chunk := entry.FirstChunk - 1; chunk < last; chunk++ {
+			offset := int64(chunkOffsets[chunk])
+			for n := uint32(0); n < entry.SamplesPerChunk && i < count; n++ {
+				samples[i].Offset = offset
+				offset += int64(samples[i].Size)
+				i++
+			}
+		}
+	}
+	if i != count {
+		return fmt.Errorf("sample tables disagree: %d of %d samples located", i, count)
+	}
+
+	t.Samples = samples
+	return nil
+}
+
+// readSampleDescription derives the codec and dimensions from the
+// first sample entry.
+func (t *Track) readSampleDescription(stsd rawBox) {
+	// version, flags, and entry count
+	if len(stsd.payload) < 8 {
+		return
+	}
+	entries, err := rawBoxes(stsd.payload[8:])
+	if err != nil || len(entries) == 0 {
+		return
+	}
+	entry := entries[0]
+	t.Codec = strings.TrimSpace(entry.typ)
+
+	var children []rawBox
+	switch t.Handler {
+	case "vide":
+		// VisualSampleEntry
+		const fixed = 78
+		if len(entry.payload) < fixed {
+			return
+		}
+		t.Width = int(binary.BigEndian.Uint16(entry.payload[24:]))
+		t.Height = int(binary.BigEndian.Uint16(entry.payload[26:]))
+		children, _ = rawBoxes(entry.payload[fixed:])
+	case "soun":
+		// AudioSampleEntry, else QuickTime sound description v1 or v2
+		fixed := 28
+		if len(entry.payload) < fixed {
+			return
+		}
+		switch binary.BigEndian.Uint16(entry.payload[8:]) {
+		case 1:
+			fixed += 16
+		case 2:
+			fixed += 36
+		}
+		if len(entry.payload) < fixed {
+			return
+		}
+		children, _ = rawBoxes(entry.payload[fixed:])
+	}
+
+	switch entry.typ {
+	case "avc1", "avc3":
+		if avcC, ok := findRawBox(children, "avcC"); ok && len(avcC.payload) >= 4 {
+			t.Codec = fmt.Sprintf("%s.%02x%02x%02x", entry.typ, avcC.payload[1], avcC.payload[2], avcC.payload[3])
+		}
+	case "hvc1", "hev1":
+		if hvcC, ok := findRawBox(children, "hvcC"); ok {
+			t.Codec = hevcCodec(entry.typ, hvcC.payload)
+		}
+	case "mp4a":
+		if esds, ok := findRawBox(children, "esds"); ok {
+			t.Codec = aacCodec(esds.payload)
+		}
+	}
+}
+
+// hevcCodec formats an HEVCDecoderConfigurationRecord per ISO/IEC
+// 14496-15 Annex E, e.g., "hvc1.1.6.L93.B0".
+func hevcCodec(typ string, hvcC []byte) string {
+	if len(hvcC) < 13 {
+		return typ
+	}
+
+	space := []string{"", "A", "B", "C"}[hvcC[1]>>6]
+	tier := "L"
+	if hvcC[1]&0x20 != 0 {
+		tier = "H"
+	}
+	profile := hvcC[1] & 0x1f
+
+	// compatibility flags, bit-reversed
+	var compatibility uint32
+	flags := binary.BigEndian.Uint32(hvcC[2:])
+	for i := 0; i < 32; i++ {
+		compatibility |= (flags >> i & 1) << (31 - i)
+	}
+
+	codec := fmt.Sprintf("%s.%s%d.%x.%s%d", typ, space, profile, compatibility, tier, hvcC[12])
+
+	// constraint bytes, sans trailing zeros
+	constraints := hvcC[6:12]
+	for len(constraints) > 0 && constraints[len(constraints)-1] == 0 {
+		constraints = constraints[:len(constraints)-1]
+	}
+	for _, b := range constraints {
+		codec += fmt.Sprintf(".%X", b)
+	}
+
+	return codec
+}
+
+// aacCodec formats an ES_Descriptor, e.g., "mp4a.40.2".
+func aacCodec(esds []byte) string {
+	const (
+		esDescrTag            = 0x03
+		decoderConfigDescrTag = 0x04
+		decSpecificInfoTag    = 0x05
+	)
+
+	// version and flags
+	if len(esds) < 4 {
+		return "mp4a"
+	}
+	buf := esds[4:]
+
+	descriptor := func(tag byte) ([]byte, bool) {
+		if len(buf) < 2 || buf[0] != tag {
+			return nil, false
+		}
+		buf = buf[1:]
+		var size int
+		for i := 0; i < 4 && len(buf) > 0; i++ {
+			b := buf[0]
+			buf = buf[1:]
+			size = size<<7 | int(b&0x7f)
+			if b&0x80 == 0 {
+				break
+			}
+		}
+		if size > len(buf) {
+			return nil, false
+		}
+		return buf[:size], true
+	}
+
+	es, ok := descriptor(esDescrTag)
+	if !ok || len(es) < 3 {
+		return "mp4a"
+	}
+	flags := es[2]
+	buf = es[3:]
+	if flags&0x80 != 0 {
+		// dependsOn_ES_ID
+		buf = buf[min(2, len(buf)):]
+	}
+	if flags&0x40 != 0 && len(buf) > 0 {
+		// URL
+		buf = buf[min(1+int(buf[0]), len(buf)):]
+	}
+	if flags&0x20 != 0 {
+		// OCR_ES_Id
+		buf = buf[min(2, len(buf)):]
+	}
+
+	config, ok := descriptor(decoderConfigDescrTag)
+	if !ok || len(config) < 13 {
+		return "mp4a"
+	}
+	objectType := config[0]
+	if objectType != 0x40 {
+		return fmt.Sprintf("mp4a.%02x", objectType)
+	}
+
+	buf = config[13:]
+	info, ok := descriptor(decSpecificInfoTag)
+	if !ok || len(info) < 1 {
+		return "mp4a.40"
+	}
+	audioObjectType := info[0] >> 3
+	if audioObjectType == 31 && len(info) >= 2 {
+		audioObjectType = 32 + (info[0]&0x07)<<3 | info[1]>>5
+	}
+	return fmt.Sprintf("mp4a.40.%d", audioObjectType)
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// segment partitions the tracks at keyframes of the first (i.e.,
+// video) track, roughly every segmentDuration seconds.
+func (m *Movie) segment() {
+	reference := m.Tracks[0]
+	timescale := uint64(reference.Timescale)
+	target := uint64(segmentDuration) * timescale
+
+	// segment start times, in reference timescale
+	var boundaries []uint64
+	for _, sample := range reference.Samples {
+		if len(boundaries) == 0 || (sample.Sync && sample.DTS-boundaries[len(boundaries)-1] >= target) {
+			boundaries = append(boundaries, sample.DTS)
+		}
+	}
+	last := reference.Samples[len(reference.Samples)-1]
+	end := last.DTS + uint64(last.Duration)
+	m.Duration = float64(end) / float64(timescale)
+
+	next := make([]int, len(m.Tracks))
+	for b, start := range boundaries {
+		stop := end
+		if b+1 < len(boundaries) {
+			stop = boundaries[b+1]
+		}
+
+		segment := &Segment{
+			Start:    float64(start) / float64(timescale),
+			Duration: float64(stop-start) / float64(timescale),
+			ranges:   make([][2]int, len(m.Tracks)),
+		}
+
+		for t, track := range m.Tracks {
+			first := next[t]
+			i := first
+			for i < len(track.Samples) {
+				// the last segment takes any stragglers
+				if b+1 < len(boundaries) && track.Samples[i].DTS*timescale >= stop*uint64(track.Timescale) {
+					break
+				}
+				segment.Size += int64(track.Samples[i].Size)
+				i++
+			}
+			segment.ranges[t] = [2]int{first, i}
+			next[t] = i
+		}
+
+		m.Segments = append(m.Segments, segment)
+	}
+}
+
+// Bandwidth returns the peak and average bitrates, in bits per second.
+func (m *Movie) Bandwidth() (peak int, average int) {
+	var size int64
+	for _, segment := range m.Segments {
+		size += segment.Size
+		if segment.Duration > 0 {
+			if bps := int(float64(segment.Size*8) / segment.Duration); bps > peak {
+				peak = bps
+			}
+		}
+	}
+	if m.Duration > 0 {
+		average = int(float64(size*8) / m.Duration)
+	}
+	return peak, average
+}
+
+// Codecs returns the RFC 6381 codecs of the tracks, e.g.,
+// "avc1.64001f,mp4a.40.2".
+func (m *Movie) Codecs() string {
+	var codecs []string
+	for _, track := range m.Tracks {
+		codecs = append(codecs, track.Codec)
+	}
+	return strings.Join(codecs, ",")
+}
+
+// Resolution returns the video dimensions, if any.
+func (m *Movie) Resolution() (width int, height int) {
+	for _, track := range m.Tracks {
+		if track.Handler == "vide" {
+			return track.Width, track.Height
+		}
+	}
+	return 0, 0
+}